@@ -0,0 +1,301 @@
+package infisical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Authenticator is an interface for machine identity authentication methods.
+//
+// It is called by the client whenever a new access token is needed.
+type Authenticator interface {
+	// Login logs in with given client and returns a newly-issued access token.
+	Login(ctx context.Context, c *Client) (AccessToken, error)
+}
+
+// AccessToken is a struct of access tokens issued for machine identities
+type AccessToken struct {
+	AccessToken       string `json:"accessToken"`
+	AccessTokenMaxTTL int64  `json:"accessTokenMaxTTL"`
+	ExpiresIn         int64  `json:"expiresIn"`
+	TokenType         string `json:"tokenType"`
+}
+
+// UniversalAuthToken is a struct of universal-auth token
+//
+// (kept for compatibility; same as `AccessToken`)
+type UniversalAuthToken = AccessToken
+
+// login with the client's authenticator and load the result into the client
+func (c *Client) login(ctx context.Context) (result AccessToken, err error) {
+	c.tokenLock.Lock()
+	authenticator := c.authenticator
+	c.tokenLock.Unlock()
+
+	if authenticator == nil {
+		return AccessToken{}, fmt.Errorf("failed to login: no authenticator was given")
+	}
+
+	if result, err = authenticator.Login(ctx, c); err == nil {
+		c.tokenLock.Lock()
+		c.setToken(result, true)
+		c.tokenLock.Unlock()
+
+		c.logEvent(ctx, slog.LevelInfo, "access token issued",
+			slog.String("authenticator", fmt.Sprintf("%T", authenticator)),
+			slog.Int64("expires_in", result.ExpiresIn),
+			slog.Int64("max_ttl", result.AccessTokenMaxTTL),
+		)
+
+		return result, nil
+	}
+
+	c.logEvent(ctx, slog.LevelWarn, "failed to login",
+		slog.String("authenticator", fmt.Sprintf("%T", authenticator)),
+		slog.Any("error", err),
+	)
+
+	return AccessToken{}, fmt.Errorf("failed to login: %w", err)
+}
+
+// refresh access token and load the result into the client
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/renew-access-token
+func (c *Client) refresh(ctx context.Context) (result AccessToken, err error) {
+	c.tokenLock.Lock()
+	token := c.token
+	c.tokenLock.Unlock()
+
+	if token == nil {
+		return AccessToken{}, fmt.Errorf("failed to refresh access token: no token to refresh")
+	}
+
+	if result, err = c.requestToken(ctx, "/v1/auth/token/renew", map[string]any{
+		"accessToken": token.AccessToken,
+	}); err == nil {
+		c.tokenLock.Lock()
+		c.setToken(result, false)
+		c.tokenLock.Unlock()
+
+		c.logEvent(ctx, slog.LevelInfo, "access token renewed",
+			slog.Int64("expires_in", result.ExpiresIn),
+		)
+
+		return result, nil
+	}
+
+	c.logEvent(ctx, slog.LevelWarn, "failed to renew access token",
+		slog.Any("error", err),
+	)
+
+	return AccessToken{}, fmt.Errorf("failed to refresh access token: %w", err)
+}
+
+// Logout revokes the current access token of the client (if any), and discards it.
+//
+// The client can still be used after it, by logging in again.
+//
+// NOTE: static access tokens of `AccessTokenAuth` are only discarded, not revoked.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/revoke-access-token
+func (c *Client) Logout() error {
+	return c.LogoutWithContext(context.Background())
+}
+
+// LogoutWithContext is the same as `Logout` with given context.
+func (c *Client) LogoutWithContext(ctx context.Context) (err error) {
+	c.tokenLock.Lock()
+	for c.tokenFlight != nil { // wait for the login/renewal in flight
+		flight := c.tokenFlight
+		c.tokenLock.Unlock()
+
+		select {
+		case <-flight.done:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to logout: %w", ctx.Err())
+		}
+
+		c.tokenLock.Lock()
+	}
+	token := c.token
+	expired := !c.tokenExpiresOn.IsZero() && !time.Now().Before(c.tokenExpiresOn)
+	_, static := c.authenticator.(*AccessTokenAuth)
+	c.clearToken()
+	c.tokenLock.Unlock()
+
+	if token == nil || expired || static {
+		return nil
+	}
+
+	if err = c.revokeToken(ctx, token.AccessToken); err != nil {
+		c.logEvent(ctx, slog.LevelWarn, "failed to revoke access token",
+			slog.Any("error", err),
+		)
+
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+
+	c.logEvent(ctx, slog.LevelInfo, "access token revoked")
+
+	return nil
+}
+
+// Close revokes the current access token of the client, and discards it.
+//
+// It is the same as `Logout`, for using with `defer` (or as an `io.Closer`).
+func (c *Client) Close() error {
+	return c.Logout()
+}
+
+// setToken loads given access token into the client
+//
+// `issued` is true when the token was newly issued by a login, not renewed.
+//
+// NOTE: `tokenLock` should be held by the caller.
+func (c *Client) setToken(token AccessToken, issued bool) {
+	now := time.Now()
+
+	c.token = &AccessToken{
+		AccessToken:       token.AccessToken,
+		AccessTokenMaxTTL: token.AccessTokenMaxTTL,
+		ExpiresIn:         token.ExpiresIn,
+		TokenType:         token.TokenType,
+	}
+	if issued {
+		if token.AccessTokenMaxTTL > 0 {
+			c.tokenMaxExpiresOn = now.Add(time.Second * time.Duration(token.AccessTokenMaxTTL))
+		} else {
+			c.tokenMaxExpiresOn = time.Time{} // no max TTL
+		}
+	}
+	if token.ExpiresIn > 0 {
+		c.tokenExpiresOn = now.Add(time.Second * time.Duration(token.ExpiresIn))
+		if !c.tokenMaxExpiresOn.IsZero() && c.tokenExpiresOn.After(c.tokenMaxExpiresOn) {
+			c.tokenExpiresOn = c.tokenMaxExpiresOn
+		}
+	} else {
+		c.tokenExpiresOn = time.Time{} // never expires
+	}
+}
+
+// clearToken discards the current token.
+//
+// NOTE: `tokenLock` should be held by the caller.
+func (c *Client) clearToken() {
+	c.token = nil
+	c.tokenExpiresOn = time.Time{}
+	c.tokenMaxExpiresOn = time.Time{}
+}
+
+// requestToken sends a login(or renew) request with given JSON body and returns the issued access token.
+//
+// NOTE: login requests are always retried with the client's retry policy.
+func (c *Client) requestToken(ctx context.Context, path string, params map[string]any) (result AccessToken, err error) {
+	var encoded []byte
+	if encoded, err = json.Marshal(params); err == nil {
+		var req *http.Request
+		if req, err = http.NewRequestWithContext(ctx, "POST", c.requestURL(path), bytes.NewReader(encoded)); err == nil {
+			req.Header.Set("Content-Type", "application/json")
+
+			var res *http.Response
+			if res, err = c.doWithRetry(req, true); err == nil {
+				if err = c.parseResponse(res, &result); err == nil {
+					return result, nil
+				}
+			}
+		}
+	}
+
+	return AccessToken{}, err
+}
+
+// revokeToken sends a request for revoking given access token.
+//
+// (the request itself is not authorized with the client's token)
+func (c *Client) revokeToken(ctx context.Context, accessToken string) (err error) {
+	var encoded []byte
+	if encoded, err = json.Marshal(map[string]any{
+		"accessToken": accessToken,
+	}); err == nil {
+		var req *http.Request
+		if req, err = http.NewRequestWithContext(ctx, "POST", c.requestURL("/v1/auth/token/revoke"), bytes.NewReader(encoded)); err == nil {
+			req.Header.Set("Content-Type", "application/json")
+
+			var res *http.Response
+			if res, err = c.do(req); err == nil {
+				var result struct {
+					Message string `json:"message"`
+				}
+				return c.parseResponse(res, &result)
+			}
+		}
+	}
+
+	return err
+}
+
+// loginWithJWT logs in to given login endpoint with an identity id and a JWT.
+//
+// (for authentication methods which verify JWTs from the platforms)
+func (c *Client) loginWithJWT(ctx context.Context, path, identityID, jwt string) (AccessToken, error) {
+	return c.requestToken(ctx, path, map[string]any{
+		"identityId": identityID,
+		"jwt":        jwt,
+	})
+}
+
+// AccessTokenAuth is an authenticator with a static access token
+type AccessTokenAuth struct {
+	accessToken string
+}
+
+// NewAccessTokenAuth returns a new authenticator which uses given access token as it is.
+//
+// The token will never be renewed by the client.
+func NewAccessTokenAuth(accessToken string) *AccessTokenAuth {
+	return &AccessTokenAuth{
+		accessToken: accessToken,
+	}
+}
+
+// Login returns the static access token.
+func (a *AccessTokenAuth) Login(ctx context.Context, c *Client) (AccessToken, error) {
+	if a.accessToken == "" {
+		return AccessToken{}, fmt.Errorf("access token is empty")
+	}
+
+	return AccessToken{
+		AccessToken: a.accessToken,
+		TokenType:   "Bearer",
+	}, nil
+}
+
+// maximum length of response bodies in errors of metadata servers
+const maxMetadataErrorBodyLength = 256
+
+// metadataError creates an error from a non-200 response of platforms' metadata servers.
+//
+// (not an `APIError`, which is only for responses from Infisical)
+func metadataError(res *http.Response, body []byte) error {
+	trimmed := strings.TrimSpace(string(body))
+	if len(trimmed) > maxMetadataErrorBodyLength {
+		trimmed = trimmed[:maxMetadataErrorBodyLength] + "..."
+	}
+	return fmt.Errorf("metadata server responded with status %d: %s", res.StatusCode, trimmed)
+}
+
+// metadataHTTPClient returns the http client for fetching tokens from platforms' metadata servers.
+func metadataHTTPClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{
+		Timeout: TimeoutSeconds * time.Second,
+	}
+}
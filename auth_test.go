@@ -0,0 +1,205 @@
+package infisical
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// run a stand-in server of login endpoints and platforms' metadata servers
+func newAuthTestServer() *httptest.Server {
+	issue := func(w http.ResponseWriter, accessToken string) {
+		_ = json.NewEncoder(w).Encode(AccessToken{
+			AccessToken:       accessToken,
+			AccessTokenMaxTTL: 3600,
+			ExpiresIn:         600,
+			TokenType:         "Bearer",
+		})
+	}
+	loginWithJWT := func(method, expectedJWT string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil ||
+				body["identityId"] != "test-identity-id" ||
+				body["jwt"] != expectedJWT {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			issue(w, method+"-access-token")
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	// login endpoints
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil ||
+			body["clientId"] != "test-client-id" ||
+			body["clientSecret"] != "test-client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issue(w, "universal-access-token")
+	})
+	mux.HandleFunc("/api/v1/auth/kubernetes-auth/login", loginWithJWT("kubernetes", "test-service-account-token"))
+	mux.HandleFunc("/api/v1/auth/gcp-auth/login", loginWithJWT("gcp", "test-gcp-id-token"))
+	mux.HandleFunc("/api/v1/auth/azure-auth/login", loginWithJWT("azure", "test-azure-access-token"))
+	mux.HandleFunc("/api/v1/auth/oidc-auth/login", loginWithJWT("oidc", "test-oidc-token"))
+	mux.HandleFunc("/api/v1/auth/aws-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil ||
+			body["identityId"] != "test-identity-id" ||
+			body["iamHttpRequestMethod"] != "POST" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		requestBody, _ := base64.StdEncoding.DecodeString(body["iamRequestBody"])
+		encodedHeaders, _ := base64.StdEncoding.DecodeString(body["iamRequestHeaders"])
+		var headers map[string]string
+		if err := json.Unmarshal(encodedHeaders, &headers); err != nil ||
+			string(requestBody) != awsSTSRequestBody ||
+			headers["Host"] != "sts.ap-northeast-2.amazonaws.com" ||
+			!strings.HasPrefix(headers["Authorization"], "AWS4-HMAC-SHA256 Credential=TESTACCESSKEYID/") ||
+			headers["X-Amz-Security-Token"] != "test-session-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issue(w, "aws-access-token")
+	})
+
+	// metadata servers
+	mux.HandleFunc("/computeMetadata/v1/instance/service-accounts/default/identity", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" || r.URL.Query().Get("audience") != "test-identity-id" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("test-gcp-id-token"))
+	})
+	mux.HandleFunc("/metadata/identity/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") != DefaultAzureResource {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "test-azure-access-token"})
+	})
+
+	// an endpoint which echoes the access token
+	mux.HandleFunc("/api/v3/secrets/raw/TOKEN", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SecretData{
+			Secret: Secret{
+				SecretKey:   "TOKEN",
+				SecretValue: strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+			},
+		})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestAuthenticators(t *testing.T) {
+	server := newAuthTestServer()
+	defer server.Close()
+
+	// service account token file for kubernetes auth
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte("test-service-account-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write service account token: %s", err)
+	}
+	kubernetes := NewKubernetesAuth("test-identity-id")
+	kubernetes.ServiceAccountTokenPath = tokenPath
+
+	aws := NewAWSIAMAuth("test-identity-id")
+	aws.Region = "ap-northeast-2"
+	aws.Credentials = func(ctx context.Context) (AWSCredentials, error) {
+		return AWSCredentials{
+			AccessKeyID:     "TESTACCESSKEYID",
+			SecretAccessKey: "test-secret-access-key",
+			SessionToken:    "test-session-token",
+		}, nil
+	}
+
+	gcp := NewGCPIDTokenAuth("test-identity-id")
+	gcp.MetadataURL = server.URL
+
+	azure := NewAzureAuth("test-identity-id")
+	azure.MetadataURL = server.URL
+
+	oidc := NewOIDCAuth("test-identity-id", func(ctx context.Context) (string, error) {
+		return "test-oidc-token", nil
+	})
+
+	for expected, authenticator := range map[string]Authenticator{
+		"universal-access-token":  NewUniversalAuth("test-client-id", "test-client-secret"),
+		"static-access-token":     NewAccessTokenAuth("static-access-token"),
+		"kubernetes-access-token": kubernetes,
+		"aws-access-token":        aws,
+		"gcp-access-token":        gcp,
+		"azure-access-token":      azure,
+		"oidc-access-token":       oidc,
+	} {
+		client := NewClientWithAuthenticator(authenticator)
+		client.SetAPIBaseURL(server.URL)
+
+		if value, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/TOKEN"); err != nil {
+			t.Errorf("failed to retrieve a secret with %T: %s", authenticator, err)
+		} else if value != expected {
+			t.Errorf("request with %T was not authorized with the expected token: '%s' vs '%s'", authenticator, value, expected)
+		}
+	}
+
+	// (wrong credentials)
+	client := NewClientWithAuthenticator(NewUniversalAuth("test-client-id", "wrong-client-secret"))
+	client.SetAPIBaseURL(server.URL)
+	if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/TOKEN"); err == nil {
+		t.Errorf("login with wrong credentials should have failed")
+	}
+
+	// (errors of metadata servers are not taken as errors of Infisical)
+	wrongGCP := NewGCPIDTokenAuth("wrong-identity-id")
+	wrongGCP.MetadataURL = server.URL
+	wrongAzure := NewAzureAuth("wrong-identity-id")
+	wrongAzure.MetadataURL = server.URL
+	wrongAzure.Resource = "https://wrong.resource"
+	for _, authenticator := range []Authenticator{wrongGCP, wrongAzure} {
+		client := NewClientWithAuthenticator(authenticator)
+		client.SetAPIBaseURL(server.URL)
+
+		var apiErr *APIError
+		if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/TOKEN"); err == nil {
+			t.Errorf("login with %T should have failed", authenticator)
+		} else if errors.As(err, &apiErr) || errors.Is(err, ErrForbidden) || isServerUnavailable(err) {
+			t.Errorf("error of the metadata server should not be an `APIError`: %v", err)
+		} else if !strings.Contains(err.Error(), "metadata server responded with status") {
+			t.Errorf("unexpected error of the metadata server: %v", err)
+		}
+	}
+}
+
+func TestAWSSigningKey(t *testing.T) {
+	// https://docs.aws.amazon.com/general/latest/gr/signature-v4-examples.html
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if expected := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"; hex.EncodeToString(key) != expected {
+		t.Errorf("derived signing key differs from the expected one: %s vs %s", hex.EncodeToString(key), expected)
+	}
+
+	headers := signAWSSTSRequest(AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	if headers["X-Amz-Date"] != "20240102T030405Z" {
+		t.Errorf("wrong `X-Amz-Date` header: %s", headers["X-Amz-Date"])
+	}
+	if !strings.HasPrefix(headers["Authorization"], "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/sts/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=") {
+		t.Errorf("wrong `Authorization` header: %s", headers["Authorization"])
+	}
+}
@@ -0,0 +1,178 @@
+package infisical
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsSTSRequestBody = "Action=GetCallerIdentity&Version=2011-06-15"
+	awsSTSContentType = "application/x-www-form-urlencoded; charset=utf-8"
+	awsDefaultRegion  = "us-east-1"
+)
+
+// AWSCredentials struct for AWS credentials
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional
+}
+
+// AWSCredentialsFromEnv reads AWS credentials from environment variables:
+// `AWS_ACCESS_KEY_ID`, `AWS_SECRET_ACCESS_KEY`, and `AWS_SESSION_TOKEN`.
+func AWSCredentialsFromEnv(ctx context.Context) (AWSCredentials, error) {
+	creds := AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return AWSCredentials{}, fmt.Errorf("no environment variables: `AWS_ACCESS_KEY_ID` or `AWS_SECRET_ACCESS_KEY` were found")
+	}
+	return creds, nil
+}
+
+// AWSIAMAuth is an authenticator with AWS IAM principals
+type AWSIAMAuth struct {
+	identityID string
+
+	// AWS region of the STS endpoint (default: `AWS_REGION` environment variable, or "us-east-1")
+	Region string
+
+	// function for fetching AWS credentials (default: `AWSCredentialsFromEnv`)
+	Credentials func(ctx context.Context) (AWSCredentials, error)
+}
+
+// NewAWSIAMAuth returns a new aws-auth authenticator for given identity id.
+func NewAWSIAMAuth(identityID string) *AWSIAMAuth {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = awsDefaultRegion
+	}
+
+	return &AWSIAMAuth{
+		identityID: identityID,
+
+		Region:      region,
+		Credentials: AWSCredentialsFromEnv,
+	}
+}
+
+// Login logs in with a signed `sts:GetCallerIdentity` request, which is verified by Infisical.
+//
+// https://infisical.com/docs/api-reference/endpoints/aws-auth/login
+func (a *AWSIAMAuth) Login(ctx context.Context, c *Client) (result AccessToken, err error) {
+	if a.Credentials == nil {
+		return AccessToken{}, fmt.Errorf("no AWS credentials function was given")
+	}
+
+	var creds AWSCredentials
+	if creds, err = a.Credentials(ctx); err != nil {
+		return AccessToken{}, fmt.Errorf("failed to fetch AWS credentials: %w", err)
+	}
+
+	var headers []byte
+	if headers, err = json.Marshal(signAWSSTSRequest(creds, a.Region, time.Now().UTC())); err != nil {
+		return AccessToken{}, err
+	}
+
+	return c.requestToken(ctx, "/v1/auth/aws-auth/login", map[string]any{
+		"identityId":           a.identityID,
+		"iamHttpRequestMethod": "POST",
+		"iamRequestBody":       base64.StdEncoding.EncodeToString([]byte(awsSTSRequestBody)),
+		"iamRequestHeaders":    base64.StdEncoding.EncodeToString(headers),
+	})
+}
+
+// signAWSSTSRequest returns headers of a `sts:GetCallerIdentity` request signed with AWS Signature Version 4.
+//
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+func signAWSSTSRequest(creds AWSCredentials, region string, now time.Time) map[string]string {
+	const service = "sts"
+
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	host := fmt.Sprintf("sts.%s.amazonaws.com", region)
+
+	headers := map[string]string{
+		"Content-Type":   awsSTSContentType,
+		"Content-Length": fmt.Sprintf("%d", len(awsSTSRequestBody)),
+		"Host":           host,
+		"X-Amz-Date":     amzDate,
+	}
+	if creds.SessionToken != "" {
+		headers["X-Amz-Security-Token"] = creds.SessionToken
+	}
+
+	// canonical headers (all headers except `Content-Length`)
+	names := []string{}
+	canonical := map[string]string{}
+	for k, v := range headers {
+		if k == "Content-Length" {
+			continue
+		}
+		name := strings.ToLower(k)
+		names = append(names, name)
+		canonical[name] = strings.TrimSpace(v)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + canonical[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		"POST",
+		"/",
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex([]byte(awsSTSRequestBody)),
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(creds.SecretAccessKey, date, region, service), []byte(stringToSign)))
+
+	headers["Authorization"] = fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", creds.AccessKeyID, scope, signedHeaders, signature)
+
+	return headers
+}
+
+// awsSigningKey derives a signing key for AWS Signature Version 4.
+func awsSigningKey(secretAccessKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), []byte(date))
+	key = hmacSHA256(key, []byte(region))
+	key = hmacSHA256(key, []byte(service))
+	return hmacSHA256(key, []byte("aws4_request"))
+}
+
+// hmacSHA256 returns HMAC-SHA256 of given data.
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// sha256Hex returns hex-encoded SHA256 of given data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
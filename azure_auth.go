@@ -0,0 +1,88 @@
+package infisical
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultAzureMetadataURL is the default base URL of the Azure instance metadata service
+	DefaultAzureMetadataURL = "http://169.254.169.254"
+
+	// DefaultAzureResource is the default resource of the managed identity's access token
+	DefaultAzureResource = "https://management.azure.com/"
+)
+
+// AzureAuth is an authenticator with access tokens of Azure managed identities
+type AzureAuth struct {
+	identityID string
+
+	// resource of the access token, must match the one configured on Infisical
+	Resource string
+
+	// base URL of the instance metadata service
+	MetadataURL string
+
+	// http client for requesting the instance metadata service (optional)
+	HTTPClient *http.Client
+}
+
+// NewAzureAuth returns a new azure-auth authenticator for given identity id.
+func NewAzureAuth(identityID string) *AzureAuth {
+	return &AzureAuth{
+		identityID: identityID,
+
+		Resource:    DefaultAzureResource,
+		MetadataURL: DefaultAzureMetadataURL,
+	}
+}
+
+// Login logs in with an access token fetched from the Azure instance metadata service.
+//
+// https://infisical.com/docs/api-reference/endpoints/azure-auth/login
+func (a *AzureAuth) Login(ctx context.Context, c *Client) (result AccessToken, err error) {
+	var jwt string
+	if jwt, err = a.fetchAccessToken(ctx); err != nil {
+		return AccessToken{}, fmt.Errorf("failed to fetch Azure access token: %w", err)
+	}
+
+	return c.loginWithJWT(ctx, "/v1/auth/azure-auth/login", a.identityID, jwt)
+}
+
+// fetch an access token of the managed identity from the instance metadata service
+func (a *AzureAuth) fetchAccessToken(ctx context.Context) (token string, err error) {
+	query := url.Values{}
+	query.Set("api-version", "2018-02-01")
+	query.Set("resource", a.Resource)
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/metadata/identity/oauth2/token?%s", strings.TrimSuffix(a.MetadataURL, "/"), query.Encode()), nil); err == nil {
+		req.Header.Set("Metadata", "true")
+
+		var res *http.Response
+		if res, err = metadataHTTPClient(a.HTTPClient).Do(req); err == nil {
+			defer res.Body.Close()
+
+			var body []byte
+			if body, err = io.ReadAll(res.Body); err == nil {
+				if res.StatusCode != http.StatusOK {
+					return "", metadataError(res, body)
+				}
+
+				var parsed struct {
+					AccessToken string `json:"access_token"`
+				}
+				if err = json.Unmarshal(body, &parsed); err == nil {
+					return parsed.AccessToken, nil
+				}
+			}
+		}
+	}
+
+	return "", err
+}
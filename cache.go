@@ -0,0 +1,261 @@
+package infisical
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretCachePolicy struct for caching retrieved & listed secrets
+type SecretCachePolicy struct {
+	// time-to-live of cached entries (never expire if <= 0)
+	TTL time.Duration
+
+	// maximum number of cached entries; least recently used ones are evicted first (no bound if <= 0)
+	MaxEntries int
+
+	// serve expired entries when Infisical is unreachable (network errors, 5xx, or 429 responses)
+	StaleIfError bool
+
+	// maximum staleness (time passed after expiry) of entries served with `StaleIfError` (no bound if <= 0)
+	MaxStaleness time.Duration
+}
+
+// DefaultSecretCachePolicy returns a secret cache policy with sane defaults.
+func DefaultSecretCachePolicy() *SecretCachePolicy {
+	return &SecretCachePolicy{
+		TTL:          1 * time.Minute,
+		MaxEntries:   1000,
+		StaleIfError: true,
+		MaxStaleness: 1 * time.Hour,
+	}
+}
+
+// WithSecretCache enables the read-through cache of `RetrieveSecret` and `ListSecrets` with given policy.
+//
+// Cached entries are invalidated automatically after this client's own `CreateSecret`, `UpdateSecret`, and `DeleteSecret`;
+// changes made by others are visible only after `TTL`, or explicit invalidation.
+func WithSecretCache(policy *SecretCachePolicy) Option {
+	return func(cfg *clientConfig) error {
+		cfg.secretCachePolicy = policy
+		return nil
+	}
+}
+
+// SetSecretCache changes the policy of the secret cache, dropping all cached entries.
+//
+// (`nil` for not caching at all)
+func (c *Client) SetSecretCache(policy *SecretCachePolicy) {
+	c.secretCache = newSecretCache(policy)
+}
+
+// InvalidateSecretCache drops cached entries of given workspace, environment, and secret path.
+func (c *Client) InvalidateSecretCache(workspaceID, environment, secretPath string) {
+	c.secretCache.invalidate(func(key secretCacheKey) bool {
+		return key.workspaceID == workspaceID &&
+			key.environment == environment &&
+			key.secretPath == normalizeSecretPath(secretPath)
+	})
+}
+
+// invalidateWorkspaceCache drops all cached entries of given workspace.
+func (c *Client) invalidateWorkspaceCache(workspaceID string) {
+	c.secretCache.invalidate(func(key secretCacheKey) bool {
+		return key.workspaceID == workspaceID
+	})
+}
+
+// ClearSecretCache drops all cached entries.
+func (c *Client) ClearSecretCache() {
+	c.secretCache.invalidate(func(key secretCacheKey) bool {
+		return true
+	})
+}
+
+// secretCacheKey struct for keys of cached entries
+type secretCacheKey struct {
+	workspaceID string
+	environment string
+	secretPath  string
+	secretType  SecretType // empty for listed secrets
+	secretKey   string     // empty for listed secrets
+
+	includeImports bool
+	tagSlugs       string // for listed secrets
+}
+
+// secretCacheEntry struct for cached entries
+type secretCacheEntry struct {
+	key      secretCacheKey
+	value    any
+	storedAt time.Time
+}
+
+// secretCache is a LRU cache of secrets, safe for concurrent use
+type secretCache struct {
+	policy SecretCachePolicy
+
+	lock    sync.Mutex
+	entries map[secretCacheKey]*list.Element
+	lru     *list.List // front: most recently used
+}
+
+// newSecretCache creates a new secret cache with given policy.
+//
+// (returns nil if `policy` is nil)
+func newSecretCache(policy *SecretCachePolicy) *secretCache {
+	if policy == nil {
+		return nil
+	}
+	return &secretCache{
+		policy:  *policy,
+		entries: map[secretCacheKey]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// lookup returns a cached value which is not expired.
+func (sc *secretCache) lookup(key secretCacheKey) (value any, found bool) {
+	return sc.load(key, func(age time.Duration) bool {
+		return sc.policy.TTL <= 0 || age < sc.policy.TTL
+	})
+}
+
+// lookupStale returns a cached value for serving when fetching failed with given error.
+func (sc *secretCache) lookupStale(key secretCacheKey, err error) (value any, found bool) {
+	if sc == nil || !sc.policy.StaleIfError || !isServerUnavailable(err) {
+		return nil, false
+	}
+	return sc.load(key, func(age time.Duration) bool {
+		return sc.policy.TTL <= 0 || sc.policy.MaxStaleness <= 0 || age < sc.policy.TTL+sc.policy.MaxStaleness
+	})
+}
+
+// load returns a cached value whose age is accepted by `acceptable`.
+func (sc *secretCache) load(key secretCacheKey, acceptable func(age time.Duration) bool) (value any, found bool) {
+	if sc == nil {
+		return nil, false
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if elem, exists := sc.entries[key]; exists {
+		entry := elem.Value.(*secretCacheEntry)
+		if acceptable(time.Since(entry.storedAt)) {
+			sc.lru.MoveToFront(elem)
+			return entry.value, true
+		}
+	}
+	return nil, false
+}
+
+// store caches given value, evicting the least recently used entries over `MaxEntries`.
+func (sc *secretCache) store(key secretCacheKey, value any) {
+	if sc == nil {
+		return
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if elem, exists := sc.entries[key]; exists {
+		entry := elem.Value.(*secretCacheEntry)
+		entry.value, entry.storedAt = value, time.Now()
+		sc.lru.MoveToFront(elem)
+		return
+	}
+
+	sc.entries[key] = sc.lru.PushFront(&secretCacheEntry{
+		key:      key,
+		value:    value,
+		storedAt: time.Now(),
+	})
+	for sc.policy.MaxEntries > 0 && sc.lru.Len() > sc.policy.MaxEntries {
+		oldest := sc.lru.Back()
+		sc.lru.Remove(oldest)
+		delete(sc.entries, oldest.Value.(*secretCacheEntry).key)
+	}
+}
+
+// invalidate drops entries whose keys match `matches`.
+func (sc *secretCache) invalidate(matches func(key secretCacheKey) bool) {
+	if sc == nil {
+		return
+	}
+
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	for key, elem := range sc.entries {
+		if matches(key) {
+			sc.lru.Remove(elem)
+			delete(sc.entries, key)
+		}
+	}
+}
+
+// invalidateSecret drops cached entries which may contain given secret.
+//
+// (listed secrets of the whole workspace are dropped, as they may contain it through secret imports)
+func (sc *secretCache) invalidateSecret(workspaceID, environment, secretPath, secretKey string) {
+	sc.invalidate(func(key secretCacheKey) bool {
+		if key.workspaceID != workspaceID {
+			return false
+		}
+		if key.secretKey == "" {
+			return true
+		}
+		return key.environment == environment &&
+			key.secretPath == normalizeSecretPath(secretPath) &&
+			key.secretKey == secretKey
+	})
+}
+
+// logStale logs that a stale value is served from the cache.
+func (c *Client) logStale(ctx context.Context, key secretCacheKey, err error) {
+	c.logEvent(ctx, slog.LevelWarn, "serving stale secrets from cache",
+		slog.String("workspace_id", key.workspaceID),
+		slog.String("environment", key.environment),
+		slog.String("secret_path", key.secretPath),
+		slog.String("secret_key", key.secretKey),
+		slog.Any("error", err),
+	)
+}
+
+// normalizeSecretPath normalizes given secret path for cache keys, eg. "" => "/", "folder/" => "/folder".
+func normalizeSecretPath(secretPath string) string {
+	return "/" + strings.Trim(secretPath, "/")
+}
+
+// cloneSecretsData returns a copy of given secrets data, for not sharing slices with the cache.
+func cloneSecretsData(data SecretsData) SecretsData {
+	cloned := SecretsData{
+		Secrets: cloneSecrets(data.Secrets),
+	}
+	if data.Imports != nil {
+		cloned.Imports = make([]SecretImport, len(data.Imports))
+		for i, imported := range data.Imports {
+			imported.Secrets = cloneSecrets(imported.Secrets)
+			cloned.Imports[i] = imported
+		}
+	}
+	return cloned
+}
+
+// cloneSecrets returns a copy of given secrets.
+func cloneSecrets(secrets []Secret) (cloned []Secret) {
+	for _, secret := range secrets {
+		cloned = append(cloned, cloneSecret(secret))
+	}
+	return cloned
+}
+
+// cloneSecret returns a copy of given secret.
+func cloneSecret(secret Secret) Secret {
+	secret.Tags = append([]SecretTag(nil), secret.Tags...)
+	return secret
+}
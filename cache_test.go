@@ -0,0 +1,145 @@
+package infisical
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestSecretCache(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	workspace := server.AddWorkspace("test-workspace", "dev")
+	server.SetSecret(workspace.ID, "dev", "/", "KEY1", "value1")
+	server.SetSecret(workspace.ID, "dev", "/", "KEY2", "value2")
+
+	const ttl = 100 * time.Millisecond
+
+	client, err := New(
+		WithUniversalAuth(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret),
+		WithBaseURL(server.URL),
+		WithRetryPolicy(nil),
+		WithSecretCache(&SecretCachePolicy{
+			TTL:          ttl,
+			MaxEntries:   2,
+			StaleIfError: true,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+
+	retrieve := func(key string) string {
+		value, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/"+key)
+		if err != nil {
+			t.Errorf("failed to retrieve secret '%s': %s", key, err)
+		}
+		return value
+	}
+	countRequests := func(key string) int {
+		return server.CountRequests("GET", "/api/v3/secrets/raw/"+key)
+	}
+
+	// (cache hits)
+	for i := 0; i < 3; i++ {
+		if value := retrieve("KEY1"); value != "value1" {
+			t.Errorf("unexpected value: %s", value)
+		}
+	}
+	if count := countRequests("KEY1"); count != 1 {
+		t.Errorf("cached secret should have been retrieved only once: %d", count)
+	}
+
+	// (changes by others are not visible until invalidated)
+	server.SetSecret(workspace.ID, "dev", "/", "KEY1", "changed1")
+	if value := retrieve("KEY1"); value != "value1" {
+		t.Errorf("cached value should have been returned: %s", value)
+	}
+	client.InvalidateSecretCache(workspace.ID, "dev", "/")
+	if value := retrieve("KEY1"); value != "changed1" {
+		t.Errorf("changed value should have been returned after invalidation: %s", value)
+	}
+
+	// (own updates invalidate cached secrets & lists)
+	params := NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev")
+	if listed, err := client.ListSecrets(params); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	} else {
+		listed.Secrets[0].SecretValue = "mutated" // should not affect the cache
+	}
+	if err := client.UpdateSecret(workspace.ID, "dev", "KEY1", "updated1", NewParamsUpdateSecret()); err != nil {
+		t.Errorf("failed to update secret: %s", err)
+	}
+	if value := retrieve("KEY1"); value != "updated1" {
+		t.Errorf("updated value should have been returned: %s", value)
+	}
+	if listed, err := client.ListSecrets(params); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	} else if count := server.CountRequests("GET", "/api/v3/secrets/raw"); count != 2 {
+		t.Errorf("listed secrets should have been invalidated: %d", count)
+	} else {
+		for _, secret := range listed.Secrets {
+			if secret.SecretValue == "mutated" {
+				t.Errorf("cached secrets should not be mutated by callers")
+			}
+		}
+	}
+
+	// (size bound)
+	client.ClearSecretCache()
+	before := countRequests("KEY1")
+	retrieve("KEY1")
+	retrieve("KEY2")
+	if _, err := client.ListSecrets(params); err != nil { // evicts KEY1
+		t.Errorf("failed to list secrets: %s", err)
+	}
+	retrieve("KEY1")
+	if count := countRequests("KEY1") - before; count != 2 {
+		t.Errorf("least recently used secret should have been evicted: %d", count)
+	}
+
+	// (expiry)
+	time.Sleep(ttl)
+	before = countRequests("KEY1")
+	retrieve("KEY1")
+	if count := countRequests("KEY1") - before; count != 1 {
+		t.Errorf("expired secret should have been retrieved again: %d", count)
+	}
+
+	// (stale-if-error)
+	time.Sleep(ttl)
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	})
+	if value := retrieve("KEY1"); value != "updated1" {
+		t.Errorf("stale value should have been served while the server is unavailable: %s", value)
+	}
+	server.ClearFaults()
+
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusNotFound,
+	})
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err == nil {
+		t.Errorf("stale value should not be served for client errors")
+	}
+	server.ClearFaults()
+
+	// (no stale values without `StaleIfError`)
+	client.SetSecretCache(&SecretCachePolicy{TTL: ttl})
+	retrieve("KEY1")
+	time.Sleep(ttl)
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	})
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err == nil {
+		t.Errorf("stale value should not be served without `StaleIfError`")
+	}
+}
@@ -1,56 +1,145 @@
 package infisical
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// DefaultTokenRenewalMargin is the default margin before the expiry of access tokens for renewing them
+const DefaultTokenRenewalMargin = 30 * time.Second
+
 // Client struct
 type Client struct {
 	// API Key (optional)
 	apiKey *string
 
-	// universal-auth token
-	clientID       string
-	clientSecret   string
-	token          *UniversalAuthToken
-	tokenExpiresOn time.Time
+	// machine identity authentication (guarded by `tokenLock`)
+	tokenLock          sync.Mutex
+	authenticator      Authenticator
+	token              *AccessToken
+	tokenExpiresOn     time.Time
+	tokenMaxExpiresOn  time.Time
+	tokenRenewalMargin time.Duration
+	tokenFlight        *tokenFlight
+
+	// rotation of universal-auth client secret (optional)
+	rotation *clientSecretRotation
+
+	httpClient   *http.Client
+	retryPolicy  *RetryPolicy
+	secretCache  *secretCache
+	offlineCache *offlineCache
 
-	httpClient *http.Client
+	baseURL   string
+	userAgent string
+	headers   http.Header
 
-	baseURL string
+	logger          *slog.Logger
+	unsafeDebugDump bool
 
-	Verbose bool // NOTE: set `true` for dumping http requests & responses
+	// Deprecated: use `WithLogger` instead.
+	//
+	// NOTE: set `true` for logging redacted dumps of http requests & responses in info level (with `slog.Default()` if no logger is set)
+	Verbose bool
 }
 
-// NewClient creates a new client and return it.
-func NewClient(apiKey, clientID, clientSecret string) *Client {
+// New creates and returns a new client configured with given options.
+//
+// eg.
+//
+//	client, err := infisical.New(
+//		infisical.WithUniversalAuth(clientID, clientSecret),
+//		infisical.WithBaseURL("https://infisical.example.com"),
+//		infisical.WithCABundleFile("/etc/ssl/internal-ca.pem"),
+//	)
+func New(opts ...Option) (*Client, error) {
+	cfg := &clientConfig{
+		baseURL:            DefaultAPIBaseURL,
+		headers:            http.Header{},
+		tokenRenewalMargin: DefaultTokenRenewalMargin,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, fmt.Errorf("failed to configure client: %w", err)
+		}
+	}
+
+	httpClient, err := cfg.buildHTTPClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure client: %w", err)
+	}
+	rotation, authenticator, err := newClientSecretRotation(cfg.rotationPolicy, cfg.authenticator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure client: %w", err)
+	}
+	if rotation != nil && cfg.offlineCachePolicy != nil && len(cfg.offlineCachePolicy.Key) == 0 {
+		return nil, fmt.Errorf("failed to configure client: offline cache requires a key when client secret is rotated")
+	}
+	offlineCache, err := newOfflineCache(cfg.offlineCachePolicy, authenticator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure client: %w", err)
+	}
+
 	return &Client{
-		apiKey: &apiKey,
+		apiKey: cfg.apiKey,
 
-		clientID:     clientID,
-		clientSecret: clientSecret,
+		authenticator:      authenticator,
+		tokenRenewalMargin: cfg.tokenRenewalMargin,
+		rotation:           rotation,
 
-		httpClient: &http.Client{
-			Timeout: TimeoutSeconds * time.Second,
-		},
+		httpClient:   httpClient,
+		retryPolicy:  cfg.retryPolicy,
+		secretCache:  newSecretCache(cfg.secretCachePolicy),
+		offlineCache: offlineCache,
 
-		baseURL: DefaultAPIBaseURL,
-	}
+		baseURL:   cfg.baseURL,
+		userAgent: cfg.userAgent,
+		headers:   cfg.headers,
+
+		logger:          cfg.logger,
+		unsafeDebugDump: cfg.unsafeDebugDump,
+	}, nil
+}
+
+// NewClient creates a new client and return it.
+func NewClient(apiKey, clientID, clientSecret string) *Client {
+	client, _ := New(WithAPIKey(apiKey), WithUniversalAuth(clientID, clientSecret))
+	return client
 }
 
 // NewClientWithoutAPIKey creates and returns a new client only with tokens.
 func NewClientWithoutAPIKey(clientID, clientSecret string) *Client {
-	return &Client{
-		clientID:     clientID,
-		clientSecret: clientSecret,
+	client, _ := New(WithUniversalAuth(clientID, clientSecret))
+	return client
+}
 
-		httpClient: &http.Client{
-			Timeout: TimeoutSeconds * time.Second,
-		},
+// NewClientWithAuthenticator creates and returns a new client which logs in with given authenticator.
+//
+// (eg. `NewKubernetesAuth`, `NewAWSIAMAuth`, `NewAccessTokenAuth`, ...)
+func NewClientWithAuthenticator(authenticator Authenticator) *Client {
+	client, _ := New(WithAuthenticator(authenticator))
+	return client
+}
 
-		baseURL: DefaultAPIBaseURL,
-	}
+// SetAuthenticator changes the authenticator and discards the current token.
+func (c *Client) SetAuthenticator(authenticator Authenticator) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	c.authenticator = authenticator
+	c.clearToken()
+}
+
+// SetTokenRenewalMargin changes the margin before the expiry of access tokens for renewing them.
+func (c *Client) SetTokenRenewalMargin(margin time.Duration) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	c.tokenRenewalMargin = margin
 }
 
 // SetAPIBaseURL changes the `baseURL`.
@@ -60,15 +149,117 @@ func (c *Client) SetAPIBaseURL(baseURL string) {
 	c.baseURL = baseURL
 }
 
+// SetRetryPolicy changes the retry policy for failed requests.
+//
+// (eg. `DefaultRetryPolicy()`, or `nil` for not retrying at all)
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// tokenFlight struct for a login/renewal in flight, shared by all waiters
+type tokenFlight struct {
+	done  chan struct{}
+	token *AccessToken
+	err   error
+}
+
 // get token, retrieve/refresh it if needed
-func (c *Client) getToken() (token *UniversalAuthToken, err error) {
-	if c.token == nil {
-		_, err = c.login()
+//
+// Concurrent callers share one in-flight login/renewal.
+func (c *Client) getToken(ctx context.Context) (token *AccessToken, err error) {
+	c.tokenLock.Lock()
+
+	if c.authenticator == nil {
+		c.tokenLock.Unlock()
+		return nil, nil
+	}
+
+	now := time.Now()
+	if c.token != nil && !c.tokenNeedsRenewal(now) {
+		token = c.token
+		c.tokenLock.Unlock()
+		return token, nil
+	}
+
+	flight := c.tokenFlight
+	if flight == nil {
+		flight = &tokenFlight{done: make(chan struct{})}
+		c.tokenFlight = flight
+
+		// renew the token if possible, or login again
+		relogin := c.token == nil || c.tokenReachedMaxTTL(now)
+
+		// NOTE: the flight should not be cancelled by its initiator, as others are also waiting for it
+		go c.fly(context.WithoutCancel(ctx), flight, relogin)
+	}
+	c.tokenLock.Unlock()
+
+	select {
+	case <-flight.done:
+		return flight.token, flight.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fly logins (or renews the token) and lands the result on given flight
+func (c *Client) fly(ctx context.Context, flight *tokenFlight, relogin bool) {
+	var err error
+	if relogin {
+		_, err = c.login(ctx)
 	} else {
-		if time.Now().After(c.tokenExpiresOn) {
-			_, err = c.refresh()
+		if _, err = c.refresh(ctx); err != nil {
+			// fallback to login, as the token may have been revoked or expired
+			_, err = c.login(ctx)
 		}
 	}
 
-	return c.token, err
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	if err != nil && c.token != nil && time.Now().Before(c.tokenExpiresOn) {
+		// keep using the current token until it really expires
+		err = nil
+	}
+	flight.token, flight.err = c.token, err
+	if err != nil {
+		flight.token = nil
+	}
+	c.tokenFlight = nil
+
+	close(flight.done)
+
+	// check the client secret after the token is issued (or renewed)
+	if err == nil && c.rotation != nil {
+		go c.rotateClientSecretInBackground(ctx)
+	}
+}
+
+// tokenNeedsRenewal checks if the current token should be renewed at given time.
+//
+// NOTE: `tokenLock` should be held by the caller.
+func (c *Client) tokenNeedsRenewal(now time.Time) bool {
+	if c.tokenExpiresOn.IsZero() {
+		return false // never expires
+	}
+
+	// renew within the margin before the expiry (but not more than half of the token's TTL)
+	margin := c.tokenRenewalMargin
+	if ttl := time.Second * time.Duration(c.token.ExpiresIn); margin > ttl/2 {
+		margin = ttl / 2
+	}
+
+	return !now.Add(margin).Before(c.tokenExpiresOn)
+}
+
+// tokenReachedMaxTTL checks if the current token cannot be renewed anymore at given time.
+//
+// NOTE: `tokenLock` should be held by the caller.
+func (c *Client) tokenReachedMaxTTL(now time.Time) bool {
+	if c.tokenMaxExpiresOn.IsZero() {
+		return false // no max TTL
+	}
+
+	// renewal will not extend the expiry anymore
+	return !c.tokenExpiresOn.Before(c.tokenMaxExpiresOn) || !now.Before(c.tokenMaxExpiresOn)
 }
@@ -0,0 +1,56 @@
+package infisical
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	////////////////////////////////
+	// run a stand-in server which never responds to secret requests
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(UniversalAuthToken{
+			AccessToken:       "test-access-token",
+			AccessTokenMaxTTL: 3600,
+			ExpiresIn:         600,
+			TokenType:         "Bearer",
+		})
+	})
+	mux.HandleFunc("/api/v3/secrets/raw", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	////////////////////////////////
+	// initialize client
+	client := NewClientWithoutAPIKey("test-client-id", "test-client-secret")
+	client.SetAPIBaseURL(server.URL)
+
+	////////////////////////////////
+	// test api functions
+
+	// (deadline exceeded while waiting for the response)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if _, err := client.ListSecretsWithContext(ctx, NewParamsListSecrets().SetWorkspaceID("workspace").SetEnvironment("dev")); err == nil {
+		t.Errorf("listing secrets should have failed with an exceeded deadline")
+	} else if elapsed := time.Since(started); elapsed >= TimeoutSeconds*time.Second {
+		t.Errorf("listing secrets did not return on deadline: took %s", elapsed)
+	}
+
+	// (already-cancelled context)
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	if _, err := client.RetrieveSecretWithContext(cancelled, "workspace", "dev", "KEY", NewParamsRetrieveSecret()); err == nil {
+		t.Errorf("retrieving a secret should have failed with a cancelled context")
+	}
+}
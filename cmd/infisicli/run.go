@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
 	"os"
 	"path"
@@ -195,7 +196,7 @@ Usage:
 Other optional arguments:
 
   %[34]s / %[35]s
-  : Dump http requests/responses for debugging.
+  : Dump http requests/responses (with sensitive values redacted) for debugging.
 `,
 		// executable name
 		applicationName,
@@ -317,7 +318,9 @@ func do(fn func(c *infisical.Client) error, verbose bool) error {
 		} else {
 			client = infisical.NewClientWithoutAPIKey(cfg.ClientID, cfg.ClientSecret)
 		}
-		client.Verbose = verbose
+		if verbose {
+			client.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		}
 
 		return fn(client)
 	}
@@ -1,9 +1,159 @@
 package infisical
 
-/*
-TODO:
-* Environments
-- [ ] [Create](https://infisical.com/docs/api-reference/endpoints/environments/create)
-- [ ] [Update](https://infisical.com/docs/api-reference/endpoints/environments/update)
-- [ ] [Delete](https://infisical.com/docs/api-reference/endpoints/environments/delete)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// EnvironmentData struct for environment response
+type EnvironmentData struct {
+	Message     string               `json:"message,omitempty"`
+	Workspace   Workspace            `json:"workspace"`
+	Environment WorkspaceEnvironment `json:"environment"`
+}
+
+// CreateEnvironment creates a new environment with given name and slug in the workspace, at the last position.
+//
+// https://infisical.com/docs/api-reference/endpoints/environments/create
+func (c *Client) CreateEnvironment(workspaceID, name, slug string) (result EnvironmentData, err error) {
+	return c.CreateEnvironmentWithContext(context.Background(), workspaceID, name, slug)
+}
+
+// CreateEnvironmentWithContext is the same as `CreateEnvironment` with given context.
+func (c *Client) CreateEnvironmentWithContext(ctx context.Context, workspaceID, name, slug string) (result EnvironmentData, err error) {
+	errs := []error{validateID("workspace id", workspaceID), validateSlug("environment slug", slug)}
+	if name == "" {
+		errs = append(errs, fmt.Errorf("%w: environment name is missing", ErrInvalidParams))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return EnvironmentData{}, fmt.Errorf("failed to create an environment: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+	}{name, slug}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/workspace/%s/environments", workspaceID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return EnvironmentData{}, fmt.Errorf("failed to create an environment: %w", err)
+}
+
+// ParamsUpdateEnvironment struct for updating an environment
+type ParamsUpdateEnvironment struct {
+	Name     *string `json:"name,omitempty"`
+	Slug     *string `json:"slug,omitempty"`
+	Position *int    `json:"position,omitempty"` // 1-based; other environments are shifted
+}
+
+// NewParamsUpdateEnvironment returns a new params for updating an environment.
+func NewParamsUpdateEnvironment() *ParamsUpdateEnvironment {
+	return &ParamsUpdateEnvironment{}
+}
+
+func (p *ParamsUpdateEnvironment) SetName(name string) *ParamsUpdateEnvironment {
+	p.Name = &name
+	return p
+}
+
+func (p *ParamsUpdateEnvironment) SetSlug(slug string) *ParamsUpdateEnvironment {
+	p.Slug = &slug
+	return p
+}
+
+func (p *ParamsUpdateEnvironment) SetPosition(position int) *ParamsUpdateEnvironment {
+	p.Position = &position
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsUpdateEnvironment) validate() error {
+	errs := []error{}
+	if p.Name == nil && p.Slug == nil && p.Position == nil {
+		errs = append(errs, fmt.Errorf("%w: nothing to update", ErrInvalidParams))
+	}
+	if p.Name != nil && *p.Name == "" {
+		errs = append(errs, fmt.Errorf("%w: environment name is empty", ErrInvalidParams))
+	}
+	if p.Slug != nil {
+		errs = append(errs, validateSlug("environment slug", *p.Slug))
+	}
+	if p.Position != nil && *p.Position < 1 {
+		errs = append(errs, fmt.Errorf("%w: position should be 1 or greater: %d", ErrInvalidParams, *p.Position))
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateEnvironment renames, changes the slug of, or reorders an environment.
+//
+// https://infisical.com/docs/api-reference/endpoints/environments/update
+func (c *Client) UpdateEnvironment(workspaceID, environmentID string, params *ParamsUpdateEnvironment) (result EnvironmentData, err error) {
+	return c.UpdateEnvironmentWithContext(context.Background(), workspaceID, environmentID, params)
+}
+
+// UpdateEnvironmentWithContext is the same as `UpdateEnvironment` with given context.
+func (c *Client) UpdateEnvironmentWithContext(ctx context.Context, workspaceID, environmentID string, params *ParamsUpdateEnvironment) (result EnvironmentData, err error) {
+	if params == nil {
+		params = NewParamsUpdateEnvironment()
+	}
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("environment id", environmentID), params.validate()); err != nil {
+		return EnvironmentData{}, fmt.Errorf("failed to update an environment: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/workspace/%s/environments/%s", workspaceID, environmentID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				// (cached secrets of the old slug are not valid anymore)
+				c.invalidateWorkspaceCache(workspaceID)
+				c.invalidateOfflineWorkspace(ctx, workspaceID)
+				return result, nil
+			}
+		}
+	}
+
+	return EnvironmentData{}, fmt.Errorf("failed to update an environment: %w", err)
+}
+
+// DeleteEnvironment deletes an environment (with all its folders and secrets) from the workspace.
+//
+// https://infisical.com/docs/api-reference/endpoints/environments/delete
+func (c *Client) DeleteEnvironment(workspaceID, environmentID string) (result EnvironmentData, err error) {
+	return c.DeleteEnvironmentWithContext(context.Background(), workspaceID, environmentID)
+}
+
+// DeleteEnvironmentWithContext is the same as `DeleteEnvironment` with given context.
+func (c *Client) DeleteEnvironmentWithContext(ctx context.Context, workspaceID, environmentID string) (result EnvironmentData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("environment id", environmentID)); err != nil {
+		return EnvironmentData{}, fmt.Errorf("failed to delete an environment: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "DELETE", fmt.Sprintf("/v1/workspace/%s/environments/%s", workspaceID, environmentID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				c.invalidateWorkspaceCache(workspaceID)
+				c.invalidateOfflineWorkspace(ctx, workspaceID)
+				return result, nil
+			}
+		}
+	}
+
+	return EnvironmentData{}, fmt.Errorf("failed to delete an environment: %w", err)
+}
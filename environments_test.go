@@ -0,0 +1,122 @@
+package infisical
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestEnvironments(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+	server.SetSecret(workspace.ID, "dev", "/", "KEY", "value")
+
+	client := target.newClient(t,
+		WithRetryPolicy(nil),
+		WithOfflineCache(&OfflineCachePolicy{Dir: t.TempDir()}),
+	)
+	unavailable := infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	}
+
+	// (create an environment)
+	var previewID string
+	if created, err := client.CreateEnvironment(workspace.ID, "Preview: feature-x", "preview-feature-x"); err != nil {
+		t.Errorf("failed to create an environment: %s", err)
+	} else if created.Environment.ID == "" || created.Environment.Position != 3 || len(created.Workspace.Environments) != 3 {
+		t.Errorf("unexpected environment: %+v", created)
+	} else {
+		previewID = created.Environment.ID
+	}
+	server.SetSecret(workspace.ID, "preview-feature-x", "/", "KEY", "preview-value")
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("preview-feature-x")); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	}
+
+	// (rename and reorder an environment)
+	if updated, err := client.UpdateEnvironment(workspace.ID, previewID, NewParamsUpdateEnvironment().
+		SetName("Preview: feature-y").
+		SetSlug("preview-feature-y").
+		SetPosition(1),
+	); err != nil {
+		t.Errorf("failed to update an environment: %s", err)
+	} else if updated.Environment.Slug != "preview-feature-y" || updated.Environment.Position != 1 {
+		t.Errorf("unexpected environment: %+v", updated.Environment)
+	}
+	server.InjectFault(unavailable)
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("preview-feature-x")); err == nil {
+		t.Errorf("secrets of the old slug should not be served from the offline cache")
+	}
+	server.ClearFaults()
+
+	// (environments with ids and positions)
+	if projects, err := client.RetrieveProjects(infisicaltest.DefaultOrganizationID); err != nil {
+		t.Errorf("failed to retrieve projects: %s", err)
+	} else if len(projects.Workspaces) != 1 {
+		t.Errorf("unexpected number of projects: %d", len(projects.Workspaces))
+	} else {
+		for i, expected := range []string{"preview-feature-y", "dev", "prod"} {
+			env := projects.Workspaces[0].Environments[i]
+			if env.Slug != expected || env.Position != i+1 || env.ID == "" {
+				t.Errorf("unexpected environment at %d: %+v", i, env)
+			}
+		}
+	}
+
+	// (secrets are moved with renamed environments)
+	if value, exists := server.Secret(workspace.ID, "preview-feature-y", "/", "KEY"); !exists || value != "preview-value" {
+		t.Errorf("secret should have been moved to the renamed environment: %s", value)
+	}
+
+	// (environments of other workspaces)
+	otherID := server.AddWorkspace("other-workspace").Environments[0].ID
+	if _, err := client.UpdateEnvironment(workspace.ID, otherID, NewParamsUpdateEnvironment().SetName("Other")); err == nil {
+		t.Errorf("environments of other workspaces should not be updated")
+	}
+
+	// (delete an environment)
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("preview-feature-y")); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	}
+	if deleted, err := client.DeleteEnvironment(workspace.ID, previewID); err != nil {
+		t.Errorf("failed to delete an environment: %s", err)
+	} else if len(deleted.Workspace.Environments) != 2 || deleted.Workspace.Environments[0].Position != 1 {
+		t.Errorf("unexpected environments after deletion: %+v", deleted.Workspace.Environments)
+	}
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("preview-feature-y")); err == nil {
+		t.Errorf("secrets of the deleted environment should not be listed")
+	}
+	server.InjectFault(unavailable)
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("preview-feature-y")); err == nil {
+		t.Errorf("secrets of the deleted environment should not be served from the offline cache")
+	}
+	server.ClearFaults()
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"invalid slug": func(client *Client) error {
+			_, err := client.CreateEnvironment(workspace.ID, "Preview", "Preview_X")
+			return err
+		},
+		"missing name": func(client *Client) error {
+			_, err := client.CreateEnvironment(workspace.ID, "", "preview")
+			return err
+		},
+		"nothing to update": func(client *Client) error {
+			_, err := client.UpdateEnvironment(workspace.ID, previewID, nil)
+			return err
+		},
+		"invalid position": func(client *Client) error {
+			_, err := client.UpdateEnvironment(workspace.ID, previewID, NewParamsUpdateEnvironment().SetPosition(0))
+			return err
+		},
+		"missing environment id": func(client *Client) error {
+			_, err := client.DeleteEnvironment(workspace.ID, "")
+			return err
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package infisical
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// sentinel errors for matching `APIError`s with `errors.Is`
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// ErrInvalidParams is returned (wrapped) when parameters are rejected before sending requests
+var ErrInvalidParams = errors.New("invalid params")
+
+// APIError struct for errors returned from Infisical API
+type APIError struct {
+	StatusCode int    // HTTP status code of the response
+	Method     string // HTTP method of the request
+	Path       string // URL path of the request
+
+	Message   string // `message` in the error response
+	ErrorCode string // `error` in the error response
+	RequestID string // `reqId` in the error response
+
+	Body []byte // raw body of the error response
+}
+
+// errorResponse struct for parsing error responses
+type errorResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+	Error      string `json:"error"`
+	RequestID  string `json:"reqId"`
+}
+
+// newAPIError creates a new `APIError` from given response and its body.
+func newAPIError(res *http.Response, body []byte) *APIError {
+	err := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       body,
+	}
+	if res.Request != nil {
+		err.Method = res.Request.Method
+		if res.Request.URL != nil {
+			err.Path = res.Request.URL.Path
+		}
+	}
+
+	var parsed errorResponse
+	if json.Unmarshal(body, &parsed) == nil {
+		err.Message = parsed.Message
+		err.ErrorCode = parsed.Error
+		err.RequestID = parsed.RequestID
+	}
+
+	return err
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("%s: `%s`", httpStatusToErr(e.StatusCode), string(e.Body))
+	}
+	return httpStatusToErr(e.StatusCode).Error()
+}
+
+// Is checks if this error matches given sentinel error.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// isServerUnavailable checks if given error means that the server could not be reached or failed to respond.
+//
+// (network errors, 5xx and 429 responses; not canceled requests or other client errors)
+func isServerUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,71 @@
+package infisical
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	////////////////////////////////
+	// run a stand-in server which responds with errors
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(UniversalAuthToken{
+			AccessToken:       "test-access-token",
+			AccessTokenMaxTTL: 3600,
+			ExpiresIn:         600,
+			TokenType:         "Bearer",
+		})
+	})
+	mux.HandleFunc("/api/v3/secrets/raw/MISSING", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"statusCode":404,"message":"Secret not found","error":"NotFound","reqId":"req-1234"}`))
+	})
+	mux.HandleFunc("/api/v3/secrets/raw/FORBIDDEN", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`not a json`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	////////////////////////////////
+	// initialize client
+	client := NewClientWithoutAPIKey("test-client-id", "test-client-secret")
+	client.SetAPIBaseURL(server.URL)
+
+	////////////////////////////////
+	// test api functions
+
+	// (not found, with error json)
+	_, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/MISSING")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("error should match `ErrNotFound`: %s", err)
+	}
+	if errors.Is(err, ErrForbidden) || errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrRateLimited) {
+		t.Errorf("error should not match other sentinel errors: %s", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error should be an `APIError`: %s", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound ||
+		apiErr.Method != "GET" ||
+		apiErr.Path != "/api/v3/secrets/raw/MISSING" ||
+		apiErr.Message != "Secret not found" ||
+		apiErr.ErrorCode != "NotFound" ||
+		apiErr.RequestID != "req-1234" {
+		t.Errorf("error was not parsed properly: %+v", apiErr)
+	}
+
+	// (forbidden, without error json)
+	_, err = client.RetrieveSecret("workspace", "dev", "FORBIDDEN", NewParamsRetrieveSecret())
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("error should match `ErrForbidden`: %s", err)
+	}
+	if errors.As(err, &apiErr) && string(apiErr.Body) != "not a json" {
+		t.Errorf("raw body of error was not kept: %s", string(apiErr.Body))
+	}
+}
@@ -1,26 +1,38 @@
 package infisical
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
-type ParamsListFolders map[string]any
+// ParamsListFolders struct for listing folders
+type ParamsListFolders struct {
+	Path      *string `json:"path,omitempty"`
+	Directory *string `json:"directory,omitempty"` // Deprecated: use `Path` instead.
+}
 
-func NewParamsListFolders() ParamsListFolders {
-	return ParamsListFolders{}
+// NewParamsListFolders returns a new params for listing folders.
+func NewParamsListFolders() *ParamsListFolders {
+	return &ParamsListFolders{}
 }
 
-func (p ParamsListFolders) SetPath(path string) ParamsListFolders {
-	p["path"] = path
+func (p *ParamsListFolders) SetPath(path string) *ParamsListFolders {
+	p.Path = &path
 	return p
 }
 
-func (p ParamsListFolders) SetDirectory(directory string) ParamsListFolders {
-	p["directory"] = directory
+func (p *ParamsListFolders) SetDirectory(directory string) *ParamsListFolders {
+	p.Directory = &directory
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsListFolders) validate() error {
+	return errors.Join(validatePath(p.Path), validatePath(p.Directory))
+}
+
 // FoldersData struct for folders response
 type FoldersData struct {
 	Folders []Folder `json:"folders"`
@@ -40,52 +52,69 @@ type Folder struct {
 // ListFolders lists folders for given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/folders/list
-func (c *Client) ListFolders(workspaceID, environment string, params ParamsListFolders) (result FoldersData, err error) {
+func (c *Client) ListFolders(workspaceID, environment string, params *ParamsListFolders) (result FoldersData, err error) {
+	return c.ListFoldersWithContext(context.Background(), workspaceID, environment, params)
+}
+
+// ListFoldersWithContext is the same as `ListFolders` with given context.
+func (c *Client) ListFoldersWithContext(ctx context.Context, workspaceID, environment string, params *ParamsListFolders) (result FoldersData, err error) {
 	if params == nil {
 		params = NewParamsListFolders()
 	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), params.validate()); err != nil {
+		return FoldersData{}, fmt.Errorf("failed to list folders: %w", err)
+	}
 
 	// essential parameters
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
+	query := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		*ParamsListFolders
+	}{workspaceID, environment, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithQueryParams("GET", "/v1/folders", AuthMethodNormal, params)
+	req, err = c.newRequestWithQueryParams(ctx, "GET", "/v1/folders", AuthMethodNormal, query)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
 				return result, nil
 			}
 		}
 	}
 
-	return FoldersData{}, fmt.Errorf("failed to list folders: %s", err)
+	return FoldersData{}, fmt.Errorf("failed to list folders: %w", err)
 }
 
-type ParamsCreateFolder map[string]any
+// ParamsCreateFolder struct for creating a folder
+type ParamsCreateFolder struct {
+	Path      *string `json:"path,omitempty"`
+	Directory *string `json:"directory,omitempty"` // Deprecated: use `Path` instead.
+}
 
-func NewParamsCreateFolder() ParamsCreateFolder {
-	return ParamsCreateFolder{
-		"directory": "/",
-		"path":      "/",
+// NewParamsCreateFolder returns a new params for creating a folder at the root path.
+func NewParamsCreateFolder() *ParamsCreateFolder {
+	return &ParamsCreateFolder{
+		Directory: ptr("/"),
+		Path:      ptr("/"),
 	}
 }
 
-func (p ParamsCreateFolder) SetDirectory(directory string) ParamsCreateFolder {
-	p["directory"] = directory
+func (p *ParamsCreateFolder) SetDirectory(directory string) *ParamsCreateFolder {
+	p.Directory = &directory
 	return p
 }
 
-func (p ParamsCreateFolder) SetPath(path string) ParamsCreateFolder {
-	p["path"] = path
+func (p *ParamsCreateFolder) SetPath(path string) *ParamsCreateFolder {
+	p.Path = &path
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsCreateFolder) validate() error {
+	return errors.Join(validatePath(p.Path), validatePath(p.Directory))
+}
+
 type FolderData struct {
 	Folder Folder `json:"folder"`
 }
@@ -93,123 +122,164 @@ type FolderData struct {
 // CreateFolder creates a new folder with given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/folders/create
-func (c *Client) CreateFolder(workspaceID, environment, name string, params ParamsCreateFolder) (result FolderData, err error) {
+func (c *Client) CreateFolder(workspaceID, environment, name string, params *ParamsCreateFolder) (result FolderData, err error) {
+	return c.CreateFolderWithContext(context.Background(), workspaceID, environment, name, params)
+}
+
+// CreateFolderWithContext is the same as `CreateFolder` with given context.
+func (c *Client) CreateFolderWithContext(ctx context.Context, workspaceID, environment, name string, params *ParamsCreateFolder) (result FolderData, err error) {
 	if params == nil {
 		params = NewParamsCreateFolder()
 	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateFolderName(name), params.validate()); err != nil {
+		return FolderData{}, fmt.Errorf("failed to create a folder: %w", err)
+	}
 
 	// essential parameters
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
-	params["name"] = name
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		Name        string `json:"name"`
+		*ParamsCreateFolder
+	}{workspaceID, environment, name, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithJSONBody("POST", "/v1/folders", AuthMethodNormal, params)
+	req, err = c.newRequestWithJSONBody(ctx, "POST", "/v1/folders", AuthMethodNormal, body)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
 				return result, nil
 			}
 		}
 	}
 
-	return FolderData{}, fmt.Errorf("failed to create a folder: %s", err)
+	return FolderData{}, fmt.Errorf("failed to create a folder: %w", err)
 }
 
-type ParamsUpdateFolder map[string]any
+// ParamsUpdateFolder struct for updating a folder
+type ParamsUpdateFolder struct {
+	Path      *string `json:"path,omitempty"`
+	Directory *string `json:"directory,omitempty"` // Deprecated: use `Path` instead.
+}
 
-func NewParamsUpdateFolder() ParamsUpdateFolder {
-	return ParamsUpdateFolder{}
+// NewParamsUpdateFolder returns a new params for updating a folder.
+func NewParamsUpdateFolder() *ParamsUpdateFolder {
+	return &ParamsUpdateFolder{}
 }
 
-func (p ParamsUpdateFolder) SetDirectory(directory string) ParamsUpdateFolder {
-	p["directory"] = directory
+func (p *ParamsUpdateFolder) SetDirectory(directory string) *ParamsUpdateFolder {
+	p.Directory = &directory
 	return p
 }
 
-func (p ParamsUpdateFolder) SetPath(path string) ParamsUpdateFolder {
-	p["path"] = path
+func (p *ParamsUpdateFolder) SetPath(path string) *ParamsUpdateFolder {
+	p.Path = &path
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsUpdateFolder) validate() error {
+	return errors.Join(validatePath(p.Path), validatePath(p.Directory))
+}
+
 // UpdateFolder updates a folder with given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/folders/update
-func (c *Client) UpdateFolder(workspaceID, environment, folderID, name string, params ParamsUpdateFolder) (result FolderData, err error) {
+func (c *Client) UpdateFolder(workspaceID, environment, folderID, name string, params *ParamsUpdateFolder) (result FolderData, err error) {
+	return c.UpdateFolderWithContext(context.Background(), workspaceID, environment, folderID, name, params)
+}
+
+// UpdateFolderWithContext is the same as `UpdateFolder` with given context.
+func (c *Client) UpdateFolderWithContext(ctx context.Context, workspaceID, environment, folderID, name string, params *ParamsUpdateFolder) (result FolderData, err error) {
 	if params == nil {
 		params = NewParamsUpdateFolder()
 	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateID("folder id", folderID), validateFolderName(name), params.validate()); err != nil {
+		return FolderData{}, fmt.Errorf("failed to update a folder: %w", err)
+	}
 
 	// essential parameters
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
-	params["name"] = name
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		Name        string `json:"name"`
+		*ParamsUpdateFolder
+	}{workspaceID, environment, name, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithJSONBody("PATCH", fmt.Sprintf("/v1/folders/%s", folderID), AuthMethodNormal, params)
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/folders/%s", folderID), AuthMethodNormal, body)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
 				return result, nil
 			}
 		}
 	}
 
-	return FolderData{}, fmt.Errorf("failed to update a folder: %s", err)
+	return FolderData{}, fmt.Errorf("failed to update a folder: %w", err)
 }
 
-type ParamsDeleteFolder map[string]any
+// ParamsDeleteFolder struct for deleting a folder
+type ParamsDeleteFolder struct {
+	Path      *string `json:"path,omitempty"`
+	Directory *string `json:"directory,omitempty"` // Deprecated: use `Path` instead.
+}
 
-func NewParamsDeleteFolder() ParamsDeleteFolder {
-	return ParamsDeleteFolder{}
+// NewParamsDeleteFolder returns a new params for deleting a folder.
+func NewParamsDeleteFolder() *ParamsDeleteFolder {
+	return &ParamsDeleteFolder{}
 }
 
-func (p ParamsDeleteFolder) SetDirectory(directory string) ParamsDeleteFolder {
-	p["directory"] = directory
+func (p *ParamsDeleteFolder) SetDirectory(directory string) *ParamsDeleteFolder {
+	p.Directory = &directory
 	return p
 }
 
-func (p ParamsDeleteFolder) SetPath(path string) ParamsDeleteFolder {
-	p["path"] = path
+func (p *ParamsDeleteFolder) SetPath(path string) *ParamsDeleteFolder {
+	p.Path = &path
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsDeleteFolder) validate() error {
+	return errors.Join(validatePath(p.Path), validatePath(p.Directory))
+}
+
 // DeleteFolder deletes a folder with given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/folders/delete
-func (c *Client) DeleteFolder(workspaceID, environment, folderID string, params ParamsDeleteFolder) (result FolderData, err error) {
+func (c *Client) DeleteFolder(workspaceID, environment, folderID string, params *ParamsDeleteFolder) (result FolderData, err error) {
+	return c.DeleteFolderWithContext(context.Background(), workspaceID, environment, folderID, params)
+}
+
+// DeleteFolderWithContext is the same as `DeleteFolder` with given context.
+func (c *Client) DeleteFolderWithContext(ctx context.Context, workspaceID, environment, folderID string, params *ParamsDeleteFolder) (result FolderData, err error) {
 	if params == nil {
 		params = NewParamsDeleteFolder()
 	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateID("folder id", folderID), params.validate()); err != nil {
+		return FolderData{}, fmt.Errorf("failed to delete a folder: %w", err)
+	}
 
 	// essential parameters
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		*ParamsDeleteFolder
+	}{workspaceID, environment, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithJSONBody("DELETE", fmt.Sprintf("/v1/folders/%s", folderID), AuthMethodNormal, params)
+	req, err = c.newRequestWithJSONBody(ctx, "DELETE", fmt.Sprintf("/v1/folders/%s", folderID), AuthMethodNormal, body)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
 				return result, nil
 			}
 		}
 	}
 
-	return FolderData{}, fmt.Errorf("failed to delete a folder: %s", err)
+	return FolderData{}, fmt.Errorf("failed to delete a folder: %w", err)
 }
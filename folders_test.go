@@ -1,27 +1,15 @@
 package infisical
 
 import (
-	"os"
 	"testing"
 )
 
 func TestFolders(t *testing.T) {
-	////////////////////////////////
-	// read values from environment variables
-	apiKey := os.Getenv("INFISICAL_API_KEY")
-	workspaceID := os.Getenv("INFISICAL_WORKSPACE_ID")
-	clientID := os.Getenv("INFISICAL_CLIENT_ID")
-	clientSecret := os.Getenv("INFISICAL_CLIENT_SECRET")
-	environment := os.Getenv("INFISICAL_ENVIRONMENT")
-	verbose := os.Getenv("VERBOSE") // NOTE: "true" or not
-
 	////////////////////////////////
 	// initialize client
-	if apiKey == "" || clientID == "" || clientSecret == "" || workspaceID == "" || environment == "" {
-		t.Fatalf("no environment variables: `INFISICAL_API_KEY`, `INFISICAL_CLIENT_ID`, `INFISICAL_CLIENT_SECRET`, `INFISICAL_WORKSPACE_ID`, or `INFISICAL_ENVIRONMENT` were found.")
-	}
-	client := NewClient(apiKey, clientID, clientSecret)
-	client.Verbose = (verbose == "true")
+	target := newTestTarget(t)
+	workspaceID, environment := target.workspaceID, target.environment
+	client := target.newClient(t)
 
 	////////////////////////////////
 	// test api functions
@@ -0,0 +1,72 @@
+package infisical
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGCPMetadataURL is the default base URL of the GCP metadata server
+const DefaultGCPMetadataURL = "http://metadata.google.internal"
+
+// GCPIDTokenAuth is an authenticator with ID tokens of GCP service accounts
+type GCPIDTokenAuth struct {
+	identityID string
+
+	// base URL of the metadata server
+	MetadataURL string
+
+	// http client for requesting the metadata server (optional)
+	HTTPClient *http.Client
+}
+
+// NewGCPIDTokenAuth returns a new gcp-auth(ID token) authenticator for given identity id.
+func NewGCPIDTokenAuth(identityID string) *GCPIDTokenAuth {
+	return &GCPIDTokenAuth{
+		identityID: identityID,
+
+		MetadataURL: DefaultGCPMetadataURL,
+	}
+}
+
+// Login logs in with an ID token fetched from the GCP metadata server.
+//
+// https://infisical.com/docs/api-reference/endpoints/gcp-auth/login
+func (a *GCPIDTokenAuth) Login(ctx context.Context, c *Client) (result AccessToken, err error) {
+	var jwt string
+	if jwt, err = a.fetchIDToken(ctx); err != nil {
+		return AccessToken{}, fmt.Errorf("failed to fetch GCP ID token: %w", err)
+	}
+
+	return c.loginWithJWT(ctx, "/v1/auth/gcp-auth/login", a.identityID, jwt)
+}
+
+// fetch an ID token from the metadata server, with the identity id as its audience
+func (a *GCPIDTokenAuth) fetchIDToken(ctx context.Context) (token string, err error) {
+	query := url.Values{}
+	query.Set("audience", a.identityID)
+	query.Set("format", "full")
+
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/computeMetadata/v1/instance/service-accounts/default/identity?%s", strings.TrimSuffix(a.MetadataURL, "/"), query.Encode()), nil); err == nil {
+		req.Header.Set("Metadata-Flavor", "Google")
+
+		var res *http.Response
+		if res, err = metadataHTTPClient(a.HTTPClient).Do(req); err == nil {
+			defer res.Body.Close()
+
+			var body []byte
+			if body, err = io.ReadAll(res.Body); err == nil {
+				if res.StatusCode != http.StatusOK {
+					return "", metadataError(res, body)
+				}
+				return strings.TrimSpace(string(body)), nil
+			}
+		}
+	}
+
+	return "", err
+}
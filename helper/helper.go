@@ -11,18 +11,33 @@ import (
 )
 
 // Value returns the secret value for given parameters.
-func Value(clientID, clientSecret, workspaceID, environment string, secretType infisical.SecretType, secretKeyPath string) (string, error) {
-	client := infisical.NewClientWithoutAPIKey(clientID, clientSecret)
+//
+// The access token issued for it is revoked before returning.
+//
+// Additional options (eg. `infisical.WithOfflineCache`) can be given with `opts`.
+func Value(clientID, clientSecret, workspaceID, environment string, secretType infisical.SecretType, secretKeyPath string, opts ...infisical.Option) (string, error) {
+	client, err := newClient(clientID, clientSecret, opts...)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = client.Close() }()
 
 	return client.RetrieveSecretValue(workspaceID, environment, secretType, secretKeyPath)
 }
 
 // Values returns multiple secret values for given parameters.
-func Values(clientID, clientSecret, workspaceID, environment string, secretType infisical.SecretType, secretKeyPaths []string) (map[string]string, error) {
-	client := infisical.NewClientWithoutAPIKey(clientID, clientSecret)
+//
+// The access token issued for them is revoked before returning.
+//
+// Additional options (eg. `infisical.WithOfflineCache`) can be given with `opts`.
+func Values(clientID, clientSecret, workspaceID, environment string, secretType infisical.SecretType, secretKeyPaths []string, opts ...infisical.Option) (map[string]string, error) {
+	client, err := newClient(clientID, clientSecret, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
 
 	var value string
-	var err error
 	values := map[string]string{}
 	errs := []error{}
 	for _, secretKeyPath := range secretKeyPaths {
@@ -36,3 +51,8 @@ func Values(clientID, clientSecret, workspaceID, environment string, secretType
 
 	return values, errors.Join(errs...)
 }
+
+// newClient creates a new client with given universal-auth credentials and options.
+func newClient(clientID, clientSecret string, opts ...infisical.Option) (*infisical.Client, error) {
+	return infisical.New(append([]infisical.Option{infisical.WithUniversalAuth(clientID, clientSecret)}, opts...)...)
+}
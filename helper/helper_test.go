@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/meinside/infisical-go"
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestHelpers(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	workspace := server.AddWorkspace("test-workspace", "dev")
+	server.SetSecret(workspace.ID, "dev", "/", "KEY1", "value1")
+	server.SetSecret(workspace.ID, "dev", "/folder", "KEY2", "value2")
+
+	const revokePath = "/api/v1/auth/token/revoke"
+
+	// (a value)
+	if value, err := Value(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret, workspace.ID, "dev", infisical.SecretTypeShared, "/KEY1",
+		infisical.WithBaseURL(server.URL),
+	); err != nil {
+		t.Errorf("failed to get a value: %s", err)
+	} else if value != "value1" {
+		t.Errorf("unexpected value: %s", value)
+	}
+	if count := server.CountRequests("POST", revokePath); count != 1 {
+		t.Errorf("access token should be revoked after use: %d", count)
+	}
+
+	// (values)
+	if values, err := Values(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret, workspace.ID, "dev", infisical.SecretTypeShared, []string{"/KEY1", "/folder/KEY2"},
+		infisical.WithBaseURL(server.URL),
+	); err != nil {
+		t.Errorf("failed to get values: %s", err)
+	} else if values["/KEY1"] != "value1" || values["/folder/KEY2"] != "value2" {
+		t.Errorf("unexpected values: %v", values)
+	}
+	if count := server.CountRequests("POST", revokePath); count != 2 {
+		t.Errorf("access token should be revoked after use: %d", count)
+	}
+}
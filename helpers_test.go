@@ -0,0 +1,113 @@
+package infisical
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+// testTarget struct for the server which tests run against
+type testTarget struct {
+	apiKey       string
+	clientID     string
+	clientSecret string
+	workspaceID  string
+	environment  string
+
+	server    *infisicaltest.Server   // nil when testing against a live server
+	workspace infisicaltest.Workspace // workspace in the fake server
+}
+
+// newTestTarget returns a live target when all `INFISICAL_*` environment variables are given,
+// or an in-memory fake server otherwise, with a workspace of given environment slugs. ("dev" if none)
+func newTestTarget(t *testing.T, environments ...string) testTarget {
+	////////////////////////////////
+	// read values from environment variables
+	target := testTarget{
+		apiKey:       os.Getenv("INFISICAL_API_KEY"),
+		clientID:     os.Getenv("INFISICAL_CLIENT_ID"),
+		clientSecret: os.Getenv("INFISICAL_CLIENT_SECRET"),
+		workspaceID:  os.Getenv("INFISICAL_WORKSPACE_ID"),
+		environment:  os.Getenv("INFISICAL_ENVIRONMENT"),
+	}
+	if target.apiKey != "" && target.clientID != "" && target.clientSecret != "" && target.workspaceID != "" && target.environment != "" {
+		return target
+	}
+
+	////////////////////////////////
+	// or run a fake server
+	server := infisicaltest.NewServer()
+	t.Cleanup(server.Close)
+
+	if len(environments) == 0 {
+		environments = []string{"dev"}
+	}
+	workspace := server.AddWorkspace("test-workspace", environments...)
+
+	return testTarget{
+		apiKey:       infisicaltest.DefaultAPIKey,
+		clientID:     infisicaltest.DefaultClientID,
+		clientSecret: infisicaltest.DefaultClientSecret,
+		workspaceID:  workspace.ID,
+		environment:  environments[0],
+		server:       server,
+		workspace:    workspace,
+	}
+}
+
+// fakeServer returns the fake server of the target, or skips the test when testing against a live server.
+func (target testTarget) fakeServer(t *testing.T) *infisicaltest.Server {
+	t.Helper()
+
+	if target.server == nil {
+		t.Skip("skipping tests which require the fake server")
+	}
+	return target.server
+}
+
+// newClient creates a new client for the target with given options.
+func (target testTarget) newClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+
+	defaults := []Option{
+		WithAPIKey(target.apiKey),
+		WithUniversalAuth(target.clientID, target.clientSecret),
+	}
+	if target.server != nil {
+		defaults = append(defaults, WithBaseURL(target.server.URL))
+	}
+	if os.Getenv("VERBOSE") == "true" {
+		defaults = append(defaults, WithLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	}
+
+	client, err := New(append(defaults, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	return client
+}
+
+// assertRejectedBeforeRequest checks that each case fails with `ErrInvalidParams` without sending any request.
+//
+// (cases are run with a client of a separate fake server, so that no request is expected at all)
+func assertRejectedBeforeRequest(t *testing.T, cases map[string]func(*Client) error) {
+	t.Helper()
+
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	client := NewClientWithoutAPIKey(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret)
+	client.SetAPIBaseURL(server.URL)
+
+	for name, run := range cases {
+		if err := run(client); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("%s: error should match `ErrInvalidParams`: %v", name, err)
+		}
+	}
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Errorf("requests were sent with invalid params: %+v", requests)
+	}
+}
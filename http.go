@@ -2,12 +2,14 @@ package infisical
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
+	"time"
 )
 
 const (
@@ -23,26 +25,113 @@ const (
 	AuthMethodTokenOnly  AuthMethod = 1 << iota
 )
 
-// dump http request
-func (c *Client) dumpRequest(req *http.Request) {
-	if c.Verbose {
-		if bytes, err := httputil.DumpRequest(req, true); err == nil {
-			log.Printf("**** dumping HTTP request:\n\n%s\n", string(bytes))
+// do sends given http request, retrying it with the client's retry policy if its method is retryable.
+func (c *Client) do(req *http.Request) (res *http.Response, err error) {
+	return c.doWithRetry(req, c.retryPolicy.allowsMethod(req.Method))
+}
+
+// doWithRetry sends given http request, retrying it with the client's retry policy if `retryable` is true.
+func (c *Client) doWithRetry(req *http.Request, retryable bool) (res *http.Response, err error) {
+	c.setDefaultHeaders(req)
+
+	policy := c.retryPolicy
+	if !retryable || policy == nil || policy.MaxAttempts <= 1 {
+		return c.send(req, 1)
+	}
+
+	ctx := req.Context()
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	for attempt := 1; ; attempt++ {
+		// rewind request body for retries
+		if attempt > 1 && req.GetBody != nil {
+			req = req.Clone(ctx)
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err = c.send(req, attempt)
+		if err == nil && !policy.retryableStatus(res.StatusCode) {
+			return res, nil
+		}
+
+		if attempt >= policy.MaxAttempts || !rewindable || ctx.Err() != nil {
+			return res, err
+		}
+
+		delay := policy.delay(attempt, res)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
 		} else {
-			log.Printf("**** failed to dump HTTP request:\n\n%s\n", err)
+			attrs = append(attrs, slog.Int("status", res.StatusCode))
+		}
+		c.logEvent(ctx, slog.LevelInfo, "retrying request", attrs...)
+
+		// discard the response body before retrying
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
 		}
 	}
 }
 
-// dump http response
-func (c *Client) dumpResponse(req *http.Response) {
-	if c.Verbose {
-		if bytes, err := httputil.DumpResponse(req, true); err == nil {
-			log.Printf("**** dumping HTTP response:\n\n%s\n", string(bytes))
-		} else {
-			log.Printf("**** failed to dump HTTP response:\n\n%s\n", err)
+// send sends given http request once, logging it
+func (c *Client) send(req *http.Request, attempt int) (res *http.Response, err error) {
+	ctx := req.Context()
+
+	c.logEvent(ctx, slog.LevelDebug, "sending request",
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+	)
+	c.dumpRequest(req)
+
+	started := time.Now()
+	if res, err = c.httpClient.Do(req); err == nil {
+		c.logEvent(ctx, slog.LevelDebug, "received response",
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Int("status", res.StatusCode),
+			slog.Duration("latency", time.Since(started)),
+		)
+		c.dumpResponse(res)
+	} else {
+		c.logEvent(ctx, slog.LevelWarn, "request failed",
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Duration("latency", time.Since(started)),
+			slog.Any("error", err),
+		)
+	}
+
+	return res, err
+}
+
+// setDefaultHeaders sets the client's default headers on given http request.
+func (c *Client) setDefaultHeaders(req *http.Request) {
+	for k, vs := range c.headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
 		}
 	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 }
 
 // requestURL returns a URL string for HTTP request with given path.
@@ -51,11 +140,13 @@ func (c *Client) requestURL(path string) string {
 }
 
 // newRequestWithQueryParams creates a new http request with query strings.
-func (c *Client) newRequestWithQueryParams(method, path string, authMethod AuthMethod, params map[string]any) (req *http.Request, err error) {
+//
+// `params` can be a map, or a struct with JSON tags.
+func (c *Client) newRequestWithQueryParams(ctx context.Context, method, path string, authMethod AuthMethod, params any) (req *http.Request, err error) {
 	apiKey := c.apiKey
-	var token *UniversalAuthToken
-	if token, err = c.getToken(); err != nil {
-		return nil, fmt.Errorf("failed to fetch token, cannot generate a request: %s", err)
+	var token *AccessToken
+	if token, err = c.getToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch token, cannot generate a request: %w", err)
 	}
 
 	if authMethod&AuthMethodAPIKeyOnly != 0 && emptyString(c.apiKey) {
@@ -65,10 +156,14 @@ func (c *Client) newRequestWithQueryParams(method, path string, authMethod AuthM
 		return nil, fmt.Errorf("%s %s requires `token` that is missing, cannot generate a request", method, path)
 	}
 
-	if req, err = http.NewRequest(method, c.requestURL(path), nil); err == nil {
+	if req, err = http.NewRequestWithContext(ctx, method, c.requestURL(path), nil); err == nil {
 		// query parameters
+		var converted map[string]any
+		if converted, err = queryParams(params); err != nil {
+			return nil, err
+		}
 		q := req.URL.Query()
-		for k, v := range params {
+		for k, v := range converted {
 			q.Add(k, fmt.Sprintf("%v", v))
 		}
 		req.URL.RawQuery = q.Encode()
@@ -103,11 +198,13 @@ func (c *Client) newRequestWithQueryParams(method, path string, authMethod AuthM
 }
 
 // newRequestWithJSONBody creates a new http request with JSON body.
-func (c *Client) newRequestWithJSONBody(method, path string, authMethod AuthMethod, params map[string]any) (req *http.Request, err error) {
+//
+// `params` can be a map, or a struct with JSON tags.
+func (c *Client) newRequestWithJSONBody(ctx context.Context, method, path string, authMethod AuthMethod, params any) (req *http.Request, err error) {
 	apiKey := c.apiKey
-	var token *UniversalAuthToken
-	if token, err = c.getToken(); err != nil {
-		return nil, fmt.Errorf("failed to fetch token, cannot generate a request: %s", err)
+	var token *AccessToken
+	if token, err = c.getToken(ctx); err != nil {
+		return nil, fmt.Errorf("failed to fetch token, cannot generate a request: %w", err)
 	}
 
 	if authMethod&AuthMethodAPIKeyOnly != 0 && emptyString(c.apiKey) {
@@ -123,7 +220,7 @@ func (c *Client) newRequestWithJSONBody(method, path string, authMethod AuthMeth
 		return nil, err
 	}
 
-	if req, err = http.NewRequest(method, c.requestURL(path), bytes.NewReader(encoded)); err == nil {
+	if req, err = http.NewRequestWithContext(ctx, method, c.requestURL(path), bytes.NewReader(encoded)); err == nil {
 		req.Header.Set("Content-Type", "application/json")
 
 		// add headers for authorization
@@ -168,12 +265,8 @@ func (c *Client) parseResponse(res *http.Response, into any) (err error) {
 			return nil
 		}
 	} else {
-		var body []byte
-		if body, err = io.ReadAll(res.Body); err == nil {
-			err = fmt.Errorf("%s: `%s`", httpStatusToErr(res.StatusCode), string(body))
-		} else {
-			err = httpStatusToErr(res.StatusCode)
-		}
+		body, _ := io.ReadAll(res.Body)
+		err = newAPIError(res, body)
 	}
 
 	return err
@@ -192,6 +285,8 @@ func httpStatusToErr(status int) error {
 		return fmt.Errorf("%s; forbidden", httpError)
 	case 404:
 		return fmt.Errorf("%s; not found", httpError)
+	case 429:
+		return fmt.Errorf("%s; too many requests", httpError)
 	case 500:
 		return fmt.Errorf("%s; internal server error", httpError)
 	case 503:
@@ -199,7 +294,7 @@ func httpStatusToErr(status int) error {
 	}
 
 	// fallback
-	return fmt.Errorf(httpError)
+	return errors.New(httpError)
 }
 
 // checks if given string pointer is an empty string
@@ -1,9 +1,242 @@
 package infisical
 
-/*
-TODO:
-* Identities
-- [ ] [Create](https://infisical.com/docs/api-reference/endpoints/identities/create)
-- [ ] [Update](https://infisical.com/docs/api-reference/endpoints/identities/update)
-- [ ] [Delete](https://infisical.com/docs/api-reference/endpoints/identities/delete)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// OrganizationRole type for roles of organization memberships (slugs of built-in or custom roles)
+type OrganizationRole string
+
+// built-in organization roles
+const (
+	OrganizationRoleAdmin    OrganizationRole = "admin"
+	OrganizationRoleMember   OrganizationRole = "member"
+	OrganizationRoleNoAccess OrganizationRole = "no-access"
+)
+
+// IdentityData struct for machine identity response
+type IdentityData struct {
+	Identity Identity `json:"identity"`
+}
+
+// Identity struct for a machine identity
+type Identity struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	AuthMethods []string `json:"authMethods"` // eg. "universal-auth"
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
+
+// IdentityMembershipsOfOrganizationData struct for machine identities' organization memberships response
+type IdentityMembershipsOfOrganizationData struct {
+	Identities []IdentityMembershipOfOrganization `json:"identities"`
+}
+
+// IdentityMembershipOfOrganizationData struct for a machine identity's organization membership response
+type IdentityMembershipOfOrganizationData struct {
+	Identity IdentityMembershipOfOrganization `json:"identity"`
+}
+
+// IdentityMembershipOfOrganization struct for a machine identity's membership of an organization
+type IdentityMembershipOfOrganization struct {
+	ID             string           `json:"id"`
+	IdentityID     string           `json:"identityId"`
+	OrganizationID string           `json:"orgId"`
+	Role           OrganizationRole `json:"role"` // "custom" for custom roles
+	CustomRoleSlug *string          `json:"customRoleSlug,omitempty"`
+	Identity       Identity         `json:"identity"`
+	CreatedAt      string           `json:"createdAt"`
+	UpdatedAt      string           `json:"updatedAt"`
+}
+
+// validateOrganizationRole checks given organization role.
+func validateOrganizationRole(role OrganizationRole) error {
+	return validateSlug("organization role", string(role))
+}
+
+// CreateIdentity creates a machine identity in an organization with given role.
+//
+// https://infisical.com/docs/api-reference/endpoints/identities/create
+func (c *Client) CreateIdentity(organizationID, name string, role OrganizationRole) (result IdentityData, err error) {
+	return c.CreateIdentityWithContext(context.Background(), organizationID, name, role)
+}
+
+// CreateIdentityWithContext is the same as `CreateIdentity` with given context.
+func (c *Client) CreateIdentityWithContext(ctx context.Context, organizationID, name string, role OrganizationRole) (result IdentityData, err error) {
+	errs := []error{validateID("organization id", organizationID), validateOrganizationRole(role)}
+	if name == "" {
+		errs = append(errs, fmt.Errorf("%w: identity name is missing", ErrInvalidParams))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return IdentityData{}, fmt.Errorf("failed to create an identity: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		Name           string           `json:"name"`
+		OrganizationID string           `json:"organizationId"`
+		Role           OrganizationRole `json:"role"`
+	}{name, organizationID, role}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", "/v1/identities", AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityData{}, fmt.Errorf("failed to create an identity: %w", err)
+}
+
+// ParamsUpdateIdentity struct for updating a machine identity
+type ParamsUpdateIdentity struct {
+	Name *string           `json:"name,omitempty"`
+	Role *OrganizationRole `json:"role,omitempty"`
+}
+
+// NewParamsUpdateIdentity returns a new params for updating a machine identity.
+func NewParamsUpdateIdentity() *ParamsUpdateIdentity {
+	return &ParamsUpdateIdentity{}
+}
+
+func (p *ParamsUpdateIdentity) SetName(name string) *ParamsUpdateIdentity {
+	p.Name = &name
+	return p
+}
+
+func (p *ParamsUpdateIdentity) SetRole(role OrganizationRole) *ParamsUpdateIdentity {
+	p.Role = &role
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsUpdateIdentity) validate() error {
+	if p.Name == nil && p.Role == nil {
+		return fmt.Errorf("%w: nothing to update", ErrInvalidParams)
+	}
+	errs := []error{}
+	if p.Name != nil && *p.Name == "" {
+		errs = append(errs, fmt.Errorf("%w: identity name is empty", ErrInvalidParams))
+	}
+	if p.Role != nil {
+		errs = append(errs, validateOrganizationRole(*p.Role))
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateIdentity updates the name or organization role of a machine identity.
+//
+// https://infisical.com/docs/api-reference/endpoints/identities/update
+func (c *Client) UpdateIdentity(identityID string, params *ParamsUpdateIdentity) (result IdentityData, err error) {
+	return c.UpdateIdentityWithContext(context.Background(), identityID, params)
+}
+
+// UpdateIdentityWithContext is the same as `UpdateIdentity` with given context.
+func (c *Client) UpdateIdentityWithContext(ctx context.Context, identityID string, params *ParamsUpdateIdentity) (result IdentityData, err error) {
+	if params == nil {
+		params = NewParamsUpdateIdentity()
+	}
+	if err = errors.Join(validateID("identity id", identityID), params.validate()); err != nil {
+		return IdentityData{}, fmt.Errorf("failed to update an identity: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/identities/%s", identityID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityData{}, fmt.Errorf("failed to update an identity: %w", err)
+}
+
+// DeleteIdentity deletes a machine identity (and all of its memberships).
+//
+// https://infisical.com/docs/api-reference/endpoints/identities/delete
+func (c *Client) DeleteIdentity(identityID string) (result IdentityData, err error) {
+	return c.DeleteIdentityWithContext(context.Background(), identityID)
+}
+
+// DeleteIdentityWithContext is the same as `DeleteIdentity` with given context.
+func (c *Client) DeleteIdentityWithContext(ctx context.Context, identityID string) (result IdentityData, err error) {
+	if err = validateID("identity id", identityID); err != nil {
+		return IdentityData{}, fmt.Errorf("failed to delete an identity: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "DELETE", fmt.Sprintf("/v1/identities/%s", identityID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityData{}, fmt.Errorf("failed to delete an identity: %w", err)
+}
+
+// GetIdentity retrieves a machine identity with its organization membership.
+func (c *Client) GetIdentity(identityID string) (result IdentityMembershipOfOrganizationData, err error) {
+	return c.GetIdentityWithContext(context.Background(), identityID)
+}
+
+// GetIdentityWithContext is the same as `GetIdentity` with given context.
+func (c *Client) GetIdentityWithContext(ctx context.Context, identityID string) (result IdentityMembershipOfOrganizationData, err error) {
+	if err = validateID("identity id", identityID); err != nil {
+		return IdentityMembershipOfOrganizationData{}, fmt.Errorf("failed to get an identity: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/identities/%s", identityID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityMembershipOfOrganizationData{}, fmt.Errorf("failed to get an identity: %w", err)
+}
+
+// ListIdentities lists machine identities of an organization with their organization memberships.
+func (c *Client) ListIdentities(organizationID string) (result IdentityMembershipsOfOrganizationData, err error) {
+	return c.ListIdentitiesWithContext(context.Background(), organizationID)
+}
+
+// ListIdentitiesWithContext is the same as `ListIdentities` with given context.
+func (c *Client) ListIdentitiesWithContext(ctx context.Context, organizationID string) (result IdentityMembershipsOfOrganizationData, err error) {
+	if err = validateID("organization id", organizationID); err != nil {
+		return IdentityMembershipsOfOrganizationData{}, fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", "/v1/identities", AuthMethodNormal, map[string]any{
+		"orgId": organizationID,
+	})
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityMembershipsOfOrganizationData{}, fmt.Errorf("failed to list identities: %w", err)
+}
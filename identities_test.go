@@ -0,0 +1,96 @@
+package infisical
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestIdentities(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	target.fakeServer(t)
+	workspace := target.workspace
+
+	client := target.newClient(t)
+
+	// (create an identity)
+	var identityID string
+	if created, err := client.CreateIdentity(infisicaltest.DefaultOrganizationID, "payments-service", OrganizationRoleNoAccess); err != nil {
+		t.Fatalf("failed to create an identity: %s", err)
+	} else if created.Identity.ID == "" || created.Identity.Name != "payments-service" || len(created.Identity.AuthMethods) != 0 {
+		t.Errorf("unexpected identity: %+v", created.Identity)
+	} else {
+		identityID = created.Identity.ID
+	}
+
+	// (list identities)
+	if identities, err := client.ListIdentities(infisicaltest.DefaultOrganizationID); err != nil {
+		t.Errorf("failed to list identities: %s", err)
+	} else if len(identities.Identities) != 2 {
+		t.Errorf("unexpected number of identities: %d", len(identities.Identities))
+	}
+
+	// (update and get an identity)
+	if updated, err := client.UpdateIdentity(identityID, NewParamsUpdateIdentity().
+		SetName("payments-worker").
+		SetRole(OrganizationRoleMember),
+	); err != nil {
+		t.Errorf("failed to update an identity: %s", err)
+	} else if updated.Identity.Name != "payments-worker" {
+		t.Errorf("unexpected identity: %+v", updated.Identity)
+	}
+	if identity, err := client.GetIdentity(identityID); err != nil {
+		t.Errorf("failed to get an identity: %s", err)
+	} else if identity.Identity.IdentityID != identityID ||
+		identity.Identity.OrganizationID != infisicaltest.DefaultOrganizationID ||
+		identity.Identity.Role != OrganizationRoleMember ||
+		identity.Identity.Identity.Name != "payments-worker" {
+		t.Errorf("unexpected identity: %+v", identity.Identity)
+	}
+
+	// (unknown organization roles)
+	if _, err := client.UpdateIdentity(identityID, NewParamsUpdateIdentity().SetRole("owner")); err == nil {
+		t.Errorf("unknown organization roles should be rejected")
+	}
+
+	// (delete an identity with its memberships)
+	if _, err := client.CreateIdentityMembership(workspace.ID, identityID, []RoleAssignment{PermanentRole(RoleViewer)}); err != nil {
+		t.Errorf("failed to create an identity membership: %s", err)
+	}
+	if _, err := client.DeleteIdentity(identityID); err != nil {
+		t.Errorf("failed to delete an identity: %s", err)
+	}
+	if _, err := client.GetIdentity(identityID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted identity should not be found: %v", err)
+	}
+	if _, err := client.GetIdentityMembership(workspace.ID, identityID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("memberships of the deleted identity should not be found: %v", err)
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing name": func(client *Client) error {
+			_, err := client.CreateIdentity(infisicaltest.DefaultOrganizationID, "", OrganizationRoleMember)
+			return err
+		},
+		"missing role": func(client *Client) error {
+			_, err := client.CreateIdentity(infisicaltest.DefaultOrganizationID, "service", "")
+			return err
+		},
+		"missing organization id": func(client *Client) error {
+			_, err := client.ListIdentities("")
+			return err
+		},
+		"nothing to update": func(client *Client) error {
+			_, err := client.UpdateIdentity(identityID, nil)
+			return err
+		},
+		"missing identity id": func(client *Client) error {
+			_, err := client.DeleteIdentity("")
+			return err
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package infisicaltest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// organization struct
+type organization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// identity struct for machine identities
+type identity struct {
+	ID             string
+	Name           string
+	OrganizationID string
+	Role           string         // slug of the organization role
+	UniversalAuth  *universalAuth // nil if universal-auth is not attached
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// accessToken struct for issued access tokens
+type accessToken struct {
+	IdentityID   string
+	TTL          int64
+	ExpiresAt    time.Time
+	MaxExpiresAt time.Time
+	NumUses      int64
+	NumUsesLimit int64    // 0 for unlimited
+	TrustedIPs   []string // CIDRs of trusted IPs (empty for any)
+}
+
+// valid checks if the token is valid at given time.
+func (t *accessToken) valid(now time.Time) bool {
+	return now.Before(t.ExpiresAt) && now.Before(t.MaxExpiresAt) && (t.NumUsesLimit == 0 || t.NumUses < t.NumUsesLimit)
+}
+
+// accessTokenResponse struct for login/renew responses
+type accessTokenResponse struct {
+	AccessToken       string `json:"accessToken"`
+	AccessTokenMaxTTL int64  `json:"accessTokenMaxTTL"`
+	ExpiresIn         int64  `json:"expiresIn"`
+	TokenType         string `json:"tokenType"`
+}
+
+// IssueAccessToken issues a new access token for given identity id, and returns it.
+func (s *Server) IssueAccessToken(identityID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, _ := s.issueAccessToken(identityID)
+	return token
+}
+
+// ExpireAccessTokens expires all issued access tokens.
+func (s *Server) ExpireAccessTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.tokens {
+		token.ExpiresAt = time.Now()
+	}
+}
+
+// issueAccessToken issues a new access token for given identity id.
+//
+// (TTLs, number of uses, and trusted IPs follow the identity's universal-auth configuration, if any)
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) issueAccessToken(identityID string) (string, *accessToken) {
+	token := "test-access-token-" + randomHex(16)
+
+	ttl, maxTTL := s.accessTokenTTL, s.accessTokenMaxTTL
+	issued := &accessToken{
+		IdentityID: identityID,
+	}
+	if i := s.findIdentity(identityID); i != nil && i.UniversalAuth != nil {
+		ttl, maxTTL = s.universalAuthTTLs(i.UniversalAuth)
+		issued.NumUsesLimit = i.UniversalAuth.AccessTokenNumUsesLimit
+		issued.TrustedIPs = i.UniversalAuth.AccessTokenTrustedIPs
+	}
+
+	now := time.Now()
+	issued.TTL = ttl
+	issued.ExpiresAt = now.Add(time.Duration(ttl) * time.Second)
+	issued.MaxExpiresAt = now.Add(time.Duration(maxTTL) * time.Second)
+	s.tokens[token] = issued
+
+	return token, issued
+}
+
+// randomHex returns a random hex string of given number of bytes.
+func randomHex(n int) string {
+	bytes := make([]byte, n)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
+
+// registerAuthRoutes registers routes for authentication.
+func (s *Server) registerAuthRoutes() {
+	// universal-auth login
+	s.handlePublic("POST", "/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body struct {
+			ClientID     string `json:"clientId"`
+			ClientSecret string `json:"clientSecret"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		now := time.Now()
+		for _, identity := range s.identities {
+			if identity.UniversalAuth == nil || identity.UniversalAuth.ClientID != body.ClientID {
+				continue
+			}
+			for _, secret := range identity.UniversalAuth.ClientSecrets {
+				if secret.Secret != body.ClientSecret || !secret.usable(now) {
+					continue
+				}
+				if !ipTrusted(identity.UniversalAuth.ClientSecretTrustedIPs, r.RemoteAddr) {
+					writeError(w, http.StatusForbidden, "ForbiddenError", "Client IP is not trusted")
+					return
+				}
+				secret.NumUses++
+
+				token, issued := s.issueAccessToken(identity.ID)
+				writeJSON(w, accessTokenResponse{
+					AccessToken:       token,
+					AccessTokenMaxTTL: int64(issued.MaxExpiresAt.Sub(now).Round(time.Second).Seconds()),
+					ExpiresIn:         issued.TTL,
+					TokenType:         "Bearer",
+				})
+				return
+			}
+		}
+
+		writeError(w, http.StatusUnauthorized, "UnauthorizedError", "Invalid credentials")
+	})
+
+	// renew access token
+	s.handlePublic("POST", "/api/v1/auth/token/renew", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body struct {
+			AccessToken string `json:"accessToken"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		now := time.Now()
+		token, exists := s.tokens[body.AccessToken]
+		if !exists || !token.valid(now) {
+			writeError(w, http.StatusUnauthorized, "UnauthorizedError", "Access token is invalid or expired")
+			return
+		}
+
+		token.ExpiresAt = now.Add(time.Duration(token.TTL) * time.Second)
+		if token.ExpiresAt.After(token.MaxExpiresAt) {
+			token.ExpiresAt = token.MaxExpiresAt
+		}
+
+		writeJSON(w, accessTokenResponse{
+			AccessToken:       body.AccessToken,
+			AccessTokenMaxTTL: int64(token.MaxExpiresAt.Sub(now).Seconds()),
+			ExpiresIn:         int64(token.ExpiresAt.Sub(now).Seconds()),
+			TokenType:         "Bearer",
+		})
+	})
+
+	// revoke access token
+	s.handlePublic("POST", "/api/v1/auth/token/revoke", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body struct {
+			AccessToken string `json:"accessToken"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, exists := s.tokens[body.AccessToken]; !exists {
+			writeError(w, http.StatusUnauthorized, "UnauthorizedError", "Access token is invalid")
+			return
+		}
+		delete(s.tokens, body.AccessToken)
+
+		writeJSON(w, map[string]any{"message": "Successfully revoked access token"})
+	})
+}
@@ -0,0 +1,270 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// folder struct for secret folders
+type folder struct {
+	ID          string
+	WorkspaceID string
+	Environment string
+	ParentPath  string
+	Name        string
+	Version     int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// path returns the full path of the folder.
+func (f *folder) path() string {
+	return normalizePath(f.ParentPath + "/" + f.Name)
+}
+
+// folderResponse struct for folders in responses
+type folderResponse struct {
+	CreatedAt     string  `json:"createdAt"`
+	EnvironmentID string  `json:"envId"`
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	ParentID      *string `json:"parentId,omitempty"`
+	UpdatedAt     string  `json:"updatedAt"`
+	Version       int     `json:"version,omitempty"`
+}
+
+// AddFolder adds a folder with given name under `path`, and returns its id.
+func (s *Server) AddFolder(workspaceID, environment, path, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addFolder(workspaceID, environment, path, name).ID
+}
+
+// addFolder adds a folder.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addFolder(workspaceID, environment, path, name string) *folder {
+	now := time.Now()
+	created := &folder{
+		ID:          s.newID("folder"),
+		WorkspaceID: workspaceID,
+		Environment: environment,
+		ParentPath:  normalizePath(path),
+		Name:        name,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.folders = append(s.folders, created)
+	return created
+}
+
+// findFolderByPath finds a folder with given full path.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findFolderByPath(workspaceID, environment, path string) *folder {
+	path = normalizePath(path)
+	for _, f := range s.folders {
+		if f.WorkspaceID == workspaceID && f.Environment == environment && f.path() == path {
+			return f
+		}
+	}
+	return nil
+}
+
+// response converts the folder into a response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) folderResponse(f *folder) folderResponse {
+	res := folderResponse{
+		CreatedAt: f.CreatedAt.UTC().Format(time.RFC3339),
+		ID:        f.ID,
+		Name:      f.Name,
+		UpdatedAt: f.UpdatedAt.UTC().Format(time.RFC3339),
+		Version:   f.Version,
+	}
+	if env := s.findEnvironment(f.WorkspaceID, f.Environment); env != nil {
+		res.EnvironmentID = env.ID
+	}
+	if parent := s.findFolderByPath(f.WorkspaceID, f.Environment, f.ParentPath); parent != nil {
+		res.ParentID = &parent.ID
+	}
+	return res
+}
+
+// isUnderPath checks if `path` is `parent` itself or a descendant of it.
+func isUnderPath(path, parent string) bool {
+	path, parent = normalizePath(path), normalizePath(parent)
+	return path == parent || strings.HasPrefix(path, strings.TrimSuffix(parent, "/")+"/")
+}
+
+// folderPathParam returns the path from `path` or (deprecated) `directory` parameter.
+func folderPathParam(path, directory string) string {
+	if path == "" {
+		path = directory
+	}
+	return normalizePath(path)
+}
+
+// registerFolderRoutes registers routes for folders.
+func (s *Server) registerFolderRoutes() {
+	// list folders
+	s.handle("GET", "/api/v1/folders", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		query := r.URL.Query()
+		workspaceID, environment := query.Get("workspaceId"), query.Get("environment")
+		path := folderPathParam(query.Get("path"), query.Get("directory"))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, workspaceID, environment) {
+			return
+		}
+
+		folders := []folderResponse{}
+		for _, f := range s.folders {
+			if f.WorkspaceID == workspaceID && f.Environment == environment && f.ParentPath == path {
+				folders = append(folders, s.folderResponse(f))
+			}
+		}
+		writeJSON(w, map[string]any{"folders": folders})
+	})
+
+	// create a folder
+	s.handle("POST", "/api/v1/folders", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body struct {
+			WorkspaceID string `json:"workspaceId"`
+			Environment string `json:"environment"`
+			Name        string `json:"name"`
+			Path        string `json:"path"`
+			Directory   string `json:"directory"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+		path := folderPathParam(body.Path, body.Directory)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		if body.Name == "" || strings.Contains(body.Name, "/") {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Invalid folder name")
+			return
+		}
+		if s.findFolderByPath(body.WorkspaceID, body.Environment, path+"/"+body.Name) != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Folder with same name already exists")
+			return
+		}
+
+		created := s.addFolder(body.WorkspaceID, body.Environment, path, body.Name)
+		writeJSON(w, map[string]any{"folder": s.folderResponse(created)})
+	})
+
+	// update a folder
+	s.handle("PATCH", "/api/v1/folders/{folderId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			WorkspaceID string `json:"workspaceId"`
+			Environment string `json:"environment"`
+			Name        string `json:"name"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		for _, f := range s.folders {
+			if f.ID == params["folderId"] && f.WorkspaceID == body.WorkspaceID && f.Environment == body.Environment {
+				// move secrets and sub folders to the renamed path
+				oldPath := f.path()
+				f.Name = body.Name
+				f.Version++
+				f.UpdatedAt = time.Now()
+				s.movePath(body.WorkspaceID, body.Environment, oldPath, f.path())
+
+				writeJSON(w, map[string]any{"folder": s.folderResponse(f)})
+				return
+			}
+		}
+
+		writeError(w, http.StatusNotFound, "NotFound", "Folder not found")
+	})
+
+	// delete a folder
+	s.handle("DELETE", "/api/v1/folders/{folderId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			WorkspaceID string `json:"workspaceId"`
+			Environment string `json:"environment"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		for _, f := range s.folders {
+			if f.ID == params["folderId"] && f.WorkspaceID == body.WorkspaceID && f.Environment == body.Environment {
+				deleted := s.folderResponse(f)
+				s.deletePath(body.WorkspaceID, body.Environment, f.path())
+
+				writeJSON(w, map[string]any{"folder": deleted})
+				return
+			}
+		}
+
+		writeError(w, http.StatusNotFound, "NotFound", "Folder not found")
+	})
+}
+
+// movePath moves folders and secrets under `from` to `to`.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) movePath(workspaceID, environment, from, to string) {
+	rebase := func(path string) string {
+		return normalizePath(to + strings.TrimPrefix(path, from))
+	}
+	for _, f := range s.folders {
+		if f.WorkspaceID == workspaceID && f.Environment == environment && isUnderPath(f.ParentPath, from) {
+			f.ParentPath = rebase(f.ParentPath)
+		}
+	}
+	for _, secret := range s.secrets {
+		if secret.WorkspaceID == workspaceID && secret.Environment == environment && isUnderPath(secret.Path, from) {
+			secret.Path = rebase(secret.Path)
+		}
+	}
+}
+
+// deletePath deletes the folder at `path` with all folders and secrets under it.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) deletePath(workspaceID, environment, path string) {
+	folders := s.folders[:0]
+	for _, f := range s.folders {
+		if f.WorkspaceID != workspaceID || f.Environment != environment || !isUnderPath(f.path(), path) {
+			folders = append(folders, f)
+		}
+	}
+	s.folders = folders
+
+	secrets := s.secrets[:0]
+	for _, secret := range s.secrets {
+		if secret.WorkspaceID != workspaceID || secret.Environment != environment || !isUnderPath(secret.Path, path) {
+			secrets = append(secrets, secret)
+		}
+	}
+	s.secrets = secrets
+}
@@ -0,0 +1,195 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+)
+
+// built-in organization roles
+var builtInOrganizationRoles = []string{"admin", "member", "no-access"}
+
+// identityResponse struct for machine identities in responses
+type identityResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	AuthMethods []string `json:"authMethods"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
+
+// identityOrganizationMembershipResponse struct for machine identities' organization memberships in responses
+type identityOrganizationMembershipResponse struct {
+	ID         string           `json:"id"`
+	IdentityID string           `json:"identityId"`
+	OrgID      string           `json:"orgId"`
+	Role       string           `json:"role"`
+	Identity   identityResponse `json:"identity"`
+	CreatedAt  string           `json:"createdAt"`
+	UpdatedAt  string           `json:"updatedAt"`
+}
+
+// authMethods returns the names of attached auth methods.
+func (i *identity) authMethods() []string {
+	methods := []string{}
+	if i.UniversalAuth != nil {
+		methods = append(methods, "universal-auth")
+	}
+	return methods
+}
+
+// response converts the identity into a response.
+func (i *identity) response() identityResponse {
+	return identityResponse{
+		ID:          i.ID,
+		Name:        i.Name,
+		AuthMethods: i.authMethods(),
+		CreatedAt:   i.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   i.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// membershipResponse converts the identity into a response of its organization membership.
+func (i *identity) membershipResponse() identityOrganizationMembershipResponse {
+	return identityOrganizationMembershipResponse{
+		ID:         "membership-" + i.ID,
+		IdentityID: i.ID,
+		OrgID:      i.OrganizationID,
+		Role:       i.Role,
+		Identity:   i.response(),
+		CreatedAt:  i.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:  i.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// addIdentity adds a new machine identity without any auth method.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addIdentity(organizationID, name, role string) *identity {
+	now := time.Now()
+	added := &identity{
+		ID:             s.newID("identity"),
+		Name:           name,
+		OrganizationID: organizationID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	s.identities = append(s.identities, added)
+	return added
+}
+
+// registerIdentityRoutes registers routes for machine identities.
+func (s *Server) registerIdentityRoutes() {
+	// create an identity
+	s.handle("POST", "/api/v1/identities", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body struct {
+			Name           string `json:"name"`
+			OrganizationID string `json:"organizationId"`
+			Role           string `json:"role"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !slices.ContainsFunc(s.organizations, func(o *organization) bool { return o.ID == body.OrganizationID }) {
+			writeError(w, http.StatusNotFound, "NotFound", "Organization not found")
+			return
+		}
+		if body.Name == "" {
+			writeError(w, http.StatusBadRequest, "BadRequest", "name is required")
+			return
+		}
+		if !slices.Contains(builtInOrganizationRoles, body.Role) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Role not found: "+body.Role)
+			return
+		}
+
+		created := s.addIdentity(body.OrganizationID, body.Name, body.Role)
+		writeJSON(w, map[string]any{"identity": created.response()})
+	})
+
+	// list identities of an organization
+	s.handle("GET", "/api/v1/identities", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		identities := []identityOrganizationMembershipResponse{}
+		for _, i := range s.identities {
+			if i.OrganizationID == r.URL.Query().Get("orgId") {
+				identities = append(identities, i.membershipResponse())
+			}
+		}
+		writeJSON(w, map[string]any{"identities": identities})
+	})
+
+	// get an identity
+	s.handle("GET", "/api/v1/identities/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity not found")
+			return
+		}
+		writeJSON(w, map[string]any{"identity": i.membershipResponse()})
+	})
+
+	// update an identity
+	s.handle("PATCH", "/api/v1/identities/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Name *string `json:"name"`
+			Role *string `json:"role"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity not found")
+			return
+		}
+		if body.Role != nil && !slices.Contains(builtInOrganizationRoles, *body.Role) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Role not found: "+*body.Role)
+			return
+		}
+
+		if body.Name != nil {
+			i.Name = *body.Name
+		}
+		if body.Role != nil {
+			i.Role = *body.Role
+		}
+		i.UpdatedAt = time.Now()
+		writeJSON(w, map[string]any{"identity": i.response()})
+	})
+
+	// delete an identity (with its memberships and access tokens)
+	s.handle("DELETE", "/api/v1/identities/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity not found")
+			return
+		}
+
+		s.identities = slices.DeleteFunc(s.identities, func(other *identity) bool { return other == i })
+		s.identityMemberships = slices.DeleteFunc(s.identityMemberships, func(membership *identityMembership) bool { return membership.IdentityID == i.ID })
+		for token, issued := range s.tokens {
+			if issued.IdentityID == i.ID {
+				delete(s.tokens, token)
+			}
+		}
+		writeJSON(w, map[string]any{"identity": i.response()})
+	})
+}
@@ -0,0 +1,219 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// identityMembership struct for machine identities' memberships of projects
+type identityMembership struct {
+	ID          string
+	WorkspaceID string
+	IdentityID  string
+	Roles       []*membershipRole
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// identityMembershipResponse struct for identity memberships in responses
+type identityMembershipResponse struct {
+	ID         string                   `json:"id"`
+	IdentityID string                   `json:"identityId"`
+	ProjectID  string                   `json:"projectId"`
+	Identity   map[string]any           `json:"identity"`
+	Roles      []membershipRoleResponse `json:"roles"`
+	CreatedAt  string                   `json:"createdAt"`
+	UpdatedAt  string                   `json:"updatedAt"`
+}
+
+// findIdentity finds a machine identity with given id.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findIdentity(identityID string) *identity {
+	for _, i := range s.identities {
+		if i.ID == identityID {
+			return i
+		}
+	}
+	return nil
+}
+
+// findIdentityMembership finds a machine identity's membership of given workspace.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findIdentityMembership(workspaceID, identityID string) *identityMembership {
+	for _, membership := range s.identityMemberships {
+		if membership.WorkspaceID == workspaceID && membership.IdentityID == identityID {
+			return membership
+		}
+	}
+	return nil
+}
+
+// identityMembershipResponse converts given membership into a response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) identityMembershipResponse(membership *identityMembership) identityMembershipResponse {
+	res := identityMembershipResponse{
+		ID:         membership.ID,
+		IdentityID: membership.IdentityID,
+		ProjectID:  membership.WorkspaceID,
+		Identity:   map[string]any{"id": membership.IdentityID},
+		Roles:      []membershipRoleResponse{},
+		CreatedAt:  membership.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:  membership.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	if i := s.findIdentity(membership.IdentityID); i != nil {
+		res.Identity["name"] = i.Name
+		res.Identity["authMethods"] = i.authMethods()
+	}
+	for _, role := range membership.Roles {
+		res.Roles = append(res.Roles, role.response())
+	}
+	return res
+}
+
+// registerProjectIdentityRoutes registers routes for machine identities' memberships of projects.
+func (s *Server) registerProjectIdentityRoutes() {
+	// add an identity to a project
+	s.handle("POST", "/api/v2/workspace/{workspaceId}/identity-memberships/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Role  string               `json:"role"`
+			Roles []membershipRoleBody `json:"roles"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if body.Role != "" {
+			body.Roles = append(body.Roles, membershipRoleBody{Role: body.Role})
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.findWorkspace(params["workspaceId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		if s.findIdentity(params["identityId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity not found")
+			return
+		}
+		if s.findIdentityMembership(params["workspaceId"], params["identityId"]) != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Identity is already a member of the project")
+			return
+		}
+		roles, ok := s.newMembershipRoles(w, params["workspaceId"], body.Roles)
+		if !ok {
+			return
+		}
+
+		now := time.Now()
+		created := &identityMembership{
+			ID:          s.newID("identity-membership"),
+			WorkspaceID: params["workspaceId"],
+			IdentityID:  params["identityId"],
+			Roles:       roles,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		s.identityMemberships = append(s.identityMemberships, created)
+		writeJSON(w, map[string]any{"identityMembership": s.identityMembershipResponse(created)})
+	})
+
+	// list identities' memberships of a project
+	s.handle("GET", "/api/v2/workspace/{workspaceId}/identity-memberships", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 50
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.findWorkspace(params["workspaceId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+
+		matched := []*identityMembership{}
+		for _, membership := range s.identityMemberships {
+			if membership.WorkspaceID != params["workspaceId"] {
+				continue
+			}
+			if name := query.Get("identityName"); name != "" {
+				if i := s.findIdentity(membership.IdentityID); i == nil || !strings.Contains(i.Name, name) {
+					continue
+				}
+			}
+			matched = append(matched, membership)
+		}
+
+		memberships := []identityMembershipResponse{}
+		for i, membership := range matched {
+			if i >= offset && i < offset+limit {
+				memberships = append(memberships, s.identityMembershipResponse(membership))
+			}
+		}
+		writeJSON(w, map[string]any{"identityMemberships": memberships, "totalCount": len(matched)})
+	})
+
+	// get an identity's membership of a project
+	s.handle("GET", "/api/v2/workspace/{workspaceId}/identity-memberships/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		membership := s.findIdentityMembership(params["workspaceId"], params["identityId"])
+		if membership == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity membership not found")
+			return
+		}
+		writeJSON(w, map[string]any{"identityMembership": s.identityMembershipResponse(membership)})
+	})
+
+	// update roles of an identity's membership
+	s.handle("PATCH", "/api/v2/workspace/{workspaceId}/identity-memberships/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Roles []membershipRoleBody `json:"roles"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		membership := s.findIdentityMembership(params["workspaceId"], params["identityId"])
+		if membership == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity membership not found")
+			return
+		}
+		roles, ok := s.newMembershipRoles(w, params["workspaceId"], body.Roles)
+		if !ok {
+			return
+		}
+
+		membership.Roles = roles
+		membership.UpdatedAt = time.Now()
+		writeJSON(w, map[string]any{"roles": s.identityMembershipResponse(membership).Roles})
+	})
+
+	// remove an identity from a project
+	s.handle("DELETE", "/api/v2/workspace/{workspaceId}/identity-memberships/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		membership := s.findIdentityMembership(params["workspaceId"], params["identityId"])
+		if membership == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity membership not found")
+			return
+		}
+		s.identityMemberships = slices.DeleteFunc(s.identityMemberships, func(m *identityMembership) bool { return m == membership })
+		writeJSON(w, map[string]any{"identityMembership": s.identityMembershipResponse(membership)})
+	})
+}
@@ -0,0 +1,267 @@
+package infisicaltest
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// allowed slugs of roles
+var roleSlugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// projectRole struct for custom roles of projects
+type projectRole struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Slug        string
+	Description string
+	Permissions json.RawMessage
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// response converts the role into a response.
+func (r *projectRole) response() map[string]any {
+	res := map[string]any{
+		"id":          r.ID,
+		"name":        r.Name,
+		"slug":        r.Slug,
+		"projectId":   r.WorkspaceID,
+		"permissions": r.Permissions,
+		"createdAt":   r.CreatedAt.Format(time.RFC3339Nano),
+		"updatedAt":   r.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	if r.Description != "" {
+		res["description"] = r.Description
+	}
+	return res
+}
+
+// projectRoleBody struct for request bodies of project roles
+type projectRoleBody struct {
+	Slug        *string         `json:"slug"`
+	Name        *string         `json:"name"`
+	Description *string         `json:"description"`
+	Permissions json.RawMessage `json:"permissions"`
+}
+
+// findProjectRole finds a custom role of given workspace with given slug.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findProjectRole(workspaceID, slug string) *projectRole {
+	for _, role := range s.projectRoles {
+		if role.WorkspaceID == workspaceID && role.Slug == slug {
+			return role
+		}
+	}
+	return nil
+}
+
+// checkProjectRoleBody checks slug and permissions of given body, or writes an error response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) checkProjectRoleBody(w http.ResponseWriter, workspaceID string, body projectRoleBody, except *projectRole) bool {
+	if body.Slug != nil {
+		if !roleSlugRegexp.MatchString(*body.Slug) || slices.Contains(builtInProjectRoles, *body.Slug) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Invalid role slug: "+*body.Slug)
+			return false
+		}
+		if found := s.findProjectRole(workspaceID, *body.Slug); found != nil && found != except {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Role with slug '"+*body.Slug+"' already exists")
+			return false
+		}
+	}
+	if body.Permissions != nil {
+		var permissions []struct {
+			Subject json.RawMessage `json:"subject"`
+			Action  json.RawMessage `json:"action"`
+		}
+		if err := json.Unmarshal(body.Permissions, &permissions); err != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Invalid permissions: "+err.Error())
+			return false
+		}
+		for _, p := range permissions {
+			if p.Subject == nil || p.Action == nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Permissions should have subjects and actions")
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// registerProjectRoleRoutes registers routes for custom roles of projects.
+func (s *Server) registerProjectRoleRoutes() {
+	// create a role
+	s.handle("POST", "/api/v1/workspace/{projectSlug}/roles", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body projectRoleBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspaceBySlug(params["projectSlug"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		if body.Slug == nil || body.Name == nil || body.Permissions == nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "slug, name, and permissions are required")
+			return
+		}
+		if !s.checkProjectRoleBody(w, workspace.ID, body, nil) {
+			return
+		}
+
+		now := time.Now()
+		created := &projectRole{
+			ID:          s.newID("role"),
+			WorkspaceID: workspace.ID,
+			Name:        *body.Name,
+			Slug:        *body.Slug,
+			Permissions: body.Permissions,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if body.Description != nil {
+			created.Description = *body.Description
+		}
+		s.projectRoles = append(s.projectRoles, created)
+		writeJSON(w, map[string]any{"role": created.response()})
+	})
+
+	// list roles
+	s.handle("GET", "/api/v1/workspace/{projectSlug}/roles", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspaceBySlug(params["projectSlug"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+
+		roles := []map[string]any{}
+		for _, role := range s.projectRoles {
+			if role.WorkspaceID == workspace.ID {
+				roles = append(roles, role.response())
+			}
+		}
+		writeJSON(w, map[string]any{"roles": roles})
+	})
+
+	// get a role by slug
+	s.handle("GET", "/api/v1/workspace/{projectSlug}/roles/slug/{slug}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspaceBySlug(params["projectSlug"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		role := s.findProjectRole(workspace.ID, params["slug"])
+		if role == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Role not found")
+			return
+		}
+		writeJSON(w, map[string]any{"role": role.response()})
+	})
+
+	// update a role
+	s.handle("PATCH", "/api/v1/workspace/{projectSlug}/roles/{roleId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body projectRoleBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspaceBySlug(params["projectSlug"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		i := slices.IndexFunc(s.projectRoles, func(role *projectRole) bool {
+			return role.ID == params["roleId"] && role.WorkspaceID == workspace.ID
+		})
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Role not found")
+			return
+		}
+		role := s.projectRoles[i]
+		if !s.checkProjectRoleBody(w, workspace.ID, body, role) {
+			return
+		}
+
+		if body.Slug != nil {
+			// (memberships follow the renamed role)
+			for _, r := range s.membershipRoles() {
+				if r.CustomRoleID == role.ID {
+					r.CustomRole = *body.Slug
+				}
+			}
+			role.Slug = *body.Slug
+		}
+		if body.Name != nil {
+			role.Name = *body.Name
+		}
+		if body.Description != nil {
+			role.Description = *body.Description
+		}
+		if body.Permissions != nil {
+			role.Permissions = body.Permissions
+		}
+		role.UpdatedAt = time.Now()
+		writeJSON(w, map[string]any{"role": role.response()})
+	})
+
+	// delete a role
+	s.handle("DELETE", "/api/v1/workspace/{projectSlug}/roles/{roleId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspaceBySlug(params["projectSlug"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		i := slices.IndexFunc(s.projectRoles, func(role *projectRole) bool {
+			return role.ID == params["roleId"] && role.WorkspaceID == workspace.ID
+		})
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Role not found")
+			return
+		}
+		for _, r := range s.membershipRoles() {
+			if r.CustomRoleID == params["roleId"] {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Role is assigned to memberships")
+				return
+			}
+		}
+
+		deleted := s.projectRoles[i]
+		s.projectRoles = slices.Delete(s.projectRoles, i, i+1)
+		writeJSON(w, map[string]any{"role": deleted.response()})
+	})
+}
+
+// membershipRoles returns all roles assigned to users' and identities' memberships.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) membershipRoles() []*membershipRole {
+	roles := []*membershipRole{}
+	for _, membership := range s.projectMemberships {
+		roles = append(roles, membership.Roles...)
+	}
+	for _, membership := range s.identityMemberships {
+		roles = append(roles, membership.Roles...)
+	}
+	return roles
+}
@@ -0,0 +1,420 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// built-in project roles
+var builtInProjectRoles = []string{"admin", "member", "viewer", "no-access"}
+
+// user struct for users of the organization
+type user struct {
+	ID        string
+	Email     string
+	Username  string
+	FirstName string
+	LastName  string
+}
+
+// userResponse struct for users in responses
+type userResponse struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// membershipRole struct for roles assigned to project memberships
+type membershipRole struct {
+	ID           string
+	Role         string // slug of a built-in role, or "custom"
+	CustomRoleID string
+	CustomRole   string // slug of a custom role
+	IsTemporary  bool
+
+	TemporaryMode  string
+	TemporaryRange string
+	StartTime      time.Time
+	EndTime        time.Time
+}
+
+// membershipRoleResponse struct for roles in responses
+type membershipRoleResponse struct {
+	ID                       string  `json:"id"`
+	Role                     string  `json:"role"`
+	CustomRoleID             *string `json:"customRoleId,omitempty"`
+	CustomRoleSlug           *string `json:"customRoleSlug,omitempty"`
+	IsTemporary              bool    `json:"isTemporary"`
+	TemporaryMode            *string `json:"temporaryMode,omitempty"`
+	TemporaryRange           *string `json:"temporaryRange,omitempty"`
+	TemporaryAccessStartTime *string `json:"temporaryAccessStartTime,omitempty"`
+	TemporaryAccessEndTime   *string `json:"temporaryAccessEndTime,omitempty"`
+}
+
+// response converts the role into a response.
+func (r *membershipRole) response() membershipRoleResponse {
+	res := membershipRoleResponse{
+		ID:          r.ID,
+		Role:        r.Role,
+		IsTemporary: r.IsTemporary,
+	}
+	if r.CustomRole != "" {
+		id, slug := r.CustomRoleID, r.CustomRole
+		res.CustomRoleID, res.CustomRoleSlug = &id, &slug
+	}
+	if r.IsTemporary {
+		mode, rng := r.TemporaryMode, r.TemporaryRange
+		start, end := r.StartTime.UTC().Format(time.RFC3339Nano), r.EndTime.UTC().Format(time.RFC3339Nano)
+		res.TemporaryMode, res.TemporaryRange = &mode, &rng
+		res.TemporaryAccessStartTime, res.TemporaryAccessEndTime = &start, &end
+	}
+	return res
+}
+
+// membershipRoleBody struct for roles in request bodies
+type membershipRoleBody struct {
+	Role                     string `json:"role"`
+	IsTemporary              bool   `json:"isTemporary"`
+	TemporaryMode            string `json:"temporaryMode"`
+	TemporaryRange           string `json:"temporaryRange"`
+	TemporaryAccessStartTime string `json:"temporaryAccessStartTime"`
+}
+
+// projectMembership struct for users' memberships of projects
+type projectMembership struct {
+	ID          string
+	WorkspaceID string
+	UserID      string
+	Roles       []*membershipRole
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// projectMembershipResponse struct for project memberships in responses
+type projectMembershipResponse struct {
+	ID        string                   `json:"id"`
+	UserID    string                   `json:"userId"`
+	ProjectID string                   `json:"projectId"`
+	User      *userResponse            `json:"user,omitempty"`
+	Roles     []membershipRoleResponse `json:"roles,omitempty"`
+	CreatedAt string                   `json:"createdAt"`
+	UpdatedAt string                   `json:"updatedAt"`
+}
+
+// AddUser adds a new user to the organization, and returns its id.
+func (s *Server) AddUser(email, username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := &user{
+		ID:       s.newID("user"),
+		Email:    email,
+		Username: username,
+	}
+	s.users = append(s.users, added)
+	return added.ID
+}
+
+// findUser finds a user with given id.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findUser(userID string) *user {
+	for _, u := range s.users {
+		if u.ID == userID {
+			return u
+		}
+	}
+	return nil
+}
+
+// findUsers finds users with given emails and usernames, and returns the ones which were not found.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findUsers(emails, usernames []string) (found []*user, missing []string) {
+	for _, email := range emails {
+		if i := slices.IndexFunc(s.users, func(u *user) bool { return strings.EqualFold(u.Email, email) }); i >= 0 {
+			found = append(found, s.users[i])
+		} else {
+			missing = append(missing, email)
+		}
+	}
+	for _, username := range usernames {
+		if i := slices.IndexFunc(s.users, func(u *user) bool { return u.Username == username }); i >= 0 {
+			found = append(found, s.users[i])
+		} else {
+			missing = append(missing, username)
+		}
+	}
+	return found, missing
+}
+
+// findProjectMembership finds a user's membership of given workspace.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findProjectMembership(workspaceID, userID string) *projectMembership {
+	for _, membership := range s.projectMemberships {
+		if membership.WorkspaceID == workspaceID && membership.UserID == userID {
+			return membership
+		}
+	}
+	return nil
+}
+
+// projectMembershipResponse converts given membership into a response (with its user and roles if `detailed`).
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) projectMembershipResponse(membership *projectMembership, detailed bool) projectMembershipResponse {
+	res := projectMembershipResponse{
+		ID:        membership.ID,
+		UserID:    membership.UserID,
+		ProjectID: membership.WorkspaceID,
+		CreatedAt: membership.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: membership.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	if detailed {
+		if u := s.findUser(membership.UserID); u != nil {
+			res.User = &userResponse{
+				ID:        u.ID,
+				Email:     u.Email,
+				Username:  u.Username,
+				FirstName: u.FirstName,
+				LastName:  u.LastName,
+			}
+		}
+		res.Roles = []membershipRoleResponse{}
+		for _, role := range membership.Roles {
+			res.Roles = append(res.Roles, role.response())
+		}
+	}
+	return res
+}
+
+// newMembershipRoles validates given roles of a workspace, and converts them, or writes an error response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) newMembershipRoles(w http.ResponseWriter, workspaceID string, bodies []membershipRoleBody) ([]*membershipRole, bool) {
+	if len(bodies) == 0 {
+		writeError(w, http.StatusBadRequest, "BadRequest", "At least one role is required")
+		return nil, false
+	}
+
+	roles := []*membershipRole{}
+	for _, body := range bodies {
+		role := &membershipRole{
+			ID:          s.newID("membership-role"),
+			Role:        body.Role,
+			IsTemporary: body.IsTemporary,
+		}
+		if custom := s.findProjectRole(workspaceID, body.Role); custom != nil {
+			role.Role, role.CustomRoleID, role.CustomRole = "custom", custom.ID, custom.Slug
+		} else if !slices.Contains(builtInProjectRoles, body.Role) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Role not found: "+body.Role)
+			return nil, false
+		}
+		if body.IsTemporary {
+			duration, ok := parseTemporaryRange(body.TemporaryRange)
+			start, err := time.Parse(time.RFC3339Nano, body.TemporaryAccessStartTime)
+			if body.TemporaryMode != "relative" || !ok || err != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Invalid temporary role: "+body.Role)
+				return nil, false
+			}
+			role.TemporaryMode, role.TemporaryRange = body.TemporaryMode, body.TemporaryRange
+			role.StartTime, role.EndTime = start, start.Add(duration)
+		}
+		roles = append(roles, role)
+	}
+	return roles, true
+}
+
+// parseTemporaryRange parses ranges of temporary roles, eg. "90m", "1h", "7d".
+func parseTemporaryRange(value string) (time.Duration, bool) {
+	for _, unit := range []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"ms", time.Millisecond},
+		{"s", time.Second},
+		{"m", time.Minute},
+		{"h", time.Hour},
+		{"d", 24 * time.Hour},
+	} {
+		if n, err := strconv.Atoi(strings.TrimSuffix(value, unit.suffix)); strings.HasSuffix(value, unit.suffix) && err == nil && n > 0 {
+			return time.Duration(n) * unit.duration, true
+		}
+	}
+	return 0, false
+}
+
+// registerProjectUserRoutes registers routes for users' memberships of projects.
+func (s *Server) registerProjectUserRoutes() {
+	// invite users to a project (existing members are skipped)
+	s.handle("POST", "/api/v2/workspace/{workspaceId}/memberships", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Emails    []string `json:"emails"`
+			Usernames []string `json:"usernames"`
+			RoleSlugs []string `json:"roleSlugs"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.findWorkspace(params["workspaceId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		users, missing := s.findUsers(body.Emails, body.Usernames)
+		if len(missing) > 0 {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Users not found in the organization: "+strings.Join(missing, ", "))
+			return
+		}
+		if len(body.RoleSlugs) == 0 {
+			body.RoleSlugs = []string{"member"}
+		}
+		roleBodies := []membershipRoleBody{}
+		for _, slug := range body.RoleSlugs {
+			roleBodies = append(roleBodies, membershipRoleBody{Role: slug})
+		}
+		if _, ok := s.newMembershipRoles(w, params["workspaceId"], roleBodies); !ok {
+			return
+		}
+
+		memberships := []projectMembershipResponse{}
+		for _, u := range users {
+			if s.findProjectMembership(params["workspaceId"], u.ID) != nil {
+				continue
+			}
+			roles, _ := s.newMembershipRoles(w, params["workspaceId"], roleBodies)
+			now := time.Now()
+			created := &projectMembership{
+				ID:          s.newID("membership"),
+				WorkspaceID: params["workspaceId"],
+				UserID:      u.ID,
+				Roles:       roles,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			s.projectMemberships = append(s.projectMemberships, created)
+			memberships = append(memberships, s.projectMembershipResponse(created, false))
+		}
+		writeJSON(w, map[string]any{"memberships": memberships})
+	})
+
+	// remove users from a project
+	s.handle("DELETE", "/api/v2/workspace/{workspaceId}/memberships", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Emails    []string `json:"emails"`
+			Usernames []string `json:"usernames"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		users, missing := s.findUsers(body.Emails, body.Usernames)
+		found := []*projectMembership{}
+		for _, u := range users {
+			if membership := s.findProjectMembership(params["workspaceId"], u.ID); membership != nil {
+				found = append(found, membership)
+			} else {
+				missing = append(missing, u.Email)
+			}
+		}
+		if len(missing) > 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Users are not members of the project: "+strings.Join(missing, ", "))
+			return
+		}
+
+		memberships := []projectMembershipResponse{}
+		for _, membership := range found {
+			memberships = append(memberships, s.projectMembershipResponse(membership, false))
+		}
+		s.projectMemberships = slices.DeleteFunc(s.projectMemberships, func(membership *projectMembership) bool {
+			return slices.Contains(found, membership)
+		})
+		writeJSON(w, map[string]any{"memberships": memberships})
+	})
+
+	// list users' memberships of a project
+	s.handle("GET", "/api/v1/workspace/{workspaceId}/memberships", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.findWorkspace(params["workspaceId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+
+		memberships := []projectMembershipResponse{}
+		for _, membership := range s.projectMemberships {
+			if membership.WorkspaceID == params["workspaceId"] {
+				memberships = append(memberships, s.projectMembershipResponse(membership, true))
+			}
+		}
+		writeJSON(w, map[string]any{"memberships": memberships})
+	})
+
+	// get a user's membership of a project by username
+	s.handle("POST", "/api/v1/workspace/{workspaceId}/memberships/details", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Username string `json:"username"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		users, _ := s.findUsers(nil, []string{body.Username})
+		if len(users) == 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "User not found")
+			return
+		}
+		membership := s.findProjectMembership(params["workspaceId"], users[0].ID)
+		if membership == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Membership not found")
+			return
+		}
+		writeJSON(w, map[string]any{"membership": s.projectMembershipResponse(membership, true)})
+	})
+
+	// update roles of a user's membership
+	s.handle("PATCH", "/api/v1/workspace/{workspaceId}/memberships/{membershipId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Roles []membershipRoleBody `json:"roles"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := slices.IndexFunc(s.projectMemberships, func(membership *projectMembership) bool {
+			return membership.ID == params["membershipId"] && membership.WorkspaceID == params["workspaceId"]
+		})
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Membership not found")
+			return
+		}
+		roles, ok := s.newMembershipRoles(w, params["workspaceId"], body.Roles)
+		if !ok {
+			return
+		}
+
+		membership := s.projectMemberships[i]
+		membership.Roles = roles
+		membership.UpdatedAt = time.Now()
+		writeJSON(w, map[string]any{"roles": s.projectMembershipResponse(membership, true).Roles})
+	})
+}
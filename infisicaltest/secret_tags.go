@@ -0,0 +1,226 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+)
+
+// tag struct for secret tags
+type tag struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Slug        string
+	Color       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// tagResponse struct for secret tags in responses
+type tagResponse struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Slug      string  `json:"slug"`
+	Color     *string `json:"color,omitempty"`
+	ProjectID string  `json:"projectId"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+// response converts the tag into a response.
+func (t *tag) response() tagResponse {
+	res := tagResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Slug:      t.Slug,
+		ProjectID: t.WorkspaceID,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: t.UpdatedAt.Format(time.RFC3339),
+	}
+	if t.Color != "" {
+		color := t.Color
+		res.Color = &color
+	}
+	return res
+}
+
+// hasAnyTag returns whether the secret has any of given tag slugs.
+func (s *secret) hasAnyTag(slugs []string) bool {
+	for _, tag := range s.Tags {
+		if slices.Contains(slugs, tag.Slug) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSecretTag adds a secret tag to the workspace, and returns its id.
+func (s *Server) AddSecretTag(workspaceID, name, slug string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addTag(workspaceID, name, slug, "").ID
+}
+
+// addTag adds a new secret tag.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addTag(workspaceID, name, slug, color string) *tag {
+	now := time.Now()
+	created := &tag{
+		ID:          s.newID("tag"),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Slug:        slug,
+		Color:       color,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.tags = append(s.tags, created)
+	return created
+}
+
+// findTagBySlug finds a secret tag of the workspace with given slug.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findTagBySlug(workspaceID, slug string) *tag {
+	for _, tag := range s.tags {
+		if tag.WorkspaceID == workspaceID && tag.Slug == slug {
+			return tag
+		}
+	}
+	return nil
+}
+
+// secretTagsBody struct for request bodies of attaching/detaching secret tags
+type secretTagsBody struct {
+	ProjectSlug string   `json:"projectSlug"`
+	Environment string   `json:"environment"`
+	SecretPath  string   `json:"secretPath"`
+	Type        string   `json:"type"`
+	TagSlugs    []string `json:"tagSlugs"`
+}
+
+// registerSecretTagRoutes registers routes for secret tags.
+func (s *Server) registerSecretTagRoutes() {
+	// list tags
+	s.handle("GET", "/api/v1/workspace/{workspaceId}/tags", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.findWorkspace(params["workspaceId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+
+		tags := []tagResponse{}
+		for _, tag := range s.tags {
+			if tag.WorkspaceID == params["workspaceId"] {
+				tags = append(tags, tag.response())
+			}
+		}
+		writeJSON(w, map[string]any{"workspaceTags": tags})
+	})
+
+	// create a tag
+	s.handle("POST", "/api/v1/workspace/{workspaceId}/tags", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Name  string `json:"name"`
+			Slug  string `json:"slug"`
+			Color string `json:"color"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.findWorkspace(params["workspaceId"]) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		if body.Name == "" || body.Slug == "" {
+			writeError(w, http.StatusBadRequest, "BadRequest", "name and slug are required")
+			return
+		}
+		if s.findTagBySlug(params["workspaceId"], body.Slug) != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Tag with slug already exists")
+			return
+		}
+
+		created := s.addTag(params["workspaceId"], body.Name, body.Slug, body.Color)
+		writeJSON(w, map[string]any{"workspaceTag": created.response()})
+	})
+
+	// delete a tag (detaching it from all secrets)
+	s.handle("DELETE", "/api/v1/workspace/{workspaceId}/tags/{tagId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for i, deleted := range s.tags {
+			if deleted.WorkspaceID == params["workspaceId"] && deleted.ID == params["tagId"] {
+				s.tags = append(s.tags[:i], s.tags[i+1:]...)
+				for _, secret := range s.secrets {
+					secret.Tags = slices.DeleteFunc(secret.Tags, func(t *tag) bool { return t == deleted })
+				}
+				writeJSON(w, map[string]any{"workspaceTag": deleted.response()})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "NotFound", "Tag not found")
+	})
+
+	// attach or detach tags
+	handleSecretTags := func(attach bool) func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+		return func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+			var body secretTagsBody
+			if !readJSON(w, r, &body) {
+				return
+			}
+			typ, ok := secretTypeParam(w, body.Type)
+			if !ok {
+				return
+			}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			workspace := s.findWorkspaceBySlug(body.ProjectSlug)
+			if workspace == nil {
+				writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+				return
+			}
+			if !s.checkEnvironment(w, workspace.ID, body.Environment) {
+				return
+			}
+			found := s.findSecret(workspace.ID, body.Environment, body.SecretPath, params["secretKey"], typ, actor)
+			if found == nil {
+				writeError(w, http.StatusNotFound, "NotFound", "Secret not found")
+				return
+			}
+			tags := []*tag{}
+			for _, slug := range body.TagSlugs {
+				t := s.findTagBySlug(workspace.ID, slug)
+				if t == nil {
+					writeError(w, http.StatusNotFound, "NotFound", "Tag not found: "+slug)
+					return
+				}
+				tags = append(tags, t)
+			}
+
+			for _, t := range tags {
+				if attach && !slices.Contains(found.Tags, t) {
+					found.Tags = append(found.Tags, t)
+				} else if !attach {
+					found.Tags = slices.DeleteFunc(found.Tags, func(attached *tag) bool { return attached == t })
+				}
+			}
+			found.UpdatedAt = time.Now()
+			writeJSON(w, map[string]any{"secret": found.response()})
+		}
+	}
+	s.handle("POST", "/api/v3/secrets/tags/{secretKey}", handleSecretTags(true))
+	s.handle("DELETE", "/api/v3/secrets/tags/{secretKey}", handleSecretTags(false))
+}
@@ -0,0 +1,118 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secretVersion struct for versions of secrets
+type secretVersion struct {
+	ID        string
+	Secret    secret // copy of the secret at the time
+	Actor     string // actor's id ("" for changes made with server-side helpers)
+	CreatedAt time.Time
+}
+
+// secretVersionResponse struct for secret versions in responses
+type secretVersionResponse struct {
+	ID              string  `json:"id"`
+	SecretID        string  `json:"secretId"`
+	Version         int     `json:"version"`
+	Type            string  `json:"type"`
+	SecretKey       string  `json:"secretKey"`
+	SecretValue     string  `json:"secretValue"`
+	SecretComment   *string `json:"secretComment,omitempty"`
+	Environment     string  `json:"environment"`
+	Workspace       string  `json:"workspace"`
+	ActorType       string  `json:"actorType"`
+	UserActorID     *string `json:"userActorId"`
+	IdentityActorID *string `json:"identityActorId"`
+	CreatedAt       string  `json:"createdAt"`
+	UpdatedAt       string  `json:"updatedAt"`
+}
+
+// response converts the secret version into a response.
+func (v *secretVersion) response() secretVersionResponse {
+	secret := v.Secret.response()
+	res := secretVersionResponse{
+		ID:            v.ID,
+		SecretID:      secret.ID,
+		Version:       secret.Version,
+		Type:          secret.Type,
+		SecretKey:     secret.SecretKey,
+		SecretValue:   secret.SecretValue,
+		SecretComment: secret.SecretComment,
+		Environment:   secret.Environment,
+		Workspace:     secret.Workspace,
+		ActorType:     "platform",
+		CreatedAt:     v.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:     v.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if strings.HasPrefix(v.Actor, "api-key:") {
+		res.ActorType = "user"
+		res.UserActorID = &v.Actor
+	} else if v.Actor != "" {
+		res.ActorType = "identity"
+		res.IdentityActorID = &v.Actor
+	}
+	return res
+}
+
+// recordSecretVersion records the current state of given secret as a new version.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) recordSecretVersion(changed *secret, actor string) {
+	copied := *changed
+	copied.Tags = nil
+	s.secretVersions = append(s.secretVersions, &secretVersion{
+		ID:        s.newID("secret-version"),
+		Secret:    copied,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	})
+}
+
+// listSecretVersions lists versions of given secret, from the newest one.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) listSecretVersions(secretID string) []*secretVersion {
+	versions := []*secretVersion{}
+	for i := len(s.secretVersions) - 1; i >= 0; i-- {
+		if version := s.secretVersions[i]; version.Secret.ID == secretID {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// registerSecretVersionRoutes registers routes for secret versions.
+func (s *Server) registerSecretVersionRoutes() {
+	// list versions of a secret (from the newest one)
+	s.handle("GET", "/api/v1/secret/{secretId}/secret-versions", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 20
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		all := s.listSecretVersions(params["secretId"])
+		if len(all) == 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Secret not found")
+			return
+		}
+
+		versions := []secretVersionResponse{}
+		for i, version := range all {
+			if i >= offset && i < offset+limit {
+				versions = append(versions, version.response())
+			}
+		}
+		writeJSON(w, map[string]any{"secretVersions": versions})
+	})
+}
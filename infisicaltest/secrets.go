@@ -0,0 +1,764 @@
+package infisicaltest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// secret types
+const (
+	secretTypeShared   = "shared"
+	secretTypePersonal = "personal"
+)
+
+// secret struct for stored secrets
+type secret struct {
+	ID          string
+	WorkspaceID string
+	Environment string
+	Path        string
+	Key         string
+	Value       string
+	Comment     string
+	Type        string
+	Owner       string // actor's id for personal secrets
+	Tags        []*tag
+	Version     int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// secretResponse struct for secrets in responses
+type secretResponse struct {
+	ID_           string        `json:"_id"`
+	Environment   string        `json:"environment"`
+	ID            string        `json:"id"`
+	SecretComment *string       `json:"secretComment,omitempty"`
+	SecretKey     string        `json:"secretKey"`
+	SecretValue   string        `json:"secretValue"`
+	Type          string        `json:"type"`
+	Version       int           `json:"version"`
+	Workspace     string        `json:"workspace"`
+	Tags          []tagResponse `json:"tags"`
+}
+
+// response converts the secret into a response.
+func (s *secret) response() secretResponse {
+	res := secretResponse{
+		ID_:         s.ID,
+		Environment: s.Environment,
+		ID:          s.ID,
+		SecretKey:   s.Key,
+		SecretValue: s.Value,
+		Type:        s.Type,
+		Version:     s.Version,
+		Workspace:   s.WorkspaceID,
+		Tags:        []tagResponse{},
+	}
+	for _, tag := range s.Tags {
+		res.Tags = append(res.Tags, tag.response())
+	}
+	if s.Comment != "" {
+		comment := s.Comment
+		res.SecretComment = &comment
+	}
+	return res
+}
+
+// secretImport struct for secret imports
+type secretImport struct {
+	ID                string
+	WorkspaceID       string
+	Environment       string
+	Path              string
+	ImportEnvironment string
+	ImportPath        string
+	Position          int
+}
+
+// secretImportResponse struct for imported secrets in responses
+type secretImportResponse struct {
+	Environment string           `json:"environment"`
+	FolderID    *string          `json:"folderId,omitempty"`
+	SecretPath  string           `json:"secretPath"`
+	Secrets     []secretResponse `json:"secrets"`
+}
+
+// SetSecret creates or updates a shared secret, creating missing folders of `path`.
+func (s *Server) SetSecret(workspaceID, environment, path, key, value string) {
+	s.setSecret(workspaceID, environment, path, key, value, secretTypeShared, "")
+}
+
+// SetPersonalSecret creates or updates a personal secret of given identity, creating missing folders of `path`.
+func (s *Server) SetPersonalSecret(workspaceID, environment, path, key, value, identityID string) {
+	s.setSecret(workspaceID, environment, path, key, value, secretTypePersonal, identityID)
+}
+
+// Secret returns the value of a shared secret, and whether it exists.
+func (s *Server) Secret(workspaceID, environment, path, key string) (value string, exists bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if found := s.findSecret(workspaceID, environment, path, key, secretTypeShared, ""); found != nil {
+		return found.Value, true
+	}
+	return "", false
+}
+
+// AddSecretImport adds a secret import of `importEnvironment`:`importPath` into `environment`:`path`, and returns its id.
+func (s *Server) AddSecretImport(workspaceID, environment, path, importEnvironment, importPath string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addSecretImport(workspaceID, environment, path, importEnvironment, importPath).ID
+}
+
+// addSecretImport adds a secret import at the last position.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addSecretImport(workspaceID, environment, path, importEnvironment, importPath string) *secretImport {
+	position := 1
+	for _, imported := range s.imports {
+		if imported.WorkspaceID == workspaceID && imported.Environment == environment && imported.Path == normalizePath(path) {
+			position++
+		}
+	}
+
+	created := &secretImport{
+		ID:                s.newID("import"),
+		WorkspaceID:       workspaceID,
+		Environment:       environment,
+		Path:              normalizePath(path),
+		ImportEnvironment: importEnvironment,
+		ImportPath:        normalizePath(importPath),
+		Position:          position,
+	}
+	s.imports = append(s.imports, created)
+	return created
+}
+
+// setSecret creates or updates a secret.
+func (s *Server) setSecret(workspaceID, environment, path, key, value, typ, owner string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// create missing folders
+	parent := "/"
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		if s.findFolderByPath(workspaceID, environment, parent+"/"+name) == nil {
+			s.addFolder(workspaceID, environment, parent, name)
+		}
+		parent = normalizePath(parent + "/" + name)
+	}
+
+	if found := s.findSecret(workspaceID, environment, path, key, typ, owner); found != nil {
+		found.Value = value
+		found.Version++
+		found.UpdatedAt = time.Now()
+		s.recordSecretVersion(found, "")
+	} else {
+		s.recordSecretVersion(s.addSecret(workspaceID, environment, path, key, value, "", typ, owner), "")
+	}
+}
+
+// addSecret adds a new secret.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addSecret(workspaceID, environment, path, key, value, comment, typ, owner string) *secret {
+	now := time.Now()
+	created := &secret{
+		ID:          s.newID("secret"),
+		WorkspaceID: workspaceID,
+		Environment: environment,
+		Path:        normalizePath(path),
+		Key:         key,
+		Value:       value,
+		Comment:     comment,
+		Type:        typ,
+		Owner:       owner,
+		Version:     1,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.secrets = append(s.secrets, created)
+	return created
+}
+
+// findSecret finds a secret with given type (and owner for personal secrets).
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findSecret(workspaceID, environment, path, key, typ, owner string) *secret {
+	path = normalizePath(path)
+	for _, secret := range s.secrets {
+		if secret.WorkspaceID == workspaceID &&
+			secret.Environment == environment &&
+			secret.Path == path &&
+			secret.Key == key &&
+			secret.Type == typ &&
+			(typ == secretTypeShared || secret.Owner == owner) {
+			return secret
+		}
+	}
+	return nil
+}
+
+// listSecrets lists shared secrets and personal secrets of `actor` at given path.
+//
+// (only secrets with any of `tagSlugs` are listed, if given)
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) listSecrets(workspaceID, environment, path, actor string, tagSlugs ...string) []secretResponse {
+	path = normalizePath(path)
+	secrets := []secretResponse{}
+	for _, secret := range s.secrets {
+		if secret.WorkspaceID == workspaceID &&
+			secret.Environment == environment &&
+			secret.Path == path &&
+			(secret.Type == secretTypeShared || secret.Owner == actor) &&
+			(len(tagSlugs) == 0 || secret.hasAnyTag(tagSlugs)) {
+			secrets = append(secrets, secret.response())
+		}
+	}
+	return secrets
+}
+
+// listImportedSecrets lists secrets imported into given path.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) listImportedSecrets(workspaceID, environment, path string) []secretImportResponse {
+	imports := []secretImportResponse{}
+	for _, found := range s.listSecretImports(workspaceID, environment, path) {
+		res := secretImportResponse{
+			Environment: found.ImportEnvironment,
+			SecretPath:  found.ImportPath,
+			Secrets:     s.listSecrets(workspaceID, found.ImportEnvironment, found.ImportPath, ""),
+		}
+		if f := s.findFolderByPath(workspaceID, found.ImportEnvironment, found.ImportPath); f != nil {
+			res.FolderID = &f.ID
+		}
+		imports = append(imports, res)
+	}
+	return imports
+}
+
+// secretTypeParam validates and returns the secret type parameter, or writes an error response.
+func secretTypeParam(w http.ResponseWriter, typ string) (string, bool) {
+	switch typ {
+	case "":
+		return secretTypeShared, true
+	case secretTypeShared, secretTypePersonal:
+		return typ, true
+	}
+	writeError(w, http.StatusBadRequest, "BadRequest", "Invalid secret type: "+typ)
+	return "", false
+}
+
+// secretBody struct for request bodies of secrets
+type secretBody struct {
+	WorkspaceID   string  `json:"workspaceId"`
+	Environment   string  `json:"environment"`
+	SecretPath    string  `json:"secretPath"`
+	SecretValue   *string `json:"secretValue"`
+	SecretComment *string `json:"secretComment"`
+	Type          string  `json:"type"`
+}
+
+// MaxSecretsPerBatch is the maximum number of secrets accepted in one batch request.
+const MaxSecretsPerBatch = 100
+
+// batchSecretsBody struct for request bodies of batch secrets
+type batchSecretsBody struct {
+	WorkspaceID string `json:"workspaceId"`
+	Environment string `json:"environment"`
+	SecretPath  string `json:"secretPath"`
+	Secrets     []struct {
+		SecretKey     string  `json:"secretKey"`
+		SecretValue   *string `json:"secretValue"`
+		SecretComment *string `json:"secretComment"`
+		Type          string  `json:"type"`
+	} `json:"secrets"`
+}
+
+// readBatchSecretsBody reads and checks the request body of batch secrets, or writes an error response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) readBatchSecretsBody(w http.ResponseWriter, r *http.Request) (body batchSecretsBody, ok bool) {
+	if !readJSON(w, r, &body) {
+		return body, false
+	}
+	if len(body.Secrets) == 0 || len(body.Secrets) > MaxSecretsPerBatch {
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Number of secrets should be between 1 and %d", MaxSecretsPerBatch))
+		return body, false
+	}
+	if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+		return body, false
+	}
+	if normalizePath(body.SecretPath) != "/" && s.findFolderByPath(body.WorkspaceID, body.Environment, body.SecretPath) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "Folder not found")
+		return body, false
+	}
+	return body, true
+}
+
+// secretImportEntryResponse struct for secret imports (not imported secrets) in responses
+type secretImportEntryResponse struct {
+	ID         string      `json:"id"`
+	ImportPath string      `json:"importPath"`
+	ImportEnv  Environment `json:"importEnv"`
+	Position   int         `json:"position"`
+	FolderID   string      `json:"folderId,omitempty"`
+}
+
+// secretImportEntryResponse converts given secret import into a response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) secretImportEntryResponse(imported *secretImport) secretImportEntryResponse {
+	res := secretImportEntryResponse{
+		ID:         imported.ID,
+		ImportPath: imported.ImportPath,
+		ImportEnv:  Environment{Slug: imported.ImportEnvironment},
+		Position:   imported.Position,
+	}
+	if env := s.findEnvironment(imported.WorkspaceID, imported.ImportEnvironment); env != nil {
+		res.ImportEnv = *env
+	}
+	if f := s.findFolderByPath(imported.WorkspaceID, imported.Environment, imported.Path); f != nil {
+		res.FolderID = f.ID
+	}
+	return res
+}
+
+// listSecretImports lists secret imports of given path, in order of their positions.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) listSecretImports(workspaceID, environment, path string) []*secretImport {
+	path = normalizePath(path)
+
+	imports := []*secretImport{}
+	for _, imported := range s.imports {
+		if imported.WorkspaceID == workspaceID && imported.Environment == environment && imported.Path == path {
+			imports = append(imports, imported)
+		}
+	}
+	slices.SortFunc(imports, func(a, b *secretImport) int { return a.Position - b.Position })
+	return imports
+}
+
+// secretImportBody struct for request bodies of secret imports
+type secretImportBody struct {
+	WorkspaceID string `json:"workspaceId"`
+	Environment string `json:"environment"`
+	Path        string `json:"path"`
+	Import      struct {
+		Environment *string `json:"environment"`
+		Path        *string `json:"path"`
+		Position    *int    `json:"position"`
+	} `json:"import"`
+}
+
+// registerSecretRoutes registers routes for secrets.
+func (s *Server) registerSecretRoutes() {
+	// list secrets
+	s.handle("GET", "/api/v3/secrets/raw", func(w http.ResponseWriter, r *http.Request, _ map[string]string, actor string) {
+		query := r.URL.Query()
+		workspaceID, environment, path := query.Get("workspaceId"), query.Get("environment"), query.Get("secretPath")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, workspaceID, environment) {
+			return
+		}
+
+		var tagSlugs []string
+		if query.Get("tagSlugs") != "" {
+			tagSlugs = strings.Split(query.Get("tagSlugs"), ",")
+		}
+
+		res := map[string]any{"secrets": s.listSecrets(workspaceID, environment, path, actor, tagSlugs...)}
+		if query.Get("include_imports") == "true" {
+			res["imports"] = s.listImportedSecrets(workspaceID, environment, path)
+		}
+		writeJSON(w, res)
+	})
+
+	// retrieve a secret
+	s.handle("GET", "/api/v3/secrets/raw/{secretKey}", func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+		query := r.URL.Query()
+		workspaceID, environment, path := query.Get("workspaceId"), query.Get("environment"), query.Get("secretPath")
+		typ, ok := secretTypeParam(w, query.Get("type"))
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, workspaceID, environment) {
+			return
+		}
+
+		// personal secret first, then shared one
+		var found *secret
+		if typ == secretTypePersonal {
+			found = s.findSecret(workspaceID, environment, path, params["secretKey"], secretTypePersonal, actor)
+		}
+		if found == nil {
+			found = s.findSecret(workspaceID, environment, path, params["secretKey"], secretTypeShared, "")
+		}
+		// then imported one (later imports over earlier ones)
+		if found == nil && query.Get("include_imports") != "false" {
+			imports := s.listSecretImports(workspaceID, environment, path)
+			for i := len(imports) - 1; i >= 0 && found == nil; i-- {
+				found = s.findSecret(workspaceID, imports[i].ImportEnvironment, imports[i].ImportPath, params["secretKey"], secretTypeShared, "")
+			}
+		}
+		if found == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Secret not found")
+			return
+		}
+		writeJSON(w, map[string]any{"secret": found.response()})
+	})
+
+	// create a secret
+	s.handle("POST", "/api/v3/secrets/raw/{secretKey}", func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+		var body secretBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+		typ, ok := secretTypeParam(w, body.Type)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		if normalizePath(body.SecretPath) != "/" && s.findFolderByPath(body.WorkspaceID, body.Environment, body.SecretPath) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Folder not found")
+			return
+		}
+		if s.findSecret(body.WorkspaceID, body.Environment, body.SecretPath, params["secretKey"], typ, actor) != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Secret already exist")
+			return
+		}
+
+		var value, comment string
+		if body.SecretValue != nil {
+			value = *body.SecretValue
+		}
+		if body.SecretComment != nil {
+			comment = *body.SecretComment
+		}
+		created := s.addSecret(body.WorkspaceID, body.Environment, body.SecretPath, params["secretKey"], value, comment, typ, actor)
+		s.recordSecretVersion(created, actor)
+		s.takeSnapshot(body.WorkspaceID, body.Environment, body.SecretPath)
+		writeJSON(w, map[string]any{"secret": created.response()})
+	})
+
+	// update a secret
+	s.handle("PATCH", "/api/v3/secrets/raw/{secretKey}", func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+		var body secretBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+		typ, ok := secretTypeParam(w, body.Type)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		found := s.findSecret(body.WorkspaceID, body.Environment, body.SecretPath, params["secretKey"], typ, actor)
+		if found == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Secret not found")
+			return
+		}
+
+		if body.SecretValue != nil {
+			found.Value = *body.SecretValue
+		}
+		if body.SecretComment != nil {
+			found.Comment = *body.SecretComment
+		}
+		found.Version++
+		found.UpdatedAt = time.Now()
+		s.recordSecretVersion(found, actor)
+		s.takeSnapshot(body.WorkspaceID, body.Environment, body.SecretPath)
+		writeJSON(w, map[string]any{"secret": found.response()})
+	})
+
+	// delete a secret
+	s.handle("DELETE", "/api/v3/secrets/raw/{secretKey}", func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+		var body secretBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+		typ, ok := secretTypeParam(w, body.Type)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		found := s.findSecret(body.WorkspaceID, body.Environment, body.SecretPath, params["secretKey"], typ, actor)
+		if found == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Secret not found")
+			return
+		}
+
+		for i, secret := range s.secrets {
+			if secret == found {
+				s.secrets = append(s.secrets[:i], s.secrets[i+1:]...)
+				break
+			}
+		}
+		s.takeSnapshot(body.WorkspaceID, body.Environment, body.SecretPath)
+		writeJSON(w, map[string]any{"secret": found.response()})
+	})
+
+	// create secrets in batch (all or nothing)
+	s.handle("POST", "/api/v3/secrets/batch/raw", func(w http.ResponseWriter, r *http.Request, _ map[string]string, actor string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		body, ok := s.readBatchSecretsBody(w, r)
+		if !ok {
+			return
+		}
+		keys := map[string]bool{}
+		for _, item := range body.Secrets {
+			if keys[item.SecretKey] || s.findSecret(body.WorkspaceID, body.Environment, body.SecretPath, item.SecretKey, secretTypeShared, "") != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Secret already exist: "+item.SecretKey)
+				return
+			}
+			keys[item.SecretKey] = true
+		}
+
+		secrets := []secretResponse{}
+		for _, item := range body.Secrets {
+			var value, comment string
+			if item.SecretValue != nil {
+				value = *item.SecretValue
+			}
+			if item.SecretComment != nil {
+				comment = *item.SecretComment
+			}
+			created := s.addSecret(body.WorkspaceID, body.Environment, body.SecretPath, item.SecretKey, value, comment, secretTypeShared, "")
+			s.recordSecretVersion(created, actor)
+			secrets = append(secrets, created.response())
+		}
+		s.takeSnapshot(body.WorkspaceID, body.Environment, body.SecretPath)
+		writeJSON(w, map[string]any{"secrets": secrets})
+	})
+
+	// update secrets in batch (all or nothing)
+	s.handle("PATCH", "/api/v3/secrets/batch/raw", func(w http.ResponseWriter, r *http.Request, _ map[string]string, actor string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		body, ok := s.readBatchSecretsBody(w, r)
+		if !ok {
+			return
+		}
+		found := make([]*secret, len(body.Secrets))
+		for i, item := range body.Secrets {
+			if found[i] = s.findSecret(body.WorkspaceID, body.Environment, body.SecretPath, item.SecretKey, secretTypeShared, ""); found[i] == nil {
+				writeError(w, http.StatusNotFound, "NotFound", "Secret not found: "+item.SecretKey)
+				return
+			}
+		}
+
+		secrets := []secretResponse{}
+		for i, item := range body.Secrets {
+			if item.SecretValue != nil {
+				found[i].Value = *item.SecretValue
+			}
+			if item.SecretComment != nil {
+				found[i].Comment = *item.SecretComment
+			}
+			found[i].Version++
+			found[i].UpdatedAt = time.Now()
+			s.recordSecretVersion(found[i], actor)
+			secrets = append(secrets, found[i].response())
+		}
+		s.takeSnapshot(body.WorkspaceID, body.Environment, body.SecretPath)
+		writeJSON(w, map[string]any{"secrets": secrets})
+	})
+
+	// delete secrets in batch (all or nothing)
+	s.handle("DELETE", "/api/v3/secrets/batch/raw", func(w http.ResponseWriter, r *http.Request, _ map[string]string, actor string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		body, ok := s.readBatchSecretsBody(w, r)
+		if !ok {
+			return
+		}
+		found := make([]*secret, len(body.Secrets))
+		for i, item := range body.Secrets {
+			typ, ok := secretTypeParam(w, item.Type)
+			if !ok {
+				return
+			}
+			if found[i] = s.findSecret(body.WorkspaceID, body.Environment, body.SecretPath, item.SecretKey, typ, actor); found[i] == nil {
+				writeError(w, http.StatusNotFound, "NotFound", "Secret not found: "+item.SecretKey)
+				return
+			}
+		}
+
+		secrets := []secretResponse{}
+		for _, deleted := range found {
+			for i, secret := range s.secrets {
+				if secret == deleted {
+					s.secrets = append(s.secrets[:i], s.secrets[i+1:]...)
+					break
+				}
+			}
+			secrets = append(secrets, deleted.response())
+		}
+		s.takeSnapshot(body.WorkspaceID, body.Environment, body.SecretPath)
+		writeJSON(w, map[string]any{"secrets": secrets})
+	})
+
+	// list secret imports
+	s.handle("GET", "/api/v1/secret-imports", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		query := r.URL.Query()
+		workspaceID, environment, path := query.Get("workspaceId"), query.Get("environment"), query.Get("path")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, workspaceID, environment) {
+			return
+		}
+
+		imports := []secretImportEntryResponse{}
+		for _, imported := range s.listSecretImports(workspaceID, environment, path) {
+			imports = append(imports, s.secretImportEntryResponse(imported))
+		}
+		writeJSON(w, map[string]any{"secretImports": imports})
+	})
+
+	// create a secret import
+	s.handle("POST", "/api/v1/secret-imports", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body secretImportBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		if body.Import.Environment == nil || body.Import.Path == nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "import environment and path are required")
+			return
+		}
+		if s.findEnvironment(body.WorkspaceID, *body.Import.Environment) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Environment not found")
+			return
+		}
+		if *body.Import.Environment == body.Environment && normalizePath(*body.Import.Path) == normalizePath(body.Path) {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Cannot import from the same location")
+			return
+		}
+		for _, imported := range s.listSecretImports(body.WorkspaceID, body.Environment, body.Path) {
+			if imported.ImportEnvironment == *body.Import.Environment && imported.ImportPath == normalizePath(*body.Import.Path) {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Secret import already exists")
+				return
+			}
+		}
+
+		created := s.addSecretImport(body.WorkspaceID, body.Environment, body.Path, *body.Import.Environment, *body.Import.Path)
+		writeJSON(w, map[string]any{"message": "Successfully created secret import", "secretImport": s.secretImportEntryResponse(created)})
+	})
+
+	// update a secret import (moving it to another position, shifting others)
+	s.handle("PATCH", "/api/v1/secret-imports/{secretImportId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body secretImportBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		imports := s.listSecretImports(body.WorkspaceID, body.Environment, body.Path)
+		index := slices.IndexFunc(imports, func(imported *secretImport) bool { return imported.ID == params["secretImportId"] })
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Secret import not found")
+			return
+		}
+		if body.Import.Environment != nil && s.findEnvironment(body.WorkspaceID, *body.Import.Environment) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Environment not found")
+			return
+		}
+
+		updated := imports[index]
+		if body.Import.Environment != nil {
+			updated.ImportEnvironment = *body.Import.Environment
+		}
+		if body.Import.Path != nil {
+			updated.ImportPath = normalizePath(*body.Import.Path)
+		}
+		if body.Import.Position != nil {
+			position := min(max(*body.Import.Position, 1), len(imports))
+			imports = slices.Insert(slices.Delete(imports, index, index+1), position-1, updated)
+			for i, imported := range imports {
+				imported.Position = i + 1
+			}
+		}
+		writeJSON(w, map[string]any{"message": "Successfully updated secret import", "secretImport": s.secretImportEntryResponse(updated)})
+	})
+
+	// delete a secret import
+	s.handle("DELETE", "/api/v1/secret-imports/{secretImportId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body secretImportBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, body.WorkspaceID, body.Environment) {
+			return
+		}
+		imports := s.listSecretImports(body.WorkspaceID, body.Environment, body.Path)
+		index := slices.IndexFunc(imports, func(imported *secretImport) bool { return imported.ID == params["secretImportId"] })
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Secret import not found")
+			return
+		}
+
+		deleted := imports[index]
+		s.imports = slices.DeleteFunc(s.imports, func(imported *secretImport) bool { return imported == deleted })
+		for i, imported := range slices.Delete(imports, index, index+1) {
+			imported.Position = i + 1
+		}
+		writeJSON(w, map[string]any{"message": "Successfully deleted secret import", "secretImport": s.secretImportEntryResponse(deleted)})
+	})
+}
@@ -0,0 +1,385 @@
+// Package infisicaltest provides an in-memory stand-in of Infisical API server for tests.
+//
+// eg.
+//
+//	server := infisicaltest.NewServer()
+//	defer server.Close()
+//
+//	workspace := server.AddWorkspace("my-project", "dev", "prod")
+//	server.SetSecret(workspace.ID, "dev", "/", "KEY", "value")
+//
+//	client := infisical.NewClientWithoutAPIKey(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret)
+//	client.SetAPIBaseURL(server.URL)
+package infisicaltest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// default values of the server
+const (
+	DefaultOrganizationID = "test-organization-id"
+	DefaultIdentityID     = "test-identity-id"
+	DefaultClientID       = "test-client-id"
+	DefaultClientSecret   = "test-client-secret"
+	DefaultAPIKey         = "test-api-key"
+
+	DefaultAccessTokenTTL    = 7200
+	DefaultAccessTokenMaxTTL = 86400
+)
+
+// Server is an in-memory stand-in of Infisical API server
+type Server struct {
+	URL string // base URL of the server, eg. "http://127.0.0.1:12345"
+
+	server *httptest.Server
+	routes []route
+
+	mu       sync.Mutex
+	sequence int
+
+	accessTokenTTL    int64
+	accessTokenMaxTTL int64
+
+	organizations []*organization
+	identities    []*identity
+	apiKeys       map[string]bool
+	tokens        map[string]*accessToken
+
+	workspaces []*Workspace
+	folders    []*folder
+	secrets    []*secret
+	imports    []*secretImport
+	tags       []*tag
+	snapshots  []*snapshot
+
+	secretVersions []*secretVersion
+
+	users               []*user
+	projectMemberships  []*projectMembership
+	identityMemberships []*identityMembership
+	projectRoles        []*projectRole
+
+	faults   []*Fault
+	requests []Request
+}
+
+// Fault struct for injecting faults into the server
+type Fault struct {
+	Method string // HTTP method to match (empty for any method)
+	Path   string // prefix of URL paths to match (empty for any path)
+
+	StatusCode int         // status code of the response (0 for passing the request through after `Latency`)
+	Header     http.Header // headers of the response (eg. `Retry-After`)
+	Body       string      // body of the response
+
+	Latency time.Duration // delay before handling the request
+
+	Times int // number of requests to be affected (0 for unlimited)
+}
+
+// Request struct for recorded requests
+type Request struct {
+	Method string
+	Path   string
+}
+
+// NewServer creates and starts a new server with the default organization, identity, and API key.
+func NewServer() *Server {
+	s := &Server{
+		accessTokenTTL:    DefaultAccessTokenTTL,
+		accessTokenMaxTTL: DefaultAccessTokenMaxTTL,
+
+		apiKeys: map[string]bool{DefaultAPIKey: true},
+		tokens:  map[string]*accessToken{},
+	}
+	s.organizations = append(s.organizations, &organization{
+		ID:   DefaultOrganizationID,
+		Name: "Test Organization",
+		Slug: "test-organization",
+	})
+	s.identities = append(s.identities, &identity{
+		ID:             DefaultIdentityID,
+		Name:           "test-identity",
+		OrganizationID: DefaultOrganizationID,
+		Role:           "admin",
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	})
+	s.addClientSecret(s.attachUniversalAuth(s.identities[0], DefaultClientID), DefaultClientSecret, "default", 0, 0)
+	s.registerRoutes()
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// SetAccessTokenTTL changes the TTL and max TTL (in seconds) of access tokens issued from now on.
+func (s *Server) SetAccessTokenTTL(ttl, maxTTL int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessTokenTTL, s.accessTokenMaxTTL = ttl, maxTTL
+}
+
+// AddUniversalAuthIdentity adds a machine identity which logs in with given universal-auth client id and secret, and returns its id.
+func (s *Server) AddUniversalAuthIdentity(name, clientID, clientSecret string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := s.addIdentity(DefaultOrganizationID, name, "member")
+	s.addClientSecret(s.attachUniversalAuth(added, clientID), clientSecret, "default", 0, 0)
+	return added.ID
+}
+
+// InjectFault injects a fault into the server.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns all requests received by the server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request{}, s.requests...)
+}
+
+// CountRequests returns the number of received requests with given method and path.
+func (s *Server) CountRequests(method, path string) (count int) {
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+// route struct for routing requests
+type route struct {
+	method   string
+	segments []string // path segments; "{name}" for path parameters
+	apiKey   bool     // whether API key is required
+	public   bool     // whether authorization is not required
+	handler  func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string)
+}
+
+// handle registers a handler for requests which require authorization.
+func (s *Server) handle(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string)) {
+	s.routes = append(s.routes, route{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/"), handler: handler})
+}
+
+// handleWithAPIKey registers a handler for requests which require an API key.
+func (s *Server) handleWithAPIKey(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string)) {
+	s.routes = append(s.routes, route{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/"), apiKey: true, handler: handler})
+}
+
+// handlePublic registers a handler for requests which do not require authorization.
+func (s *Server) handlePublic(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string)) {
+	s.routes = append(s.routes, route{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/"), public: true, handler: handler})
+}
+
+// match checks if given method and path match this route, and returns path parameters.
+func (rt route) match(method, path string) (map[string]string, bool) {
+	if rt.method != method {
+		return nil, false
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[strings.Trim(segment, "{}")] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// serveHTTP handles all requests.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path})
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	// injected fault
+	if fault != nil {
+		if fault.Latency > 0 {
+			if !sleep(r.Context(), fault.Latency) {
+				return
+			}
+		}
+		if fault.StatusCode != 0 {
+			for k, vs := range fault.Header {
+				for _, v := range vs {
+					w.Header().Add(k, v)
+				}
+			}
+			w.WriteHeader(fault.StatusCode)
+			_, _ = w.Write([]byte(fault.Body))
+			return
+		}
+	}
+
+	for _, rt := range s.routes {
+		if params, ok := rt.match(r.Method, r.URL.Path); ok {
+			actor := ""
+			if !rt.public {
+				var authorized bool
+				if actor, authorized = s.authorize(r, rt.apiKey); !authorized {
+					writeError(w, http.StatusUnauthorized, "Unauthorized", "Failed to authenticate")
+					return
+				}
+			}
+
+			rt.handler(w, r, params, actor)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Route %s:%s not found", r.Method, r.URL.Path))
+}
+
+// matchFault returns a matching fault for given request, and consumes it.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if (fault.Method == "" || fault.Method == r.Method) && strings.HasPrefix(r.URL.Path, fault.Path) {
+			matched := *fault
+			if fault.Times > 0 {
+				fault.Times--
+				if fault.Times == 0 {
+					s.faults = append(s.faults[:i], s.faults[i+1:]...)
+				}
+			}
+			return &matched
+		}
+	}
+	return nil
+}
+
+// authorize checks the authorization of given request, and returns the actor's id.
+func (s *Server) authorize(r *http.Request, apiKeyOnly bool) (actor string, authorized bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !apiKeyOnly {
+		if token, exists := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]; exists && token.valid(time.Now()) && ipTrusted(token.TrustedIPs, r.RemoteAddr) {
+			token.NumUses++
+			return token.IdentityID, true
+		}
+	}
+
+	if apiKey := r.Header.Get("X-API-KEY"); apiKey != "" && s.apiKeys[apiKey] {
+		return "api-key:" + apiKey, true
+	}
+
+	return "", false
+}
+
+// newID generates a new id with given prefix.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) newID(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%s-%d", prefix, s.sequence)
+}
+
+// errorResponse struct for error responses
+type errorResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+	Error      string `json:"error"`
+	RequestID  string `json:"reqId"`
+}
+
+// writeJSON writes given value as a JSON response.
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{
+		StatusCode: status,
+		Message:    message,
+		Error:      code,
+		RequestID:  fmt.Sprintf("req-%d", time.Now().UnixNano()),
+	})
+}
+
+// readJSON reads JSON body of given request into `into`.
+func readJSON(w http.ResponseWriter, r *http.Request, into any) bool {
+	if err := json.NewDecoder(r.Body).Decode(into); err != nil {
+		writeError(w, http.StatusBadRequest, "BadRequest", fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// sleep waits for given duration, or returns false if the context is done.
+func sleep(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// normalizePath normalizes given secret/folder path, eg. "folder/" => "/folder".
+func normalizePath(path string) string {
+	path = "/" + strings.Trim(path, "/")
+	return path
+}
+
+// registerRoutes registers all routes of the server.
+func (s *Server) registerRoutes() {
+	s.registerAuthRoutes()
+	s.registerWorkspaceRoutes()
+	s.registerFolderRoutes()
+	s.registerSecretRoutes()
+	s.registerSecretTagRoutes()
+	s.registerSnapshotRoutes()
+	s.registerSecretVersionRoutes()
+	s.registerProjectUserRoutes()
+	s.registerProjectIdentityRoutes()
+	s.registerProjectRoleRoutes()
+	s.registerIdentityRoutes()
+	s.registerUniversalAuthRoutes()
+}
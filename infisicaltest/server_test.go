@@ -0,0 +1,133 @@
+package infisicaltest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	infisical "github.com/meinside/infisical-go"
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestServer(t *testing.T) {
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	workspace := server.AddWorkspace("test-workspace")
+	server.SetSecret(workspace.ID, "dev", "/", "SHARED_KEY", "shared-value")
+	server.SetSecret(workspace.ID, "dev", "/folder1/folder2", "NESTED_KEY", "nested-value")
+	server.SetSecret(workspace.ID, "prod", "/", "IMPORTED_KEY", "imported-value")
+	server.SetPersonalSecret(workspace.ID, "dev", "/", "SHARED_KEY", "personal-value", infisicaltest.DefaultIdentityID)
+	server.AddSecretImport(workspace.ID, "dev", "/", "prod", "/")
+
+	client, err := infisical.New(
+		infisical.WithUniversalAuth(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret),
+		infisical.WithBaseURL(server.URL),
+		infisical.WithRetryPolicy(&infisical.RetryPolicy{
+			MaxAttempts:          2,
+			BaseDelay:            time.Millisecond,
+			MaxDelay:             time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			RetryableMethods:     []string{"GET"},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+
+	// (workspaces)
+	if projects, err := client.RetrieveProjects(infisicaltest.DefaultOrganizationID); err != nil {
+		t.Errorf("failed to retrieve workspaces: %s", err)
+	} else if len(projects.Workspaces) != 1 || len(projects.Workspaces[0].Environments) != 3 {
+		t.Errorf("workspace with default environments was not returned: %+v", projects.Workspaces)
+	}
+
+	// (personal secret overrides shared one)
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypePersonal, "/SHARED_KEY"); err != nil {
+		t.Errorf("failed to retrieve a personal secret: %s", err)
+	} else if value != "personal-value" {
+		t.Errorf("personal secret should be returned: %s", value)
+	}
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypeShared, "/SHARED_KEY"); err != nil {
+		t.Errorf("failed to retrieve a shared secret: %s", err)
+	} else if value != "shared-value" {
+		t.Errorf("shared secret should be returned: %s", value)
+	}
+
+	// (secrets in folders)
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypeShared, "/folder1/folder2/NESTED_KEY"); err != nil {
+		t.Errorf("failed to retrieve a nested secret: %s", err)
+	} else if value != "nested-value" {
+		t.Errorf("nested secret value is wrong: %s", value)
+	}
+	if folders, err := client.ListFolders(workspace.ID, "dev", infisical.NewParamsListFolders().SetPath("/folder1")); err != nil {
+		t.Errorf("failed to list folders: %s", err)
+	} else if len(folders.Folders) != 1 || folders.Folders[0].Name != "folder2" {
+		t.Errorf("missing folders were not created: %+v", folders.Folders)
+	}
+	if err := client.CreateSecret(workspace.ID, "dev", "KEY", "value", infisical.NewParamsCreateSecret().SetSecretPath("/no-such-folder")); !errors.Is(err, infisical.ErrNotFound) {
+		t.Errorf("creating a secret in a non-existing folder should fail with `ErrNotFound`: %v", err)
+	}
+
+	// (imports)
+	if secrets, err := client.ListSecrets(infisical.NewParamsListSecrets().
+		SetWorkspaceID(workspace.ID).
+		SetEnvironment("dev").
+		SetIncludeImports(true)); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	} else if len(secrets.Imports) != 1 || len(secrets.Imports[0].Secrets) != 1 || secrets.Imports[0].Secrets[0].SecretValue != "imported-value" {
+		t.Errorf("imported secrets were not listed: %+v", secrets.Imports)
+	}
+
+	// (fault injection: retried status code)
+	server.InjectFault(infisicaltest.Fault{
+		Method:     "GET",
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+		Times:      1,
+	})
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypeShared, "/SHARED_KEY"); err != nil {
+		t.Errorf("request should have succeeded after a retry: %s", err)
+	}
+	if count := server.CountRequests("GET", "/api/v3/secrets/raw/SHARED_KEY"); count != 4 {
+		t.Errorf("unexpected number of requests: %d", count)
+	}
+
+	// (fault injection: status code with body)
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusTooManyRequests,
+		Body:       `{"statusCode":429,"message":"Too many requests","error":"RateLimitExceeded"}`,
+	})
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypeShared, "/SHARED_KEY"); !errors.Is(err, infisical.ErrRateLimited) {
+		t.Errorf("request should have failed with `ErrRateLimited`: %v", err)
+	}
+	server.ClearFaults()
+
+	// (fault injection: latency)
+	server.InjectFault(infisicaltest.Fault{
+		Path:    "/api/v3/secrets/raw",
+		Latency: time.Second,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.RetrieveSecretValueWithContext(ctx, workspace.ID, "dev", infisical.SecretTypeShared, "/SHARED_KEY"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("request should have timed out: %v", err)
+	}
+	server.ClearFaults()
+
+	// (expired access tokens)
+	server.ExpireAccessTokens()
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypeShared, "/SHARED_KEY"); !errors.Is(err, infisical.ErrUnauthorized) {
+		t.Errorf("request with an expired token should have failed with `ErrUnauthorized`: %v", err)
+	}
+
+	// (invalid credentials)
+	client = infisical.NewClientWithoutAPIKey(infisicaltest.DefaultClientID, "wrong-client-secret")
+	client.SetAPIBaseURL(server.URL)
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", infisical.SecretTypeShared, "/SHARED_KEY"); err == nil {
+		t.Errorf("login with invalid credentials should have failed")
+	}
+}
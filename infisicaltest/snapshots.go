@@ -0,0 +1,235 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// snapshot struct for secret snapshots of folders
+type snapshot struct {
+	ID          string
+	WorkspaceID string
+	Environment string
+	Path        string
+	Secrets     []secret // copies of secrets at the time
+	Folders     []string // names of subfolders at the time
+	CreatedAt   time.Time
+}
+
+// snapshotResponse struct for snapshots in list responses
+type snapshotResponse struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	EnvID     string `json:"envId"`
+	FolderID  string `json:"folderId,omitempty"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// snapshotDetailResponse struct for a snapshot with its secrets and folders
+type snapshotDetailResponse struct {
+	snapshotResponse
+
+	Environment    Environment      `json:"environment"`
+	SecretVersions []secretResponse `json:"secretVersions"`
+	FolderVersion  []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folderVersion"`
+}
+
+// TakeSnapshot takes a snapshot of secrets at given path, and returns its id.
+//
+// (snapshots are also taken automatically after each change of secrets)
+func (s *Server) TakeSnapshot(workspaceID, environment, path string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.takeSnapshot(workspaceID, environment, path).ID
+}
+
+// takeSnapshot takes a snapshot of secrets at given path.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) takeSnapshot(workspaceID, environment, path string) *snapshot {
+	path = normalizePath(path)
+	taken := &snapshot{
+		ID:          s.newID("snapshot"),
+		WorkspaceID: workspaceID,
+		Environment: environment,
+		Path:        path,
+		Secrets:     []secret{},
+		Folders:     []string{},
+		CreatedAt:   time.Now(),
+	}
+	for _, secret := range s.secrets {
+		if secret.WorkspaceID == workspaceID && secret.Environment == environment && secret.Path == path {
+			copied := *secret
+			copied.Tags = slices.Clone(secret.Tags)
+			taken.Secrets = append(taken.Secrets, copied)
+		}
+	}
+	for _, f := range s.folders {
+		if f.WorkspaceID == workspaceID && f.Environment == environment && f.ParentPath == path {
+			taken.Folders = append(taken.Folders, f.Name)
+		}
+	}
+	s.snapshots = append(s.snapshots, taken)
+	return taken
+}
+
+// findSnapshot finds a snapshot with given id.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findSnapshot(snapshotID string) *snapshot {
+	for _, snapshot := range s.snapshots {
+		if snapshot.ID == snapshotID {
+			return snapshot
+		}
+	}
+	return nil
+}
+
+// listSnapshots lists snapshots of given path, from the newest one.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) listSnapshots(workspaceID, environment, path string) []*snapshot {
+	path = normalizePath(path)
+	snapshots := []*snapshot{}
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		if snapshot := s.snapshots[i]; snapshot.WorkspaceID == workspaceID && snapshot.Environment == environment && snapshot.Path == path {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots
+}
+
+// snapshotResponse converts given snapshot into a response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) snapshotResponse(taken *snapshot) snapshotResponse {
+	res := snapshotResponse{
+		ID:        taken.ID,
+		ProjectID: taken.WorkspaceID,
+		CreatedAt: taken.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: taken.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if env := s.findEnvironment(taken.WorkspaceID, taken.Environment); env != nil {
+		res.EnvID = env.ID
+	}
+	if f := s.findFolderByPath(taken.WorkspaceID, taken.Environment, taken.Path); f != nil {
+		res.FolderID = f.ID
+	}
+	return res
+}
+
+// snapshotDetailResponse converts given snapshot into a response with its secrets and folders.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) snapshotDetailResponse(taken *snapshot) snapshotDetailResponse {
+	res := snapshotDetailResponse{
+		snapshotResponse: s.snapshotResponse(taken),
+		Environment:      Environment{Slug: taken.Environment},
+		SecretVersions:   []secretResponse{},
+	}
+	if env := s.findEnvironment(taken.WorkspaceID, taken.Environment); env != nil {
+		res.Environment = *env
+	}
+	for _, secret := range taken.Secrets {
+		res.SecretVersions = append(res.SecretVersions, secret.response())
+	}
+	for _, name := range taken.Folders {
+		version := struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		}{Name: name}
+		if f := s.findFolderByPath(taken.WorkspaceID, taken.Environment, taken.Path+"/"+name); f != nil {
+			version.ID = f.ID
+		}
+		res.FolderVersion = append(res.FolderVersion, version)
+	}
+	return res
+}
+
+// registerSnapshotRoutes registers routes for secret snapshots.
+func (s *Server) registerSnapshotRoutes() {
+	// list snapshots (from the newest one)
+	s.handle("GET", "/api/v1/workspace/{workspaceId}/secret-snapshots", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = 20
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, params["workspaceId"], query.Get("environment")) {
+			return
+		}
+
+		snapshots := []snapshotResponse{}
+		for i, snapshot := range s.listSnapshots(params["workspaceId"], query.Get("environment"), query.Get("path")) {
+			if i >= offset && i < offset+limit {
+				snapshots = append(snapshots, s.snapshotResponse(snapshot))
+			}
+		}
+		writeJSON(w, map[string]any{"secretSnapshots": snapshots})
+	})
+
+	// count snapshots
+	s.handle("GET", "/api/v1/workspace/{workspaceId}/secret-snapshots/count", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		query := r.URL.Query()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.checkEnvironment(w, params["workspaceId"], query.Get("environment")) {
+			return
+		}
+		writeJSON(w, map[string]any{"count": len(s.listSnapshots(params["workspaceId"], query.Get("environment"), query.Get("path")))})
+	})
+
+	// get a snapshot
+	s.handle("GET", "/api/v1/secret-snapshot/{secretSnapshotId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		snapshot := s.findSnapshot(params["secretSnapshotId"])
+		if snapshot == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Snapshot not found")
+			return
+		}
+		writeJSON(w, map[string]any{"secretSnapshot": s.snapshotDetailResponse(snapshot)})
+	})
+
+	// roll back secrets of a path to a snapshot (taking a new snapshot)
+	s.handle("POST", "/api/v1/secret-snapshot/{secretSnapshotId}/rollback", func(w http.ResponseWriter, r *http.Request, params map[string]string, actor string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		snapshot := s.findSnapshot(params["secretSnapshotId"])
+		if snapshot == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Snapshot not found")
+			return
+		}
+
+		s.secrets = slices.DeleteFunc(s.secrets, func(secret *secret) bool {
+			return secret.WorkspaceID == snapshot.WorkspaceID && secret.Environment == snapshot.Environment && secret.Path == snapshot.Path
+		})
+		for _, secret := range snapshot.Secrets {
+			restored := secret
+			restored.Tags = slices.Clone(secret.Tags)
+			restored.UpdatedAt = time.Now()
+			if versions := s.listSecretVersions(secret.ID); len(versions) > 0 {
+				restored.Version = versions[0].Secret.Version + 1
+			}
+			s.secrets = append(s.secrets, &restored)
+			s.recordSecretVersion(&restored, actor)
+		}
+		writeJSON(w, map[string]any{"secretSnapshot": s.snapshotDetailResponse(s.takeSnapshot(snapshot.WorkspaceID, snapshot.Environment, snapshot.Path))})
+	})
+}
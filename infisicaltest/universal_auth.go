@@ -0,0 +1,423 @@
+package infisicaltest
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// default trusted IPs of universal-auth (any IPv4 or IPv6 address)
+var defaultTrustedIPs = []string{"0.0.0.0/0", "::/0"}
+
+// universalAuth struct for universal-auth configurations of machine identities
+type universalAuth struct {
+	ID                      string
+	ClientID                string
+	AccessTokenTTL          int64    // 0 for the server's default
+	AccessTokenMaxTTL       int64    // 0 for the server's default
+	AccessTokenNumUsesLimit int64    // 0 for unlimited
+	ClientSecretTrustedIPs  []string // CIDRs
+	AccessTokenTrustedIPs   []string // CIDRs
+	ClientSecrets           []*clientSecret
+	CreatedAt               time.Time
+	UpdatedAt               time.Time
+}
+
+// clientSecret struct for universal-auth client secrets
+type clientSecret struct {
+	ID           string
+	Secret       string
+	Description  string
+	NumUses      int64
+	NumUsesLimit int64 // 0 for unlimited
+	TTL          int64 // in seconds, 0 for no expiry
+	Revoked      bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// usable checks if the client secret can be used for login at given time.
+func (c *clientSecret) usable(now time.Time) bool {
+	if c.Revoked {
+		return false
+	}
+	if c.NumUsesLimit > 0 && c.NumUses >= c.NumUsesLimit {
+		return false
+	}
+	if c.TTL > 0 && !now.Before(c.CreatedAt.Add(time.Duration(c.TTL)*time.Second)) {
+		return false
+	}
+	return true
+}
+
+// trustedIPResponse struct for trusted IPs in responses
+type trustedIPResponse struct {
+	IPAddress string `json:"ipAddress"`
+	Prefix    *int   `json:"prefix,omitempty"`
+	Type      string `json:"type"`
+}
+
+// universalAuthResponse struct for universal-auth configurations in responses
+type universalAuthResponse struct {
+	ID                      string              `json:"id"`
+	ClientID                string              `json:"clientId"`
+	IdentityID              string              `json:"identityId"`
+	AccessTokenTTL          int64               `json:"accessTokenTTL"`
+	AccessTokenMaxTTL       int64               `json:"accessTokenMaxTTL"`
+	AccessTokenNumUsesLimit int64               `json:"accessTokenNumUsesLimit"`
+	ClientSecretTrustedIPs  []trustedIPResponse `json:"clientSecretTrustedIps"`
+	AccessTokenTrustedIPs   []trustedIPResponse `json:"accessTokenTrustedIps"`
+	CreatedAt               string              `json:"createdAt"`
+	UpdatedAt               string              `json:"updatedAt"`
+}
+
+// clientSecretResponse struct for client secrets in responses
+type clientSecretResponse struct {
+	ID                       string `json:"id"`
+	Description              string `json:"description"`
+	ClientSecretPrefix       string `json:"clientSecretPrefix"`
+	ClientSecretNumUses      int64  `json:"clientSecretNumUses"`
+	ClientSecretNumUsesLimit int64  `json:"clientSecretNumUsesLimit"`
+	ClientSecretTTL          int64  `json:"clientSecretTTL"`
+	IsClientSecretRevoked    bool   `json:"isClientSecretRevoked"`
+	IdentityUAID             string `json:"identityUAId"`
+	CreatedAt                string `json:"createdAt"`
+	UpdatedAt                string `json:"updatedAt"`
+}
+
+// universalAuthBody struct for attaching/updating universal-auth
+type universalAuthBody struct {
+	ClientSecretTrustedIPs *[]struct {
+		IPAddress string `json:"ipAddress"`
+	} `json:"clientSecretTrustedIps"`
+	AccessTokenTrustedIPs *[]struct {
+		IPAddress string `json:"ipAddress"`
+	} `json:"accessTokenTrustedIps"`
+	AccessTokenTTL          *int64 `json:"accessTokenTTL"`
+	AccessTokenMaxTTL       *int64 `json:"accessTokenMaxTTL"`
+	AccessTokenNumUsesLimit *int64 `json:"accessTokenNumUsesLimit"`
+}
+
+// ipTrusted checks if the IP of given remote address is in one of given CIDRs (or any IP if `cidrs` is empty).
+func ipTrusted(cidrs []string, remoteAddr string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+			return true
+		}
+		if trusted := net.ParseIP(cidr); trusted != nil && trusted.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedIPs converts given trusted IPs of a request body into CIDRs.
+func parseTrustedIPs(ips []struct {
+	IPAddress string `json:"ipAddress"`
+}) ([]string, bool) {
+	cidrs := []string{}
+	for _, ip := range ips {
+		if _, _, err := net.ParseCIDR(ip.IPAddress); err != nil && net.ParseIP(ip.IPAddress) == nil {
+			return nil, false
+		}
+		cidrs = append(cidrs, ip.IPAddress)
+	}
+	return cidrs, true
+}
+
+// trustedIPsResponse converts given CIDRs into responses.
+func trustedIPsResponse(cidrs []string) []trustedIPResponse {
+	res := []trustedIPResponse{}
+	for _, cidr := range cidrs {
+		address, _, _ := strings.Cut(cidr, "/")
+		trusted := trustedIPResponse{IPAddress: address, Type: "ipv4"}
+		if strings.Contains(address, ":") {
+			trusted.Type = "ipv6"
+		}
+		if _, network, err := net.ParseCIDR(cidr); err == nil {
+			ones, _ := network.Mask.Size()
+			trusted.Prefix = &ones
+		}
+		res = append(res, trusted)
+	}
+	return res
+}
+
+// universalAuthTTLs returns the effective TTL and max TTL of access tokens issued with given configuration.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) universalAuthTTLs(ua *universalAuth) (ttl, maxTTL int64) {
+	ttl, maxTTL = ua.AccessTokenTTL, ua.AccessTokenMaxTTL
+	if ttl == 0 {
+		ttl = s.accessTokenTTL
+	}
+	if maxTTL == 0 {
+		maxTTL = s.accessTokenMaxTTL
+	}
+	return ttl, maxTTL
+}
+
+// universalAuthResponse converts given configuration of an identity into a response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) universalAuthResponse(i *identity) universalAuthResponse {
+	ua := i.UniversalAuth
+	ttl, maxTTL := s.universalAuthTTLs(ua)
+	return universalAuthResponse{
+		ID:                      ua.ID,
+		ClientID:                ua.ClientID,
+		IdentityID:              i.ID,
+		AccessTokenTTL:          ttl,
+		AccessTokenMaxTTL:       maxTTL,
+		AccessTokenNumUsesLimit: ua.AccessTokenNumUsesLimit,
+		ClientSecretTrustedIPs:  trustedIPsResponse(ua.ClientSecretTrustedIPs),
+		AccessTokenTrustedIPs:   trustedIPsResponse(ua.AccessTokenTrustedIPs),
+		CreatedAt:               ua.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:               ua.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// response converts the client secret into a response.
+func (c *clientSecret) response(ua *universalAuth) clientSecretResponse {
+	return clientSecretResponse{
+		ID:                       c.ID,
+		Description:              c.Description,
+		ClientSecretPrefix:       c.Secret[:min(len(c.Secret), 4)],
+		ClientSecretNumUses:      c.NumUses,
+		ClientSecretNumUsesLimit: c.NumUsesLimit,
+		ClientSecretTTL:          c.TTL,
+		IsClientSecretRevoked:    c.Revoked,
+		IdentityUAID:             ua.ID,
+		CreatedAt:                c.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:                c.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// attachUniversalAuth attaches universal-auth with given client id (and the default settings) to an identity.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) attachUniversalAuth(i *identity, clientID string) *universalAuth {
+	now := time.Now()
+	i.UniversalAuth = &universalAuth{
+		ID:                     s.newID("universal-auth"),
+		ClientID:               clientID,
+		ClientSecretTrustedIPs: defaultTrustedIPs,
+		AccessTokenTrustedIPs:  defaultTrustedIPs,
+		CreatedAt:              now,
+		UpdatedAt:              now,
+	}
+	return i.UniversalAuth
+}
+
+// addClientSecret adds a client secret to given universal-auth configuration.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addClientSecret(ua *universalAuth, secret, description string, numUsesLimit, ttl int64) *clientSecret {
+	now := time.Now()
+	added := &clientSecret{
+		ID:           s.newID("client-secret"),
+		Secret:       secret,
+		Description:  description,
+		NumUsesLimit: numUsesLimit,
+		TTL:          ttl,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	ua.ClientSecrets = append(ua.ClientSecrets, added)
+	return added
+}
+
+// applyUniversalAuthBody applies given request body to the configuration, or writes an error response.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) applyUniversalAuthBody(w http.ResponseWriter, ua *universalAuth, body universalAuthBody) bool {
+	updated := *ua
+	if body.ClientSecretTrustedIPs != nil {
+		cidrs, ok := parseTrustedIPs(*body.ClientSecretTrustedIPs)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Invalid IP address in clientSecretTrustedIps")
+			return false
+		}
+		updated.ClientSecretTrustedIPs = cidrs
+	}
+	if body.AccessTokenTrustedIPs != nil {
+		cidrs, ok := parseTrustedIPs(*body.AccessTokenTrustedIPs)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Invalid IP address in accessTokenTrustedIps")
+			return false
+		}
+		updated.AccessTokenTrustedIPs = cidrs
+	}
+	if body.AccessTokenTTL != nil {
+		updated.AccessTokenTTL = *body.AccessTokenTTL
+	}
+	if body.AccessTokenMaxTTL != nil {
+		updated.AccessTokenMaxTTL = *body.AccessTokenMaxTTL
+	}
+	if body.AccessTokenNumUsesLimit != nil {
+		updated.AccessTokenNumUsesLimit = *body.AccessTokenNumUsesLimit
+	}
+
+	if updated.AccessTokenTTL < 0 || updated.AccessTokenMaxTTL < 0 || updated.AccessTokenNumUsesLimit < 0 {
+		writeError(w, http.StatusBadRequest, "BadRequest", "TTLs and number of uses should not be negative")
+		return false
+	}
+	if ttl, maxTTL := s.universalAuthTTLs(&updated); ttl > maxTTL {
+		writeError(w, http.StatusBadRequest, "BadRequest", "Access token TTL cannot be greater than max TTL")
+		return false
+	}
+
+	updated.UpdatedAt = time.Now()
+	*ua = updated
+	return true
+}
+
+// registerUniversalAuthRoutes registers routes for managing universal-auth of machine identities.
+func (s *Server) registerUniversalAuthRoutes() {
+	// attach universal-auth to an identity
+	s.handle("POST", "/api/v1/auth/universal-auth/identities/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body universalAuthBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Identity not found")
+			return
+		}
+		if i.UniversalAuth != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Failed to add universal auth to already configured identity")
+			return
+		}
+
+		if !s.applyUniversalAuthBody(w, s.attachUniversalAuth(i, "test-client-id-"+randomHex(8)), body) {
+			i.UniversalAuth = nil
+			return
+		}
+
+		writeJSON(w, map[string]any{"identityUniversalAuth": s.universalAuthResponse(i)})
+	})
+
+	// get universal-auth of an identity
+	s.handle("GET", "/api/v1/auth/universal-auth/identities/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil || i.UniversalAuth == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Universal auth not found")
+			return
+		}
+		writeJSON(w, map[string]any{"identityUniversalAuth": s.universalAuthResponse(i)})
+	})
+
+	// update universal-auth of an identity
+	s.handle("PATCH", "/api/v1/auth/universal-auth/identities/{identityId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body universalAuthBody
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil || i.UniversalAuth == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Universal auth not found")
+			return
+		}
+		if !s.applyUniversalAuthBody(w, i.UniversalAuth, body) {
+			return
+		}
+		writeJSON(w, map[string]any{"identityUniversalAuth": s.universalAuthResponse(i)})
+	})
+
+	// create a client secret
+	s.handle("POST", "/api/v1/auth/universal-auth/identities/{identityId}/client-secrets", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Description  string `json:"description"`
+			NumUsesLimit int64  `json:"numUsesLimit"`
+			TTL          int64  `json:"ttl"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil || i.UniversalAuth == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Universal auth not found")
+			return
+		}
+		if body.NumUsesLimit < 0 || body.TTL < 0 {
+			writeError(w, http.StatusBadRequest, "BadRequest", "TTL and number of uses should not be negative")
+			return
+		}
+
+		secret := randomHex(32)
+		created := s.addClientSecret(i.UniversalAuth, secret, body.Description, body.NumUsesLimit, body.TTL)
+		writeJSON(w, map[string]any{
+			"clientSecret":     secret,
+			"clientSecretData": created.response(i.UniversalAuth),
+		})
+	})
+
+	// list client secrets (which are not revoked)
+	s.handle("GET", "/api/v1/auth/universal-auth/identities/{identityId}/client-secrets", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil || i.UniversalAuth == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Universal auth not found")
+			return
+		}
+
+		secrets := []clientSecretResponse{}
+		for _, secret := range i.UniversalAuth.ClientSecrets {
+			if !secret.Revoked {
+				secrets = append(secrets, secret.response(i.UniversalAuth))
+			}
+		}
+		writeJSON(w, map[string]any{"clientSecretData": secrets})
+	})
+
+	// revoke a client secret
+	s.handle("POST", "/api/v1/auth/universal-auth/identities/{identityId}/client-secrets/{clientSecretId}/revoke", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		i := s.findIdentity(params["identityId"])
+		if i == nil || i.UniversalAuth == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Universal auth not found")
+			return
+		}
+		for _, secret := range i.UniversalAuth.ClientSecrets {
+			if secret.ID == params["clientSecretId"] && !secret.Revoked {
+				secret.Revoked = true
+				secret.UpdatedAt = time.Now()
+				writeJSON(w, map[string]any{"clientSecretData": secret.response(i.UniversalAuth)})
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "NotFound", "Client secret not found")
+	})
+}
@@ -0,0 +1,401 @@
+package infisicaltest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// default environments of newly-added workspaces
+var defaultEnvironments = []Environment{
+	{Name: "Development", Slug: "dev"},
+	{Name: "Staging", Slug: "staging"},
+	{Name: "Production", Slug: "prod"},
+}
+
+// Workspace struct for projects
+type Workspace struct {
+	ID                 string        `json:"id"`
+	Name               string        `json:"name"`
+	Slug               string        `json:"slug"`
+	Description        string        `json:"description"`
+	AutoCapitalization bool          `json:"autoCapitalization"`
+	Organization       string        `json:"organization"`
+	Environments       []Environment `json:"environments"`
+}
+
+// Environment struct for environments of workspaces
+type Environment struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Position int    `json:"position"`
+}
+
+// AddWorkspace adds a workspace with given environment slugs to the default organization, and returns it.
+//
+// (default environments: "dev", "staging", and "prod" when no slug is given)
+func (s *Server) AddWorkspace(name string, environments ...string) Workspace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	envs := []Environment{}
+	for _, slug := range environments {
+		envs = append(envs, Environment{Name: slug, Slug: slug})
+	}
+	return s.addWorkspace(name, strings.ToLower(strings.ReplaceAll(name, " ", "-")), envs).copy()
+}
+
+// addWorkspace adds a workspace with given environments (or default ones if empty) to the default organization.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) addWorkspace(name, slug string, environments []Environment) *Workspace {
+	envs := []Environment{}
+	if len(environments) == 0 {
+		envs = append(envs, defaultEnvironments...)
+	} else {
+		envs = append(envs, environments...)
+	}
+	for i := range envs {
+		envs[i].ID = s.newID("env")
+		envs[i].Position = i + 1
+	}
+
+	workspace := &Workspace{
+		ID:                 s.newID("workspace"),
+		Name:               name,
+		Slug:               slug,
+		AutoCapitalization: true,
+		Organization:       DefaultOrganizationID,
+		Environments:       envs,
+	}
+	s.workspaces = append(s.workspaces, workspace)
+	return workspace
+}
+
+// copy returns a deep copy of the workspace.
+func (w *Workspace) copy() Workspace {
+	copied := *w
+	copied.Environments = append([]Environment{}, w.Environments...)
+	return copied
+}
+
+// findWorkspace finds a workspace with given id.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findWorkspace(workspaceID string) *Workspace {
+	for _, workspace := range s.workspaces {
+		if workspace.ID == workspaceID {
+			return workspace
+		}
+	}
+	return nil
+}
+
+// findWorkspaceBySlug finds a workspace with given slug.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findWorkspaceBySlug(slug string) *Workspace {
+	for _, workspace := range s.workspaces {
+		if workspace.Slug == slug {
+			return workspace
+		}
+	}
+	return nil
+}
+
+// findEnvironment finds an environment with given workspace id and slug.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) findEnvironment(workspaceID, slug string) *Environment {
+	if workspace := s.findWorkspace(workspaceID); workspace != nil {
+		for i := range workspace.Environments {
+			if workspace.Environments[i].Slug == slug {
+				return &workspace.Environments[i]
+			}
+		}
+	}
+	return nil
+}
+
+// checkEnvironment checks if given workspace and environment exist, and writes an error response if not.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) checkEnvironment(w http.ResponseWriter, workspaceID, environment string) bool {
+	if workspaceID == "" || environment == "" {
+		writeError(w, http.StatusBadRequest, "BadRequest", "workspaceId and environment are required")
+		return false
+	}
+	if s.findWorkspace(workspaceID) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+		return false
+	}
+	if s.findEnvironment(workspaceID, environment) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "Environment not found")
+		return false
+	}
+	return true
+}
+
+// registerWorkspaceRoutes registers routes for organizations and workspaces.
+func (s *Server) registerWorkspaceRoutes() {
+	// my organizations
+	s.handleWithAPIKey("GET", "/api/v2/users/me/organizations", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		writeJSON(w, map[string]any{"organizations": s.organizations})
+	})
+
+	// workspaces of an organization
+	s.handle("GET", "/api/v2/organizations/{organizationId}/workspaces", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspaces := []Workspace{}
+		for _, workspace := range s.workspaces {
+			if workspace.Organization == params["organizationId"] {
+				workspaces = append(workspaces, workspace.copy())
+			}
+		}
+		writeJSON(w, map[string]any{"workspaces": workspaces})
+	})
+
+	// create a project
+	s.handle("POST", "/api/v2/workspace", func(w http.ResponseWriter, r *http.Request, _ map[string]string, _ string) {
+		var body struct {
+			ProjectName        string `json:"projectName"`
+			ProjectDescription string `json:"projectDescription"`
+			Slug               string `json:"slug"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if body.ProjectName == "" {
+			writeError(w, http.StatusBadRequest, "BadRequest", "projectName is required")
+			return
+		}
+		if body.Slug == "" {
+			body.Slug = strings.ToLower(strings.ReplaceAll(body.ProjectName, " ", "-"))
+		}
+		if s.findWorkspaceBySlug(body.Slug) != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Project with slug '"+body.Slug+"' already exists")
+			return
+		}
+
+		created := s.addWorkspace(body.ProjectName, body.Slug, nil)
+		created.Description = body.ProjectDescription
+		writeJSON(w, map[string]any{"project": created.copy()})
+	})
+
+	// get a project
+	s.handle("GET", "/api/v1/workspace/{workspaceId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspace(params["workspaceId"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		writeJSON(w, map[string]any{"workspace": workspace.copy()})
+	})
+
+	// update a project
+	s.handle("PATCH", "/api/v1/workspace/{workspaceId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Name               *string `json:"name"`
+			Description        *string `json:"description"`
+			AutoCapitalization *bool   `json:"autoCapitalization"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspace(params["workspaceId"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		if body.Name != nil {
+			workspace.Name = *body.Name
+		}
+		if body.Description != nil {
+			workspace.Description = *body.Description
+		}
+		if body.AutoCapitalization != nil {
+			workspace.AutoCapitalization = *body.AutoCapitalization
+		}
+		writeJSON(w, map[string]any{"workspace": workspace.copy()})
+	})
+
+	// delete a project (with all its environments, folders, secrets, imports, and tags)
+	s.handle("DELETE", "/api/v1/workspace/{workspaceId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspace(params["workspaceId"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+
+		for _, env := range workspace.Environments {
+			s.deletePath(workspace.ID, env.Slug, "/")
+		}
+		s.imports = slices.DeleteFunc(s.imports, func(imported *secretImport) bool { return imported.WorkspaceID == workspace.ID })
+		s.tags = slices.DeleteFunc(s.tags, func(t *tag) bool { return t.WorkspaceID == workspace.ID })
+		s.projectMemberships = slices.DeleteFunc(s.projectMemberships, func(membership *projectMembership) bool { return membership.WorkspaceID == workspace.ID })
+		s.identityMemberships = slices.DeleteFunc(s.identityMemberships, func(membership *identityMembership) bool { return membership.WorkspaceID == workspace.ID })
+		s.projectRoles = slices.DeleteFunc(s.projectRoles, func(role *projectRole) bool { return role.WorkspaceID == workspace.ID })
+		s.workspaces = slices.DeleteFunc(s.workspaces, func(ws *Workspace) bool { return ws == workspace })
+		writeJSON(w, map[string]any{"workspace": workspace.copy()})
+	})
+
+	// create an environment
+	s.handle("POST", "/api/v1/workspace/{workspaceId}/environments", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Name string `json:"name"`
+			Slug string `json:"slug"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspace(params["workspaceId"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		if body.Name == "" || body.Slug == "" {
+			writeError(w, http.StatusBadRequest, "BadRequest", "name and slug are required")
+			return
+		}
+		if s.findEnvironment(workspace.ID, body.Slug) != nil {
+			writeError(w, http.StatusBadRequest, "BadRequest", "Environment with slug '"+body.Slug+"' already exists")
+			return
+		}
+
+		created := Environment{
+			ID:       s.newID("env"),
+			Name:     body.Name,
+			Slug:     body.Slug,
+			Position: len(workspace.Environments) + 1,
+		}
+		workspace.Environments = append(workspace.Environments, created)
+		writeJSON(w, map[string]any{"message": "Successfully created environment", "workspace": workspace.copy(), "environment": created})
+	})
+
+	// update an environment (moving it to another position, shifting others)
+	s.handle("PATCH", "/api/v1/workspace/{workspaceId}/environments/{environmentId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		var body struct {
+			Name     *string `json:"name"`
+			Slug     *string `json:"slug"`
+			Position *int    `json:"position"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspace(params["workspaceId"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		index := slices.IndexFunc(workspace.Environments, func(env Environment) bool { return env.ID == params["environmentId"] })
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Environment not found")
+			return
+		}
+		updated := workspace.Environments[index]
+		if body.Slug != nil && *body.Slug != updated.Slug {
+			if s.findEnvironment(workspace.ID, *body.Slug) != nil {
+				writeError(w, http.StatusBadRequest, "BadRequest", "Environment with slug '"+*body.Slug+"' already exists")
+				return
+			}
+			s.renameEnvironment(workspace.ID, updated.Slug, *body.Slug)
+			updated.Slug = *body.Slug
+		}
+		if body.Name != nil {
+			updated.Name = *body.Name
+		}
+
+		envs := slices.Delete(workspace.Environments, index, index+1)
+		position := index + 1
+		if body.Position != nil {
+			position = min(max(*body.Position, 1), len(envs)+1)
+		}
+		workspace.Environments = slices.Insert(envs, position-1, updated)
+		for i := range workspace.Environments {
+			workspace.Environments[i].Position = i + 1
+		}
+		writeJSON(w, map[string]any{"message": "Successfully updated environment", "workspace": workspace.copy(), "environment": workspace.Environments[position-1]})
+	})
+
+	// delete an environment (with all its folders, secrets, and imports)
+	s.handle("DELETE", "/api/v1/workspace/{workspaceId}/environments/{environmentId}", func(w http.ResponseWriter, r *http.Request, params map[string]string, _ string) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		workspace := s.findWorkspace(params["workspaceId"])
+		if workspace == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "Project not found")
+			return
+		}
+		index := slices.IndexFunc(workspace.Environments, func(env Environment) bool { return env.ID == params["environmentId"] })
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "NotFound", "Environment not found")
+			return
+		}
+
+		deleted := workspace.Environments[index]
+		s.deletePath(workspace.ID, deleted.Slug, "/")
+		s.imports = slices.DeleteFunc(s.imports, func(imported *secretImport) bool {
+			return imported.WorkspaceID == workspace.ID && (imported.Environment == deleted.Slug || imported.ImportEnvironment == deleted.Slug)
+		})
+		workspace.Environments = slices.Delete(workspace.Environments, index, index+1)
+		for i := range workspace.Environments {
+			workspace.Environments[i].Position = i + 1
+		}
+		writeJSON(w, map[string]any{"message": "Successfully deleted environment", "workspace": workspace.copy(), "environment": deleted})
+	})
+}
+
+// renameEnvironment changes the environment slug of all folders, secrets, and imports.
+//
+// NOTE: `mu` should be held by the caller.
+func (s *Server) renameEnvironment(workspaceID, from, to string) {
+	for _, f := range s.folders {
+		if f.WorkspaceID == workspaceID && f.Environment == from {
+			f.Environment = to
+		}
+	}
+	for _, secret := range s.secrets {
+		if secret.WorkspaceID == workspaceID && secret.Environment == from {
+			secret.Environment = to
+		}
+	}
+	for _, imported := range s.imports {
+		if imported.WorkspaceID == workspaceID {
+			if imported.Environment == from {
+				imported.Environment = to
+			}
+			if imported.ImportEnvironment == from {
+				imported.ImportEnvironment = to
+			}
+		}
+	}
+}
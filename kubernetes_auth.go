@@ -0,0 +1,40 @@
+package infisical
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultKubernetesServiceAccountTokenPath is the default path of the service account token in pods
+const DefaultKubernetesServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// KubernetesAuth is an authenticator with kubernetes service account tokens
+type KubernetesAuth struct {
+	identityID string
+
+	// path of the service account token file (read on every login, as projected tokens rotate)
+	ServiceAccountTokenPath string
+}
+
+// NewKubernetesAuth returns a new kubernetes-auth authenticator for given identity id.
+func NewKubernetesAuth(identityID string) *KubernetesAuth {
+	return &KubernetesAuth{
+		identityID: identityID,
+
+		ServiceAccountTokenPath: DefaultKubernetesServiceAccountTokenPath,
+	}
+}
+
+// Login logs in with the kubernetes service account token.
+//
+// https://infisical.com/docs/api-reference/endpoints/kubernetes-auth/login
+func (a *KubernetesAuth) Login(ctx context.Context, c *Client) (result AccessToken, err error) {
+	var bytes []byte
+	if bytes, err = os.ReadFile(a.ServiceAccountTokenPath); err != nil {
+		return AccessToken{}, fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	return c.loginWithJWT(ctx, "/v1/auth/kubernetes-auth/login", a.identityID, strings.TrimSpace(string(bytes)))
+}
@@ -0,0 +1,235 @@
+package infisical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// placeholder for redacted values
+const redacted = "[REDACTED]"
+
+// headers which are redacted in logs
+var sensitiveHeaders = []string{
+	"Authorization",
+	"X-API-KEY",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// JSON keys whose values are redacted in logs
+var sensitiveKeys = map[string]bool{
+	"accessToken":       true,
+	"apiKey":            true,
+	"clientSecret":      true,
+	"iamRequestBody":    true,
+	"iamRequestHeaders": true,
+	"jwt":               true,
+	"secretValue":       true,
+	"token":             true,
+}
+
+// WithLogger sets the structured logger for logging requests, responses, retries, and token events.
+//
+// In debug level, redacted dumps of http requests & responses are also logged.
+//
+// Tokens, client secrets, and secret values are never logged unless `WithUnsafeDebugDump` is given.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *clientConfig) error {
+		cfg.logger = logger
+		return nil
+	}
+}
+
+// WithUnsafeDebugDump makes the client log full, unredacted dumps of http requests & responses.
+//
+// NOTE: dumps will include tokens, client secrets, and secret values; never use it in production.
+func WithUnsafeDebugDump() Option {
+	return func(cfg *clientConfig) error {
+		cfg.unsafeDebugDump = true
+		return nil
+	}
+}
+
+// SetLogger changes the structured logger of the client.
+//
+// (`nil` for not logging at all)
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+// log returns the logger of the client, or nil if nothing should be logged.
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil && c.Verbose {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+// logEvent logs an event with given level and attributes.
+func (c *Client) logEvent(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if logger := c.log(); logger != nil {
+		logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
+
+// dumpLevel returns the log level of http dumps.
+func (c *Client) dumpLevel() slog.Level {
+	if c.Verbose {
+		return slog.LevelInfo
+	}
+	return slog.LevelDebug
+}
+
+// dump http request
+//
+// (redacted unless `WithUnsafeDebugDump` is given)
+func (c *Client) dumpRequest(req *http.Request) {
+	logger, level, ctx := c.log(), c.dumpLevel(), req.Context()
+	if logger == nil || !logger.Enabled(ctx, level) {
+		return
+	}
+
+	if c.unsafeDebugDump {
+		if bytes, err := httputil.DumpRequest(req, true); err == nil {
+			logger.Log(ctx, level, "dumping HTTP request (unsafe)", slog.String("dump", string(bytes)))
+		} else {
+			logger.Log(ctx, level, "failed to dump HTTP request", slog.Any("error", err))
+		}
+	} else {
+		// dump with redacted headers and body
+		clone := req.Clone(ctx)
+		clone.Body = nil
+		redactHeaders(clone.Header)
+		clone.URL.RawQuery = redactQuery(clone.URL.RawQuery)
+
+		var body []byte
+		if req.GetBody != nil {
+			if copied, err := req.GetBody(); err == nil {
+				body, _ = io.ReadAll(copied)
+				copied.Close()
+			}
+		}
+
+		if bytes, err := httputil.DumpRequest(clone, false); err == nil {
+			logger.Log(ctx, level, "dumping HTTP request", slog.String("dump", string(bytes)+string(redactJSON(body))))
+		} else {
+			logger.Log(ctx, level, "failed to dump HTTP request", slog.Any("error", err))
+		}
+	}
+}
+
+// dump http response
+//
+// (redacted unless `WithUnsafeDebugDump` is given)
+func (c *Client) dumpResponse(res *http.Response) {
+	ctx := context.Background()
+	if res.Request != nil {
+		ctx = res.Request.Context()
+	}
+
+	logger, level := c.log(), c.dumpLevel()
+	if logger == nil || !logger.Enabled(ctx, level) {
+		return
+	}
+
+	if c.unsafeDebugDump {
+		if bytes, err := httputil.DumpResponse(res, true); err == nil {
+			logger.Log(ctx, level, "dumping HTTP response (unsafe)", slog.String("dump", string(bytes)))
+		} else {
+			logger.Log(ctx, level, "failed to dump HTTP response", slog.Any("error", err))
+		}
+	} else {
+		// read (and restore) the body
+		var body []byte
+		if res.Body != nil {
+			body, _ = io.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		clone := *res
+		clone.Header = res.Header.Clone()
+		clone.Body = nil
+		redactHeaders(clone.Header)
+
+		if bytes, err := httputil.DumpResponse(&clone, false); err == nil {
+			logger.Log(ctx, level, "dumping HTTP response", slog.String("dump", string(bytes)+string(redactJSON(body))))
+		} else {
+			logger.Log(ctx, level, "failed to dump HTTP response", slog.Any("error", err))
+		}
+	}
+}
+
+// redactHeaders replaces values of sensitive headers in place.
+func redactHeaders(header http.Header) {
+	for _, name := range sensitiveHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+}
+
+// redactQuery returns given query string with values of sensitive keys replaced.
+//
+// (query strings which cannot be parsed are replaced entirely)
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return redacted
+	}
+
+	for key := range query {
+		if sensitiveKeys[key] {
+			query[key] = []string{redacted}
+		}
+	}
+	return query.Encode()
+}
+
+// redactJSON returns given JSON with values of sensitive keys replaced.
+//
+// (bodies which are not JSON are replaced entirely)
+func redactJSON(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return []byte(redacted)
+	}
+
+	if redactedJSON, err := json.Marshal(redactValue(parsed)); err == nil {
+		return redactedJSON
+	}
+	return []byte(redacted)
+}
+
+// redactValue replaces values of sensitive keys in given (unmarshalled) JSON value recursively.
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if sensitiveKeys[key] {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(child)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	}
+	return value
+}
@@ -0,0 +1,168 @@
+package infisical
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// thread-safe buffer for logs
+type logBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestLogging(t *testing.T) {
+	////////////////////////////////
+	// run a stand-in server which responds with secret values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(AccessToken{
+			AccessToken: "test-access-token",
+			ExpiresIn:   600,
+			TokenType:   "Bearer",
+		})
+	})
+	mux.HandleFunc("/api/v3/secrets/raw/KEY", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SecretData{
+			Secret: Secret{
+				SecretKey:   "KEY",
+				SecretValue: "test-secret-value",
+			},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	sensitives := []string{"test-client-secret", "test-access-token", "test-secret-value", "test-api-key"}
+
+	newClient := func(buf *logBuffer, opts ...Option) *Client {
+		client, err := New(append([]Option{
+			WithAPIKey("test-api-key"),
+			WithUniversalAuth("test-client-id", "test-client-secret"),
+			WithBaseURL(server.URL),
+			WithLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		}, opts...)...)
+		if err != nil {
+			t.Fatalf("failed to create client: %s", err)
+		}
+		return client
+	}
+
+	// (events and redacted dumps, without sensitive values)
+	buf := &logBuffer{}
+	client := newClient(buf)
+	if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/KEY"); err != nil {
+		t.Fatalf("failed to retrieve a secret: %s", err)
+	}
+	if req, err := client.newRequestWithQueryParams(context.Background(), "GET", "/v3/secrets/raw/KEY", AuthMethodNormal, map[string]any{
+		"token": "test-query-token",
+	}); err != nil {
+		t.Fatalf("failed to create a request: %s", err)
+	} else if res, err := client.do(req); err != nil {
+		t.Fatalf("failed to send a request: %s", err)
+	} else {
+		res.Body.Close()
+	}
+	logs := buf.String()
+	for _, event := range []string{"sending request", "received response", "access token issued", "latency=", "dumping HTTP response", redacted} {
+		if !strings.Contains(logs, event) {
+			t.Errorf("logs should contain '%s': %s", event, logs)
+		}
+	}
+	for _, sensitive := range append(sensitives, "test-query-token") {
+		if strings.Contains(logs, sensitive) {
+			t.Errorf("logs should not contain '%s': %s", sensitive, logs)
+		}
+	}
+
+	// (no dumps in info level)
+	buf = &logBuffer{}
+	client = newClient(buf, WithLogger(slog.New(slog.NewTextHandler(buf, nil))))
+	if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/KEY"); err != nil {
+		t.Fatalf("failed to retrieve a secret: %s", err)
+	}
+	logs = buf.String()
+	if strings.Contains(logs, "dumping HTTP") || !strings.Contains(logs, "access token issued") {
+		t.Errorf("logs in info level should contain only info events: %s", logs)
+	}
+
+	// (redacted dumps in info level, with deprecated `Verbose`)
+	buf = &logBuffer{}
+	client = newClient(buf, WithLogger(slog.New(slog.NewTextHandler(buf, nil))))
+	client.Verbose = true
+	if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/KEY"); err != nil {
+		t.Fatalf("failed to retrieve a secret: %s", err)
+	}
+	logs = buf.String()
+	if !strings.Contains(logs, "dumping HTTP response") || !strings.Contains(logs, redacted) {
+		t.Errorf("logs should contain redacted dumps: %s", logs)
+	}
+	for _, sensitive := range sensitives {
+		if strings.Contains(logs, sensitive) {
+			t.Errorf("redacted dumps should not contain '%s': %s", sensitive, logs)
+		}
+	}
+
+	// (unsafe dumps)
+	buf = &logBuffer{}
+	client = newClient(buf, WithUnsafeDebugDump())
+	if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/KEY"); err != nil {
+		t.Fatalf("failed to retrieve a secret: %s", err)
+	}
+	logs = buf.String()
+	for _, sensitive := range sensitives {
+		if !strings.Contains(logs, sensitive) {
+			t.Errorf("unsafe dumps should contain '%s': %s", sensitive, logs)
+		}
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	redactedBody := string(redactJSON([]byte(`{"secrets":[{"secretKey":"KEY","secretValue":"value"}],"accessToken":"token","expiresIn":600}`)))
+	if strings.Contains(redactedBody, `"value"`) || strings.Contains(redactedBody, `"token"`) {
+		t.Errorf("sensitive values were not redacted: %s", redactedBody)
+	}
+	if !strings.Contains(redactedBody, `"KEY"`) || !strings.Contains(redactedBody, `600`) {
+		t.Errorf("non-sensitive values should be kept: %s", redactedBody)
+	}
+
+	if string(redactJSON([]byte("not a json"))) != redacted {
+		t.Errorf("non-JSON body should be redacted entirely")
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	redactedQuery := redactQuery("environment=dev&accessToken=token&secretPath=%2Ffolder")
+	if strings.Contains(redactedQuery, "=token") {
+		t.Errorf("sensitive values were not redacted: %s", redactedQuery)
+	}
+	if !strings.Contains(redactedQuery, "environment=dev") || !strings.Contains(redactedQuery, "secretPath=%2Ffolder") {
+		t.Errorf("non-sensitive values should be kept: %s", redactedQuery)
+	}
+
+	if redactQuery("") != "" {
+		t.Errorf("empty query should be kept")
+	}
+	if redactQuery("invalid=%zz") != redacted {
+		t.Errorf("unparsable query should be redacted entirely")
+	}
+}
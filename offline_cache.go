@@ -0,0 +1,483 @@
+package infisical
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// salt and info for deriving encryption keys of the offline cache
+const (
+	offlineCacheKeySalt = "infisical-go/offline-cache"
+	offlineCacheKeyInfo = "snapshot encryption v1"
+)
+
+// OfflineCachePolicy struct for persisting snapshots of secrets on disk
+type OfflineCachePolicy struct {
+	// directory for snapshot files (created with permission 0700 if missing)
+	Dir string
+
+	// secret material for deriving the encryption key
+	//
+	// (derived from universal-auth client id and secret if empty; give one if the client secret will be rotated)
+	Key []byte
+
+	// maximum age of snapshots which can be served (no bound if <= 0)
+	MaxStaleness time.Duration
+}
+
+// WithOfflineCache enables the encrypted on-disk cache of secrets with given policy.
+//
+// Successful `ListSecrets` and `RetrieveSecret` results are written to snapshot files,
+// and served when Infisical is unreachable (network errors, 5xx, or 429 responses), even from a freshly-started process.
+func WithOfflineCache(policy *OfflineCachePolicy) Option {
+	return func(cfg *clientConfig) error {
+		if policy != nil && policy.Dir == "" {
+			return fmt.Errorf("directory of the offline cache is missing")
+		}
+		cfg.offlineCachePolicy = policy
+		return nil
+	}
+}
+
+// offlineSecret struct for retrieved secrets in snapshots
+type offlineSecret struct {
+	Secret      Secret    `json:"secret"`
+	RetrievedAt time.Time `json:"retrievedAt"`
+}
+
+// offlineSnapshot struct for snapshot files of a workspace, environment, and secret path
+type offlineSnapshot struct {
+	WorkspaceID string `json:"workspaceId"`
+	Environment string `json:"environment"`
+	SecretPath  string `json:"secretPath"`
+
+	// full list of secrets (valid only if `TakenAt` is not zero)
+	Secrets []Secret  `json:"secrets,omitempty"`
+	TakenAt time.Time `json:"takenAt"`
+
+	// imported secrets (valid only if `ImportsTakenAt` is not zero)
+	Imports        []SecretImport `json:"imports,omitempty"`
+	ImportsTakenAt time.Time      `json:"importsTakenAt"`
+
+	// individually retrieved secrets, keyed with type and key
+	Retrieved map[string]offlineSecret `json:"retrieved,omitempty"`
+}
+
+// offlineCache is an encrypted on-disk cache of secrets
+type offlineCache struct {
+	dir          string
+	aead         cipher.AEAD
+	maxStaleness time.Duration
+
+	lock sync.Mutex
+}
+
+// newOfflineCache creates a new offline cache with given policy and authenticator.
+//
+// (returns nil if `policy` is nil)
+func newOfflineCache(policy *OfflineCachePolicy, authenticator Authenticator) (*offlineCache, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	material := policy.Key
+	if len(material) == 0 {
+		if ua, ok := authenticator.(*UniversalAuth); ok {
+			clientID, clientSecret := ua.credential()
+			material = []byte(clientID + "\x00" + clientSecret)
+		} else {
+			return nil, fmt.Errorf("offline cache requires a key when universal-auth is not used")
+		}
+	}
+
+	block, err := aes.NewCipher(deriveOfflineCacheKey(material))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher of offline cache: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher of offline cache: %w", err)
+	}
+
+	return &offlineCache{
+		dir:          policy.Dir,
+		aead:         aead,
+		maxStaleness: policy.MaxStaleness,
+	}, nil
+}
+
+// deriveOfflineCacheKey derives a 256-bit key from given secret material with HKDF-SHA256.
+func deriveOfflineCacheKey(material []byte) []byte {
+	prk := hmacSHA256([]byte(offlineCacheKeySalt), material)
+	return hmacSHA256(prk, append([]byte(offlineCacheKeyInfo), 1))
+}
+
+// snapshotID returns the identifier of a snapshot, used as its file name and additional data of encryption.
+func snapshotID(workspaceID, environment, secretPath string) string {
+	hash := sha256.Sum256([]byte(workspaceID + "\x00" + environment + "\x00" + normalizeSecretPath(secretPath)))
+	return hex.EncodeToString(hash[:])
+}
+
+// fresh checks if a snapshot taken at given time can be served.
+func (oc *offlineCache) fresh(takenAt time.Time) bool {
+	return !takenAt.IsZero() && (oc.maxStaleness <= 0 || time.Since(takenAt) < oc.maxStaleness)
+}
+
+// read reads and decrypts a snapshot. (empty one if missing or unreadable)
+//
+// NOTE: `lock` should be held by the caller.
+func (oc *offlineCache) read(workspaceID, environment, secretPath string) (snapshot offlineSnapshot, err error) {
+	snapshot = offlineSnapshot{
+		WorkspaceID: workspaceID,
+		Environment: environment,
+		SecretPath:  normalizeSecretPath(secretPath),
+	}
+
+	var parsed offlineSnapshot
+	if parsed, err = oc.readFile(snapshotID(workspaceID, environment, secretPath)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return snapshot, nil
+		}
+		return snapshot, err
+	}
+	return parsed, nil
+}
+
+// readFile reads and decrypts the snapshot file with given identifier.
+//
+// NOTE: `lock` should be held by the caller.
+func (oc *offlineCache) readFile(id string) (snapshot offlineSnapshot, err error) {
+	var encrypted []byte
+	if encrypted, err = os.ReadFile(filepath.Join(oc.dir, id+".snapshot")); err != nil {
+		return snapshot, err
+	}
+
+	nonceSize := oc.aead.NonceSize()
+	if len(encrypted) < nonceSize {
+		return snapshot, fmt.Errorf("snapshot is too short")
+	}
+	var decrypted []byte
+	if decrypted, err = oc.aead.Open(nil, encrypted[:nonceSize], encrypted[nonceSize:], []byte(id)); err != nil {
+		return snapshot, fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+
+	if err = json.Unmarshal(decrypted, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// write encrypts and writes a snapshot atomically, with file permission 0600.
+//
+// NOTE: `lock` should be held by the caller.
+func (oc *offlineCache) write(snapshot offlineSnapshot) (err error) {
+	if err = os.MkdirAll(oc.dir, 0o700); err != nil {
+		return err
+	}
+
+	var encoded []byte
+	if encoded, err = json.Marshal(snapshot); err != nil {
+		return err
+	}
+
+	id := snapshotID(snapshot.WorkspaceID, snapshot.Environment, snapshot.SecretPath)
+	nonce := make([]byte, oc.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+	encrypted := oc.aead.Seal(nonce, nonce, encoded, []byte(id))
+
+	// (`os.CreateTemp` creates files with permission 0600)
+	var tmp *os.File
+	if tmp, err = os.CreateTemp(oc.dir, id+".*.tmp"); err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(encrypted); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(oc.dir, id+".snapshot"))
+}
+
+// update reads, modifies, and writes a snapshot.
+func (oc *offlineCache) update(workspaceID, environment, secretPath string, modify func(snapshot *offlineSnapshot)) error {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	// (unreadable snapshots, eg. encrypted with other keys, are overwritten)
+	snapshot, _ := oc.read(workspaceID, environment, secretPath)
+	modify(&snapshot)
+
+	return oc.write(snapshot)
+}
+
+// storeList writes listed secrets into a snapshot.
+func (oc *offlineCache) storeList(workspaceID, environment, secretPath string, includeImports bool, data SecretsData) error {
+	return oc.update(workspaceID, environment, secretPath, func(snapshot *offlineSnapshot) {
+		now := time.Now()
+		snapshot.Secrets, snapshot.TakenAt = data.Secrets, now
+		if includeImports {
+			snapshot.Imports, snapshot.ImportsTakenAt = data.Imports, now
+		}
+	})
+}
+
+// storeSecret writes a retrieved secret into a snapshot.
+func (oc *offlineCache) storeSecret(workspaceID, environment, secretPath string, secretType SecretType, secretKey string, secret Secret) error {
+	return oc.update(workspaceID, environment, secretPath, func(snapshot *offlineSnapshot) {
+		if snapshot.Retrieved == nil {
+			snapshot.Retrieved = map[string]offlineSecret{}
+		}
+		snapshot.Retrieved[string(secretType)+"/"+secretKey] = offlineSecret{
+			Secret:      secret,
+			RetrievedAt: time.Now(),
+		}
+	})
+}
+
+// invalidate modifies and writes existing snapshots of given workspace. (all workspaces if empty)
+//
+// `modify` returns whether the snapshot was changed.
+// (missing or unreadable snapshots are left as they are)
+func (oc *offlineCache) invalidate(workspaceID string, modify func(snapshot *offlineSnapshot) bool) error {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(oc.dir, "*.snapshot"))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, file := range files {
+		snapshot, err := oc.readFile(strings.TrimSuffix(filepath.Base(file), ".snapshot"))
+		if err != nil || (workspaceID != "" && snapshot.WorkspaceID != workspaceID) {
+			continue
+		}
+		if modify(&snapshot) {
+			errs = append(errs, oc.write(snapshot))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// invalidateSecrets drops given secrets of a path from its snapshot, and listed secrets from all snapshots of the workspace.
+//
+// (listed secrets of the whole workspace are dropped, as they may contain them through secret imports)
+func (oc *offlineCache) invalidateSecrets(workspaceID, environment, secretPath string, secretKeys ...string) error {
+	secretPath = normalizeSecretPath(secretPath)
+	return oc.invalidate(workspaceID, func(snapshot *offlineSnapshot) (changed bool) {
+		if snapshot.Environment == environment && snapshot.SecretPath == secretPath {
+			for _, secretKey := range secretKeys {
+				for _, secretType := range []SecretType{SecretTypeShared, SecretTypePersonal} {
+					if _, exists := snapshot.Retrieved[string(secretType)+"/"+secretKey]; exists {
+						delete(snapshot.Retrieved, string(secretType)+"/"+secretKey)
+						changed = true
+					}
+				}
+			}
+		}
+		if !snapshot.TakenAt.IsZero() || !snapshot.ImportsTakenAt.IsZero() {
+			snapshot.TakenAt, snapshot.ImportsTakenAt = time.Time{}, time.Time{}
+			snapshot.Secrets, snapshot.Imports = nil, nil
+			changed = true
+		}
+		return changed
+	})
+}
+
+// invalidateImports drops listed secrets with imports, and retrieved secrets of a path.
+func (oc *offlineCache) invalidateImports(workspaceID, environment, secretPath string) error {
+	secretPath = normalizeSecretPath(secretPath)
+	return oc.invalidate(workspaceID, func(snapshot *offlineSnapshot) bool {
+		if snapshot.Environment != environment || snapshot.SecretPath != secretPath ||
+			(snapshot.ImportsTakenAt.IsZero() && len(snapshot.Retrieved) == 0) {
+			return false
+		}
+		snapshot.ImportsTakenAt = time.Time{}
+		snapshot.Imports = nil
+		snapshot.Retrieved = nil
+		return true
+	})
+}
+
+// invalidateWorkspace drops all secrets from snapshots of given workspace. (all workspaces if empty)
+func (oc *offlineCache) invalidateWorkspace(workspaceID string) error {
+	return oc.invalidate(workspaceID, func(snapshot *offlineSnapshot) bool {
+		if snapshot.TakenAt.IsZero() && snapshot.ImportsTakenAt.IsZero() && len(snapshot.Retrieved) == 0 {
+			return false
+		}
+		*snapshot = offlineSnapshot{
+			WorkspaceID: snapshot.WorkspaceID,
+			Environment: snapshot.Environment,
+			SecretPath:  snapshot.SecretPath,
+		}
+		return true
+	})
+}
+
+// loadList returns listed secrets from a snapshot which is fresh enough.
+func (oc *offlineCache) loadList(workspaceID, environment, secretPath string, includeImports bool) (data SecretsData, takenAt time.Time, found bool) {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	snapshot, err := oc.read(workspaceID, environment, secretPath)
+	if err != nil {
+		return SecretsData{}, time.Time{}, false
+	}
+
+	takenAt = snapshot.TakenAt
+	if includeImports {
+		takenAt = snapshot.ImportsTakenAt
+	}
+	if !oc.fresh(takenAt) {
+		return SecretsData{}, time.Time{}, false
+	}
+
+	data = SecretsData{Secrets: snapshot.Secrets}
+	if includeImports {
+		data.Imports = snapshot.Imports
+	}
+	return data, takenAt, true
+}
+
+// loadSecret returns a secret from a snapshot which is fresh enough.
+//
+// (individually retrieved one first, then listed one)
+func (oc *offlineCache) loadSecret(workspaceID, environment, secretPath string, secretType SecretType, secretKey string) (secret Secret, takenAt time.Time, found bool) {
+	oc.lock.Lock()
+	defer oc.lock.Unlock()
+
+	snapshot, err := oc.read(workspaceID, environment, secretPath)
+	if err != nil {
+		return Secret{}, time.Time{}, false
+	}
+
+	if retrieved, exists := snapshot.Retrieved[string(secretType)+"/"+secretKey]; exists && oc.fresh(retrieved.RetrievedAt) {
+		return retrieved.Secret, retrieved.RetrievedAt, true
+	}
+
+	if oc.fresh(snapshot.TakenAt) {
+		// personal secret first (if requested), then shared one
+		types := []SecretType{SecretTypeShared}
+		if secretType == SecretTypePersonal {
+			types = []SecretType{SecretTypePersonal, SecretTypeShared}
+		}
+		for _, typ := range types {
+			for _, listed := range snapshot.Secrets {
+				if listed.SecretKey == secretKey && listed.Type == typ {
+					return listed, snapshot.TakenAt, true
+				}
+			}
+		}
+	}
+
+	return Secret{}, time.Time{}, false
+}
+
+// storeOfflineList writes listed secrets into the offline cache (if enabled, and not filtered by tags).
+func (c *Client) storeOfflineList(ctx context.Context, key secretCacheKey, data SecretsData) {
+	if c.offlineCache == nil || key.tagSlugs != "" {
+		return
+	}
+	if err := c.offlineCache.storeList(key.workspaceID, key.environment, key.secretPath, key.includeImports, data); err != nil {
+		c.logEvent(ctx, slog.LevelWarn, "failed to write offline cache", slog.Any("error", err))
+	}
+}
+
+// storeOfflineSecret writes a retrieved secret into the offline cache (if enabled).
+func (c *Client) storeOfflineSecret(ctx context.Context, key secretCacheKey, secret Secret) {
+	if c.offlineCache == nil {
+		return
+	}
+	if err := c.offlineCache.storeSecret(key.workspaceID, key.environment, key.secretPath, key.secretType, key.secretKey, secret); err != nil {
+		c.logEvent(ctx, slog.LevelWarn, "failed to write offline cache", slog.Any("error", err))
+	}
+}
+
+// invalidateOfflineSecrets drops given secrets of a path from the offline cache (if enabled).
+func (c *Client) invalidateOfflineSecrets(ctx context.Context, workspaceID, environment, secretPath string, secretKeys ...string) {
+	if c.offlineCache == nil || len(secretKeys) == 0 {
+		return
+	}
+	if err := c.offlineCache.invalidateSecrets(workspaceID, environment, secretPath, secretKeys...); err != nil {
+		c.logEvent(ctx, slog.LevelWarn, "failed to write offline cache", slog.Any("error", err))
+	}
+}
+
+// invalidateOfflineImports drops listed secrets with imports, and retrieved secrets of a path from the offline cache (if enabled).
+func (c *Client) invalidateOfflineImports(ctx context.Context, workspaceID, environment, secretPath string) {
+	if c.offlineCache == nil {
+		return
+	}
+	if err := c.offlineCache.invalidateImports(workspaceID, environment, secretPath); err != nil {
+		c.logEvent(ctx, slog.LevelWarn, "failed to write offline cache", slog.Any("error", err))
+	}
+}
+
+// invalidateOfflineWorkspace drops all secrets of given workspace from the offline cache (if enabled). (all workspaces if empty)
+func (c *Client) invalidateOfflineWorkspace(ctx context.Context, workspaceID string) {
+	if c.offlineCache == nil {
+		return
+	}
+	if err := c.offlineCache.invalidateWorkspace(workspaceID); err != nil {
+		c.logEvent(ctx, slog.LevelWarn, "failed to write offline cache", slog.Any("error", err))
+	}
+}
+
+// loadOfflineList returns listed secrets (not filtered by tags) from the offline cache when fetching them failed with given error.
+func (c *Client) loadOfflineList(ctx context.Context, key secretCacheKey, err error) (data SecretsData, found bool) {
+	if c.offlineCache == nil || key.tagSlugs != "" || !isServerUnavailable(err) {
+		return SecretsData{}, false
+	}
+
+	var takenAt time.Time
+	if data, takenAt, found = c.offlineCache.loadList(key.workspaceID, key.environment, key.secretPath, key.includeImports); found {
+		c.logOffline(ctx, key, takenAt, err)
+	}
+	return data, found
+}
+
+// loadOfflineSecret returns a secret from the offline cache when retrieving it failed with given error.
+func (c *Client) loadOfflineSecret(ctx context.Context, key secretCacheKey, err error) (secret Secret, found bool) {
+	if c.offlineCache == nil || !isServerUnavailable(err) {
+		return Secret{}, false
+	}
+
+	var takenAt time.Time
+	if secret, takenAt, found = c.offlineCache.loadSecret(key.workspaceID, key.environment, key.secretPath, key.secretType, key.secretKey); found {
+		c.logOffline(ctx, key, takenAt, err)
+	}
+	return secret, found
+}
+
+// logOffline logs that secrets are served from the offline cache.
+func (c *Client) logOffline(ctx context.Context, key secretCacheKey, takenAt time.Time, err error) {
+	c.logEvent(ctx, slog.LevelWarn, "serving secrets from offline cache",
+		slog.String("workspace_id", key.workspaceID),
+		slog.String("environment", key.environment),
+		slog.String("secret_path", key.secretPath),
+		slog.String("secret_key", key.secretKey),
+		slog.Time("taken_at", takenAt),
+		slog.Any("error", err),
+	)
+}
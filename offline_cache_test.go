@@ -0,0 +1,160 @@
+package infisical
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestOfflineCache(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	workspace := server.AddWorkspace("test-workspace", "dev")
+	server.SetSecret(workspace.ID, "dev", "/", "KEY1", "offline-value1")
+	server.SetSecret(workspace.ID, "dev", "/", "KEY2", "offline-value2")
+	server.AddFolder(workspace.ID, "dev", "/", "app")
+	importID := server.AddSecretImport(workspace.ID, "dev", "/app", "dev", "/")
+
+	dir := filepath.Join(t.TempDir(), "snapshots")
+
+	newClient := func(opts ...Option) *Client {
+		client, err := New(append([]Option{
+			WithUniversalAuth(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret),
+			WithBaseURL(server.URL),
+			WithRetryPolicy(nil),
+		}, opts...)...)
+		if err != nil {
+			t.Fatalf("failed to create client: %s", err)
+		}
+		return client
+	}
+	params := NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev")
+
+	// (write snapshots)
+	client := newClient(WithOfflineCache(&OfflineCachePolicy{Dir: dir}))
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err != nil {
+		t.Errorf("failed to retrieve a secret: %s", err)
+	}
+	if _, err := client.ListSecrets(params); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.snapshot"))
+	if len(files) != 1 {
+		t.Fatalf("a snapshot file should have been written: %v", files)
+	}
+	if info, err := os.Stat(files[0]); err != nil {
+		t.Errorf("failed to stat snapshot: %s", err)
+	} else if info.Mode().Perm() != 0o600 {
+		t.Errorf("snapshot file should have permission 0600: %o", info.Mode().Perm())
+	}
+	if written, err := os.ReadFile(files[0]); err != nil {
+		t.Errorf("failed to read snapshot: %s", err)
+	} else if bytes.Contains(written, []byte("offline-value")) || bytes.Contains(written, []byte("KEY1")) {
+		t.Errorf("snapshot should be encrypted")
+	}
+
+	// (serve snapshots from a fresh client while the server is unavailable)
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	})
+	client = newClient(WithOfflineCache(&OfflineCachePolicy{Dir: dir, MaxStaleness: time.Hour}))
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err != nil {
+		t.Errorf("retrieved secret should have been served from the offline cache: %s", err)
+	} else if value != "offline-value1" {
+		t.Errorf("unexpected value from the offline cache: %s", value)
+	}
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypePersonal, "/KEY2"); err != nil {
+		t.Errorf("listed secret should have been served from the offline cache: %s", err)
+	} else if value != "offline-value2" {
+		t.Errorf("unexpected value from the offline cache: %s", value)
+	}
+	if listed, err := client.ListSecrets(params); err != nil {
+		t.Errorf("listed secrets should have been served from the offline cache: %s", err)
+	} else if len(listed.Secrets) != 2 {
+		t.Errorf("unexpected number of secrets from the offline cache: %d", len(listed.Secrets))
+	}
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev").SetIncludeImports(true)); err == nil {
+		t.Errorf("secrets with imports should not be served when they were never listed")
+	}
+
+	// (staleness)
+	client = newClient(WithOfflineCache(&OfflineCachePolicy{Dir: dir, MaxStaleness: time.Nanosecond}))
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err == nil {
+		t.Errorf("too stale snapshot should not be served")
+	}
+
+	// (other keys)
+	client = newClient(WithOfflineCache(&OfflineCachePolicy{Dir: dir, Key: []byte("other-key")}))
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err == nil {
+		t.Errorf("snapshot encrypted with other keys should not be served")
+	}
+	server.ClearFaults()
+
+	// (client errors)
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusForbidden,
+	})
+	client = newClient(WithOfflineCache(&OfflineCachePolicy{Dir: dir}))
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err == nil {
+		t.Errorf("snapshot should not be served for client errors")
+	}
+	server.ClearFaults()
+
+	// (own changes)
+	importing := NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev").SetSecretPath("/app").SetIncludeImports(true)
+	if _, err := client.ListSecrets(importing); err != nil {
+		t.Errorf("failed to list secrets with imports: %s", err)
+	}
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/app/KEY2"); err != nil {
+		t.Errorf("failed to retrieve an imported secret: %s", err)
+	}
+	if err := client.DeleteSecret(workspace.ID, "dev", "KEY1", NewParamsDeleteSecret().SetType(SecretTypeShared)); err != nil {
+		t.Errorf("failed to delete a secret: %s", err)
+	}
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusBadGateway,
+	})
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY1"); err == nil {
+		t.Errorf("deleted secret should not be served from the offline cache")
+	}
+	if _, err := client.ListSecrets(importing); err == nil {
+		t.Errorf("secrets importing the deleted one should not be served from the offline cache")
+	}
+	if _, err := client.DeleteSecretImport(workspace.ID, "dev", importID, NewParamsDeleteSecretImport().SetPath("/app")); err != nil {
+		t.Errorf("failed to delete a secret import: %s", err)
+	}
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/app/KEY2"); err == nil {
+		t.Errorf("secret of the deleted import should not be served from the offline cache")
+	}
+	server.ClearFaults()
+
+	// (invalidations do not write snapshots)
+	empty := filepath.Join(t.TempDir(), "empty")
+	client = newClient(WithOfflineCache(&OfflineCachePolicy{Dir: empty}))
+	if err := client.CreateSecret(workspace.ID, "dev", "KEY3", "offline-value3", nil); err != nil {
+		t.Errorf("failed to create a secret: %s", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(empty, "*.snapshot")); len(files) != 0 {
+		t.Errorf("no snapshot should have been written without cached secrets: %v", files)
+	}
+
+	// (invalid policies)
+	if _, err := New(WithOfflineCache(&OfflineCachePolicy{})); err == nil {
+		t.Errorf("offline cache without a directory should have been rejected")
+	}
+	if _, err := New(WithAuthenticator(NewAccessTokenAuth("token")), WithOfflineCache(&OfflineCachePolicy{Dir: dir})); err == nil {
+		t.Errorf("offline cache without a key nor universal-auth should have been rejected")
+	}
+}
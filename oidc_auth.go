@@ -0,0 +1,53 @@
+package infisical
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// OIDCAuth is an authenticator with JWTs issued by OIDC providers
+type OIDCAuth struct {
+	identityID  string
+	tokenSource func(ctx context.Context) (string, error)
+}
+
+// NewOIDCAuth returns a new oidc-auth authenticator for given identity id.
+//
+// `tokenSource` is called on every login for fetching a fresh JWT.
+func NewOIDCAuth(identityID string, tokenSource func(ctx context.Context) (string, error)) *OIDCAuth {
+	return &OIDCAuth{
+		identityID:  identityID,
+		tokenSource: tokenSource,
+	}
+}
+
+// OIDCTokenFromFile returns a token source which reads a JWT from given file.
+//
+// (eg. for tokens which are written to files by CI runners or sidecars)
+func OIDCTokenFromFile(path string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(bytes)), nil
+	}
+}
+
+// Login logs in with a JWT from the token source.
+//
+// https://infisical.com/docs/api-reference/endpoints/oidc-auth/login
+func (a *OIDCAuth) Login(ctx context.Context, c *Client) (result AccessToken, err error) {
+	if a.tokenSource == nil {
+		return AccessToken{}, fmt.Errorf("no token source was given")
+	}
+
+	var jwt string
+	if jwt, err = a.tokenSource(ctx); err != nil {
+		return AccessToken{}, fmt.Errorf("failed to fetch OIDC token: %w", err)
+	}
+
+	return c.loginWithJWT(ctx, "/v1/auth/oidc-auth/login", a.identityID, jwt)
+}
@@ -0,0 +1,249 @@
+package infisical
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Option is a function for configuring clients created with `New`
+type Option func(cfg *clientConfig) error
+
+// clientConfig struct for building clients with options
+type clientConfig struct {
+	apiKey        *string
+	authenticator Authenticator
+
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    *time.Duration
+
+	rootCAs     *x509.CertPool
+	clientCerts []tls.Certificate
+	proxy       func(*http.Request) (*url.URL, error)
+
+	baseURL   string
+	userAgent string
+	headers   http.Header
+
+	retryPolicy        *RetryPolicy
+	tokenRenewalMargin time.Duration
+	secretCachePolicy  *SecretCachePolicy
+	offlineCachePolicy *OfflineCachePolicy
+	rotationPolicy     *ClientSecretRotationPolicy
+
+	logger          *slog.Logger
+	unsafeDebugDump bool
+}
+
+// WithAPIKey sets the API key of the client.
+func WithAPIKey(apiKey string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.apiKey = &apiKey
+		return nil
+	}
+}
+
+// WithAuthenticator sets the machine identity authenticator of the client.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(cfg *clientConfig) error {
+		cfg.authenticator = authenticator
+		return nil
+	}
+}
+
+// WithUniversalAuth sets a universal-auth authenticator with given client id and secret.
+func WithUniversalAuth(clientID, clientSecret string) Option {
+	return WithAuthenticator(NewUniversalAuth(clientID, clientSecret))
+}
+
+// WithHTTPClient sets the http client for sending requests.
+//
+// The given client is copied, so it will not be altered by other options.
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *clientConfig) error {
+		if client == nil {
+			return fmt.Errorf("http client is nil")
+		}
+		cfg.httpClient = client
+		return nil
+	}
+}
+
+// WithTransport sets the round tripper of the http client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) error {
+		if transport == nil {
+			return fmt.Errorf("transport is nil")
+		}
+		cfg.transport = transport
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of each http request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		cfg.timeout = &timeout
+		return nil
+	}
+}
+
+// WithBaseURL sets the API base URL.
+//
+// (eg. for using in self-hosted infisical servers)
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) error {
+		if _, err := url.ParseRequestURI(baseURL); err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		cfg.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithUserAgent sets the `User-Agent` header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHeader adds a header which will be sent with every request.
+func WithHeader(key, value string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithCACertificates adds PEM-encoded CA certificates for verifying servers.
+//
+// (eg. for self-hosted infisical servers behind an internal CA)
+func WithCACertificates(pem []byte) Option {
+	return func(cfg *clientConfig) error {
+		if cfg.rootCAs == nil {
+			if pool, err := x509.SystemCertPool(); err == nil {
+				cfg.rootCAs = pool
+			} else {
+				cfg.rootCAs = x509.NewCertPool()
+			}
+		}
+		if !cfg.rootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no valid CA certificate was found")
+		}
+		return nil
+	}
+}
+
+// WithCABundleFile adds CA certificates from given PEM-encoded bundle file.
+func WithCABundleFile(path string) Option {
+	return func(cfg *clientConfig) error {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		return WithCACertificates(pem)(cfg)
+	}
+}
+
+// WithClientCertificate adds a client certificate for mTLS.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(cfg *clientConfig) error {
+		cfg.clientCerts = append(cfg.clientCerts, cert)
+		return nil
+	}
+}
+
+// WithClientCertificateFiles adds a client certificate for mTLS from given PEM-encoded certificate and key files.
+func WithClientCertificateFiles(certFile, keyFile string) Option {
+	return func(cfg *clientConfig) error {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		return WithClientCertificate(cert)(cfg)
+	}
+}
+
+// WithProxy sets the proxy URL for requests.
+func WithProxy(proxyURL string) Option {
+	return func(cfg *clientConfig) error {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		cfg.proxy = http.ProxyURL(parsed)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy for failed requests.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithTokenRenewalMargin sets the margin before the expiry of access tokens for renewing them.
+func WithTokenRenewalMargin(margin time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		cfg.tokenRenewalMargin = margin
+		return nil
+	}
+}
+
+// buildHTTPClient builds the http client with the configuration.
+func (cfg *clientConfig) buildHTTPClient() (*http.Client, error) {
+	var client http.Client
+	if cfg.httpClient != nil {
+		client = *cfg.httpClient
+	} else {
+		client.Timeout = TimeoutSeconds * time.Second
+	}
+	if cfg.timeout != nil {
+		client.Timeout = *cfg.timeout
+	}
+	if cfg.transport != nil {
+		client.Transport = cfg.transport
+	}
+
+	// apply TLS and proxy settings to the transport
+	if cfg.rootCAs != nil || len(cfg.clientCerts) > 0 || cfg.proxy != nil {
+		var transport *http.Transport
+		switch t := client.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = t.Clone()
+		default:
+			return nil, fmt.Errorf("TLS and proxy options cannot be applied to transport of type %T", t)
+		}
+
+		if cfg.rootCAs != nil || len(cfg.clientCerts) > 0 {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			if cfg.rootCAs != nil {
+				transport.TLSClientConfig.RootCAs = cfg.rootCAs
+			}
+			if len(cfg.clientCerts) > 0 {
+				transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, cfg.clientCerts...)
+			}
+		}
+		if cfg.proxy != nil {
+			transport.Proxy = cfg.proxy
+		}
+
+		client.Transport = transport
+	}
+
+	return &client, nil
+}
@@ -0,0 +1,169 @@
+package infisical
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// handler of a stand-in server which echoes request headers as a secret value
+func newOptionsTestHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(AccessToken{
+			AccessToken: "test-access-token",
+			ExpiresIn:   600,
+			TokenType:   "Bearer",
+		})
+	})
+	mux.HandleFunc("/api/v3/secrets/raw/HEADERS", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(SecretData{
+			Secret: Secret{
+				SecretKey:   "HEADERS",
+				SecretValue: r.Header.Get("User-Agent") + "|" + r.Header.Get("X-Extra-Header"),
+			},
+		})
+	})
+	return mux
+}
+
+// generate a self-signed client certificate for mTLS
+func newTestClientCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}
+}
+
+// counting round tripper
+type countingTransport struct {
+	count atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestOptions(t *testing.T) {
+	// (user agent, extra headers, and transport)
+	server := httptest.NewServer(newOptionsTestHandler())
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client, err := New(
+		WithUniversalAuth("test-client-id", "test-client-secret"),
+		WithBaseURL(server.URL),
+		WithUserAgent("test-user-agent"),
+		WithHeader("X-Extra-Header", "extra"),
+		WithTransport(transport),
+		WithTimeout(3*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	if value, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/HEADERS"); err != nil {
+		t.Errorf("failed to retrieve a secret: %s", err)
+	} else if value != "test-user-agent|extra" {
+		t.Errorf("user agent or extra header was not sent: %s", value)
+	}
+	if transport.count.Load() != 2 {
+		t.Errorf("requests were not sent through the given transport: %d", transport.count.Load())
+	}
+
+	// (custom CA & client certificate)
+	tlsServer := httptest.NewUnstartedServer(newOptionsTestHandler())
+	tlsServer.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+
+	if client, err := New(
+		WithUniversalAuth("test-client-id", "test-client-secret"),
+		WithBaseURL(tlsServer.URL),
+	); err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	} else if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/HEADERS"); err == nil {
+		t.Errorf("request to a server with an unknown CA should have failed")
+	}
+
+	if client, err := New(
+		WithUniversalAuth("test-client-id", "test-client-secret"),
+		WithBaseURL(tlsServer.URL),
+		WithCACertificates(caPEM),
+	); err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	} else if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/HEADERS"); err == nil {
+		t.Errorf("request without a client certificate should have failed")
+	}
+
+	if client, err := New(
+		WithUniversalAuth("test-client-id", "test-client-secret"),
+		WithBaseURL(tlsServer.URL),
+		WithCACertificates(caPEM),
+		WithClientCertificate(newTestClientCertificate(t)),
+	); err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	} else if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/HEADERS"); err != nil {
+		t.Errorf("failed to retrieve a secret with custom CA and client certificate: %s", err)
+	}
+
+	// (proxy)
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Add(1)
+		newOptionsTestHandler().ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	if client, err := New(
+		WithUniversalAuth("test-client-id", "test-client-secret"),
+		WithBaseURL("http://infisical.invalid"),
+		WithProxy(proxy.URL),
+	); err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	} else if _, err := client.RetrieveSecretValue("workspace", "dev", SecretTypeShared, "/HEADERS"); err != nil {
+		t.Errorf("failed to retrieve a secret through the proxy: %s", err)
+	} else if proxied.Load() != 2 {
+		t.Errorf("requests were not sent through the proxy: %d", proxied.Load())
+	}
+
+	// (invalid options)
+	if _, err := New(WithCACertificates([]byte("not a certificate"))); err == nil {
+		t.Errorf("invalid CA certificate should have been rejected")
+	}
+	if _, err := New(WithBaseURL("not a url")); err == nil {
+		t.Errorf("invalid base URL should have been rejected")
+	}
+	if _, err := New(WithTransport(transport), WithProxy(proxy.URL)); err == nil {
+		t.Errorf("proxy option should have been rejected for a custom round tripper")
+	}
+}
@@ -1,6 +1,7 @@
 package infisical
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -17,16 +18,23 @@ type ProjectsData struct {
 
 // Workspace struct for project
 type Workspace struct {
-	ID           string                 `json:"id"`
-	Name         string                 `json:"name"`
-	Organization string                 `json:"organization"`
-	Environments []WorkspaceEnvironment `json:"environments"`
+	ID                 string                 `json:"id"`
+	Name               string                 `json:"name"`
+	Slug               string                 `json:"slug,omitempty"`
+	Description        *string                `json:"description,omitempty"`
+	AutoCapitalization bool                   `json:"autoCapitalization"`
+	Organization       string                 `json:"organization"`
+	Environments       []WorkspaceEnvironment `json:"environments"`
+	CreatedAt          string                 `json:"createdAt,omitempty"`
+	UpdatedAt          string                 `json:"updatedAt,omitempty"`
 }
 
 // WorkspaceEnvironment struct for environments
 type WorkspaceEnvironment struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	Position int    `json:"position,omitempty"`
 }
 
 // Organization struct for one organization
@@ -57,22 +65,27 @@ TODO:
 //
 // https://infisical.com/docs/api-reference/endpoints/organizations/workspaces
 func (c *Client) RetrieveProjects(organizationID string) (result ProjectsData, err error) {
+	return c.RetrieveProjectsWithContext(context.Background(), organizationID)
+}
+
+// RetrieveProjectsWithContext is the same as `RetrieveProjects` with given context.
+func (c *Client) RetrieveProjectsWithContext(ctx context.Context, organizationID string) (result ProjectsData, err error) {
+	if err = validateID("organization id", organizationID); err != nil {
+		return ProjectsData{}, fmt.Errorf("failed to retrieve workspaces: %w", err)
+	}
+
 	path := fmt.Sprintf("/v2/organizations/%s/workspaces", organizationID)
 
 	var req *http.Request
-	req, err = c.newRequestWithQueryParams("GET", path, AuthMethodNormal, nil)
+	req, err = c.newRequestWithQueryParams(ctx, "GET", path, AuthMethodNormal, nil)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
 				return result, nil
 			}
 		}
 	}
 
-	return ProjectsData{}, fmt.Errorf("failed to retrieve workspaces: %s", err)
+	return ProjectsData{}, fmt.Errorf("failed to retrieve workspaces: %w", err)
 }
@@ -0,0 +1,120 @@
+package infisical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// allowed names of folders
+var folderNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// allowed slugs (of tags, projects, environments, ...)
+var slugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// queryParams converts given params (a map, or a struct with JSON tags) into a map of query parameters.
+//
+// (numbers are kept as `json.Number`s, so large integers are not formatted in exponent notation)
+func queryParams(params any) (converted map[string]any, err error) {
+	switch p := params.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return p, nil
+	}
+
+	var encoded []byte
+	if encoded, err = json.Marshal(params); err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(encoded))
+		decoder.UseNumber()
+		err = decoder.Decode(&converted)
+	}
+	return converted, err
+}
+
+// validateWorkspace checks given workspace id and environment.
+func validateWorkspace(workspaceID, environment string) error {
+	if workspaceID == "" {
+		return fmt.Errorf("%w: workspace id is missing", ErrInvalidParams)
+	}
+	if environment == "" {
+		return fmt.Errorf("%w: environment is missing", ErrInvalidParams)
+	}
+	return nil
+}
+
+// validateID checks given id (or slug) which will be a part of request paths.
+func validateID(name, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: %s is missing", ErrInvalidParams, name)
+	}
+	if strings.ContainsAny(id, "/?#") {
+		return fmt.Errorf("%w: %s '%s' has invalid characters", ErrInvalidParams, name, id)
+	}
+	return nil
+}
+
+// validateSlug checks given slug, eg. "team-payments".
+func validateSlug(name, slug string) error {
+	if slug == "" {
+		return fmt.Errorf("%w: %s is missing", ErrInvalidParams, name)
+	}
+	if !slugRegexp.MatchString(slug) {
+		return fmt.Errorf("%w: %s '%s' should consist of lowercase letters, digits, and hyphens", ErrInvalidParams, name, slug)
+	}
+	return nil
+}
+
+// validateSecretKey checks given secret key.
+func validateSecretKey(secretKey string) error {
+	return validateID("secret key", secretKey)
+}
+
+// validatePath checks given secret or folder path. (nil is valid, for the default path)
+//
+// eg. "/", "/folder1/folder2"
+func validatePath(path *string) error {
+	if path == nil || *path == "/" {
+		return nil
+	}
+	if !strings.HasPrefix(*path, "/") {
+		return fmt.Errorf("%w: path '%s' should start with '/'", ErrInvalidParams, *path)
+	}
+	for _, name := range strings.Split(strings.TrimPrefix(*path, "/"), "/") {
+		if !folderNameRegexp.MatchString(name) {
+			return fmt.Errorf("%w: path '%s' has an invalid folder name: '%s'", ErrInvalidParams, *path, name)
+		}
+	}
+	return nil
+}
+
+// validateSecretType checks given secret type. (nil is valid, for the default type)
+func validateSecretType(typ *SecretType) error {
+	if typ == nil || *typ == SecretTypeShared || *typ == SecretTypePersonal {
+		return nil
+	}
+	return fmt.Errorf("%w: secret type '%s' is not one of '%s' and '%s'", ErrInvalidParams, *typ, SecretTypeShared, SecretTypePersonal)
+}
+
+// validateFolderName checks given folder name.
+func validateFolderName(name string) error {
+	if !folderNameRegexp.MatchString(name) {
+		return fmt.Errorf("%w: folder name '%s' is invalid", ErrInvalidParams, name)
+	}
+	return nil
+}
+
+// ptr returns a pointer to given value.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// deref returns the value of given pointer, or zero value if it is nil.
+func deref[T any](p *T) (v T) {
+	if p != nil {
+		v = *p
+	}
+	return v
+}
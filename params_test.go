@@ -0,0 +1,85 @@
+package infisical
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+	server.AddFolder(workspace.ID, "dev", "/", "folder")
+	server.AddFolder(workspace.ID, "prod", "/", "folder")
+
+	client := target.newClient(t)
+
+	// (params are not mutated, so they can be reused)
+	params := NewParamsCreateSecret().SetSecretPath("/folder/").SetSecretComment("comment")
+	copied := *params
+	if err := client.CreateSecret(workspace.ID, "dev", "KEY", "dev-value", params); err != nil {
+		t.Errorf("failed to create a secret: %s", err)
+	}
+	if err := client.CreateSecret(workspace.ID, "prod", "KEY", "prod-value", params); err != nil {
+		t.Errorf("failed to create a secret with reused params: %s", err)
+	}
+	if !reflect.DeepEqual(*params, copied) {
+		t.Errorf("params were mutated: %+v", *params)
+	}
+	for env, expected := range map[string]string{"dev": "dev-value", "prod": "prod-value"} {
+		if value, exists := server.Secret(workspace.ID, env, "/folder", "KEY"); !exists || value != expected {
+			t.Errorf("secret was not created properly in '%s': %s", env, value)
+		}
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing workspace id": func(client *Client) error {
+			return client.CreateSecret("", "dev", "KEY", "value", nil)
+		},
+		"missing environment": func(client *Client) error {
+			_, err := client.RetrieveSecret(workspace.ID, "", "KEY", nil)
+			return err
+		},
+		"missing secret key": func(client *Client) error {
+			return client.UpdateSecret(workspace.ID, "dev", "", "value", nil)
+		},
+		"invalid secret key": func(client *Client) error {
+			return client.DeleteSecret(workspace.ID, "dev", "folder/KEY", nil)
+		},
+		"relative path": func(client *Client) error {
+			return client.CreateSecret(workspace.ID, "dev", "KEY", "value", NewParamsCreateSecret().SetSecretPath("folder"))
+		},
+		"empty folder name": func(client *Client) error {
+			return client.CreateSecret(workspace.ID, "dev", "KEY", "value", NewParamsCreateSecret().SetSecretPath("/folder//sub"))
+		},
+		"invalid type": func(client *Client) error {
+			return client.UpdateSecret(workspace.ID, "dev", "KEY", "value", NewParamsUpdateSecret().SetType("private"))
+		},
+		"missing list params": func(client *Client) error {
+			_, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID))
+			return err
+		},
+		"invalid folder name": func(client *Client) error {
+			_, err := client.CreateFolder(workspace.ID, "dev", "new folder", nil)
+			return err
+		},
+		"missing folder id": func(client *Client) error {
+			_, err := client.DeleteFolder(workspace.ID, "dev", "", nil)
+			return err
+		},
+	})
+
+	// (errors are prefixed with what failed)
+	for expected, err := range map[string]error{
+		"failed to create a secret: ": client.CreateSecret("", "dev", "KEY", "value", nil),
+		"failed to update a secret: ": client.UpdateSecret(workspace.ID, "dev", "", "value", nil),
+		"failed to delete a secret: ": client.DeleteSecret(workspace.ID, "dev", "KEY", NewParamsDeleteSecret().SetSecretPath("/missing")),
+	} {
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Errorf("error should start with '%s': %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package infisical
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PermissionSubject type for subjects of permissions
+type PermissionSubject string
+
+// PermissionSubject constants
+const (
+	SubjectSecrets        PermissionSubject = "secrets"
+	SubjectSecretFolders  PermissionSubject = "secret-folders"
+	SubjectSecretImports  PermissionSubject = "secret-imports"
+	SubjectSecretRollback PermissionSubject = "secret-rollback"
+	SubjectSecretApproval PermissionSubject = "secret-approval"
+	SubjectEnvironments   PermissionSubject = "environments"
+	SubjectTags           PermissionSubject = "tags"
+	SubjectMembers        PermissionSubject = "member"
+	SubjectGroups         PermissionSubject = "groups"
+	SubjectIdentities     PermissionSubject = "identity"
+	SubjectRoles          PermissionSubject = "role"
+	SubjectSettings       PermissionSubject = "settings"
+	SubjectIntegrations   PermissionSubject = "integrations"
+	SubjectWebhooks       PermissionSubject = "webhooks"
+	SubjectServiceTokens  PermissionSubject = "service-tokens"
+	SubjectAuditLogs      PermissionSubject = "audit-logs"
+	SubjectIPAllowList    PermissionSubject = "ip-allowlist"
+	SubjectWorkspace      PermissionSubject = "workspace"
+)
+
+// PermissionAction type for actions of permissions
+type PermissionAction string
+
+// PermissionAction constants
+const (
+	ActionRead   PermissionAction = "read"
+	ActionCreate PermissionAction = "create"
+	ActionEdit   PermissionAction = "edit"
+	ActionDelete PermissionAction = "delete"
+)
+
+// fields of permission conditions
+const (
+	ConditionFieldEnvironment = "environment"
+	ConditionFieldSecretPath  = "secretPath"
+)
+
+// ConditionOperator type for operators of permission conditions
+type ConditionOperator string
+
+// ConditionOperator constants
+const (
+	OperatorEq   ConditionOperator = "$eq"
+	OperatorNe   ConditionOperator = "$ne"
+	OperatorIn   ConditionOperator = "$in"
+	OperatorGlob ConditionOperator = "$glob"
+)
+
+// PermissionCondition struct for a condition of a permission, eg. `secretPath` `$glob` `/payments/**`
+type PermissionCondition struct {
+	Field    string // eg. `ConditionFieldEnvironment`
+	Operator ConditionOperator
+	Values   []string // one or more values for `OperatorIn`, exactly one for others
+}
+
+// validate checks the condition.
+func (c PermissionCondition) validate() error {
+	if c.Field == "" {
+		return fmt.Errorf("%w: field of condition is missing", ErrInvalidParams)
+	}
+	switch c.Operator {
+	case OperatorIn:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%w: condition '%s %s' needs one or more values", ErrInvalidParams, c.Field, c.Operator)
+		}
+	case OperatorEq, OperatorNe, OperatorGlob:
+		if len(c.Values) != 1 {
+			return fmt.Errorf("%w: condition '%s %s' needs exactly one value: %v", ErrInvalidParams, c.Field, c.Operator, c.Values)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported operator '%s' of condition '%s'", ErrInvalidParams, c.Operator, c.Field)
+	}
+	return nil
+}
+
+// Permission struct for a permission of a project role
+//
+// It is marshaled into (and unmarshaled from) Infisical's permission format, eg.
+//
+//	{"subject": "secrets", "action": ["read"], "conditions": {"environment": {"$eq": "prod"}, "secretPath": {"$glob": "/payments/**"}}}
+type Permission struct {
+	Subject    PermissionSubject
+	Actions    []PermissionAction
+	Conditions []PermissionCondition // all of them should be met (sorted by fields and operators)
+	Inverted   bool                  // true for forbidding
+}
+
+// permissionJSON struct for marshaling/unmarshaling permissions
+type permissionJSON struct {
+	Subject    json.RawMessage            `json:"subject"`
+	Action     json.RawMessage            `json:"action"`
+	Conditions map[string]json.RawMessage `json:"conditions,omitempty"`
+	Inverted   bool                       `json:"inverted,omitempty"`
+}
+
+// MarshalJSON marshals the permission into Infisical's permission format.
+func (p Permission) MarshalJSON() ([]byte, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	var encoded permissionJSON
+	var err error
+	if encoded.Subject, err = json.Marshal(p.Subject); err != nil {
+		return nil, err
+	}
+	if encoded.Action, err = json.Marshal(p.Actions); err != nil {
+		return nil, err
+	}
+	if len(p.Conditions) > 0 {
+		operators := map[string]map[ConditionOperator]any{}
+		for _, c := range p.Conditions {
+			if operators[c.Field] == nil {
+				operators[c.Field] = map[ConditionOperator]any{}
+			}
+			if c.Operator == OperatorIn {
+				operators[c.Field][c.Operator] = c.Values
+			} else {
+				operators[c.Field][c.Operator] = c.Values[0]
+			}
+		}
+		encoded.Conditions = map[string]json.RawMessage{}
+		for field, ops := range operators {
+			if encoded.Conditions[field], err = json.Marshal(ops); err != nil {
+				return nil, err
+			}
+		}
+	}
+	encoded.Inverted = p.Inverted
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON unmarshals the permission from Infisical's permission format.
+//
+// (a subject or an action can be a string or an array, and a condition can be a string for `$eq`)
+func (p *Permission) UnmarshalJSON(data []byte) error {
+	var decoded permissionJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	subjects, err := stringOrStrings(decoded.Subject)
+	if err != nil || len(subjects) != 1 {
+		return fmt.Errorf("permission should have exactly one subject: %s", string(decoded.Subject))
+	}
+	actions, err := stringOrStrings(decoded.Action)
+	if err != nil {
+		return fmt.Errorf("invalid actions of permission: %w", err)
+	}
+
+	parsed := Permission{
+		Subject:  PermissionSubject(subjects[0]),
+		Inverted: decoded.Inverted,
+	}
+	for _, action := range actions {
+		parsed.Actions = append(parsed.Actions, PermissionAction(action))
+	}
+	for field, raw := range decoded.Conditions {
+		// plain value for `$eq`
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			parsed.Conditions = append(parsed.Conditions, PermissionCondition{Field: field, Operator: OperatorEq, Values: []string{value}})
+			continue
+		}
+
+		var operators map[ConditionOperator]json.RawMessage
+		if err := json.Unmarshal(raw, &operators); err != nil {
+			return fmt.Errorf("invalid condition '%s' of permission: %w", field, err)
+		}
+		for operator, raw := range operators {
+			values, err := stringOrStrings(raw)
+			if err != nil {
+				return fmt.Errorf("invalid condition '%s %s' of permission: %w", field, operator, err)
+			}
+			parsed.Conditions = append(parsed.Conditions, PermissionCondition{Field: field, Operator: operator, Values: values})
+		}
+	}
+	sortConditions(parsed.Conditions)
+
+	*p = parsed
+	return nil
+}
+
+// validate checks the permission.
+func (p Permission) validate() error {
+	errs := []error{}
+	if p.Subject == "" {
+		errs = append(errs, fmt.Errorf("%w: subject of permission is missing", ErrInvalidParams))
+	}
+	if len(p.Actions) == 0 {
+		errs = append(errs, fmt.Errorf("%w: actions of permission '%s' are missing", ErrInvalidParams, p.Subject))
+	}
+	for i, c := range p.Conditions {
+		errs = append(errs, c.validate())
+		if slices.ContainsFunc(p.Conditions[:i], func(prev PermissionCondition) bool { return prev.Field == c.Field && prev.Operator == c.Operator }) {
+			errs = append(errs, fmt.Errorf("%w: condition '%s %s' is duplicated", ErrInvalidParams, c.Field, c.Operator))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stringOrStrings decodes a JSON string or an array of strings.
+func stringOrStrings(raw json.RawMessage) ([]string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return []string{value}, nil
+	}
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// sortConditions sorts given conditions by their fields and operators.
+func sortConditions(conditions []PermissionCondition) {
+	slices.SortFunc(conditions, func(a, b PermissionCondition) int {
+		if c := strings.Compare(a.Field, b.Field); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.Operator), string(b.Operator))
+	})
+}
+
+// ParsePermissions parses permissions in Infisical's permission format.
+func ParsePermissions(data []byte) (permissions []Permission, err error) {
+	if err = json.Unmarshal(data, &permissions); err != nil {
+		return nil, fmt.Errorf("failed to parse permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// validatePermissions checks given permissions.
+func validatePermissions(permissions []Permission) error {
+	if len(permissions) == 0 {
+		return fmt.Errorf("%w: permissions are missing", ErrInvalidParams)
+	}
+	errs := []error{}
+	for _, p := range permissions {
+		errs = append(errs, p.validate())
+	}
+	return errors.Join(errs...)
+}
+
+// PermissionsBuilder is a fluent builder of permissions.
+//
+// eg. read-only access to secrets of "prod" environment under "/payments" only:
+//
+//	permissions, err := infisical.NewPermissionsBuilder().
+//		Allow(infisical.SubjectSecrets, infisical.ActionRead).
+//		InEnvironments("prod").
+//		WithSecretPath("/payments/**").
+//		Build()
+type PermissionsBuilder struct {
+	permissions []Permission
+	errs        []error
+}
+
+// NewPermissionsBuilder returns a new builder, starting with given permissions (eg. parsed ones).
+func NewPermissionsBuilder(permissions ...Permission) *PermissionsBuilder {
+	b := &PermissionsBuilder{}
+	for _, p := range permissions {
+		p.Actions = slices.Clone(p.Actions)
+		p.Conditions = slices.Clone(p.Conditions)
+		b.permissions = append(b.permissions, p)
+	}
+	return b
+}
+
+// Allow adds a permission which allows given actions on the subject.
+//
+// Following conditions are applied to this permission.
+func (b *PermissionsBuilder) Allow(subject PermissionSubject, actions ...PermissionAction) *PermissionsBuilder {
+	b.permissions = append(b.permissions, Permission{Subject: subject, Actions: actions})
+	return b
+}
+
+// Deny adds a permission which forbids given actions on the subject.
+//
+// Following conditions are applied to this permission.
+func (b *PermissionsBuilder) Deny(subject PermissionSubject, actions ...PermissionAction) *PermissionsBuilder {
+	b.permissions = append(b.permissions, Permission{Subject: subject, Actions: actions, Inverted: true})
+	return b
+}
+
+// Where adds a condition to the last added permission.
+func (b *PermissionsBuilder) Where(field string, operator ConditionOperator, values ...string) *PermissionsBuilder {
+	if len(b.permissions) == 0 {
+		b.errs = append(b.errs, fmt.Errorf("%w: condition '%s %s' was added before any permission", ErrInvalidParams, field, operator))
+		return b
+	}
+	last := &b.permissions[len(b.permissions)-1]
+	last.Conditions = append(last.Conditions, PermissionCondition{Field: field, Operator: operator, Values: values})
+	return b
+}
+
+// InEnvironments limits the last added permission to given environments.
+func (b *PermissionsBuilder) InEnvironments(slugs ...string) *PermissionsBuilder {
+	if len(slugs) == 1 {
+		return b.Where(ConditionFieldEnvironment, OperatorEq, slugs...)
+	}
+	return b.Where(ConditionFieldEnvironment, OperatorIn, slugs...)
+}
+
+// NotInEnvironment excludes given environment from the last added permission.
+func (b *PermissionsBuilder) NotInEnvironment(slug string) *PermissionsBuilder {
+	return b.Where(ConditionFieldEnvironment, OperatorNe, slug)
+}
+
+// WithSecretPath limits the last added permission to secret paths matching given glob, eg. "/payments/**".
+func (b *PermissionsBuilder) WithSecretPath(glob string) *PermissionsBuilder {
+	return b.Where(ConditionFieldSecretPath, OperatorGlob, glob)
+}
+
+// Build validates and returns the built permissions.
+func (b *PermissionsBuilder) Build() (permissions []Permission, err error) {
+	permissions = []Permission{}
+	for _, p := range b.permissions {
+		p.Actions = slices.Clone(p.Actions)
+		p.Conditions = slices.Clone(p.Conditions)
+		sortConditions(p.Conditions)
+		permissions = append(permissions, p)
+	}
+	if err = errors.Join(append(slices.Clone(b.errs), validatePermissions(permissions))...); err != nil {
+		return nil, fmt.Errorf("failed to build permissions: %w", err)
+	}
+	return permissions, nil
+}
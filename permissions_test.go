@@ -0,0 +1,91 @@
+package infisical
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPermissions(t *testing.T) {
+	// (build permissions)
+	permissions, err := NewPermissionsBuilder().
+		Allow(SubjectSecrets, ActionRead).
+		WithSecretPath("/payments/**").
+		InEnvironments("prod").
+		Allow(SubjectSecretFolders, ActionRead).
+		InEnvironments("dev", "prod").
+		Deny(SubjectSecrets, ActionEdit, ActionDelete).
+		NotInEnvironment("dev").
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build permissions: %s", err)
+	}
+	if len(permissions) != 3 {
+		t.Fatalf("unexpected number of permissions: %d", len(permissions))
+	}
+	if conditions := permissions[0].Conditions; len(conditions) != 2 ||
+		conditions[0].Field != ConditionFieldEnvironment || conditions[0].Operator != OperatorEq ||
+		conditions[1].Field != ConditionFieldSecretPath || conditions[1].Operator != OperatorGlob {
+		t.Errorf("conditions should be sorted by fields: %+v", conditions)
+	}
+	if !permissions[2].Inverted {
+		t.Errorf("denied permission should be inverted: %+v", permissions[2])
+	}
+
+	// (marshal into Infisical's permission format)
+	encoded, err := json.Marshal(permissions)
+	if err != nil {
+		t.Fatalf("failed to marshal permissions: %s", err)
+	}
+	expected := `[` +
+		`{"subject":"secrets","action":["read"],"conditions":{"environment":{"$eq":"prod"},"secretPath":{"$glob":"/payments/**"}}},` +
+		`{"subject":"secret-folders","action":["read"],"conditions":{"environment":{"$in":["dev","prod"]}}},` +
+		`{"subject":"secrets","action":["edit","delete"],"conditions":{"environment":{"$ne":"dev"}},"inverted":true}` +
+		`]`
+	if string(encoded) != expected {
+		t.Errorf("unexpected permissions format:\n%s\n%s", string(encoded), expected)
+	}
+
+	// (round trip)
+	if parsed, err := ParsePermissions(encoded); err != nil {
+		t.Errorf("failed to parse permissions: %s", err)
+	} else if !reflect.DeepEqual(parsed, permissions) {
+		t.Errorf("permissions were not round-tripped:\n%+v\n%+v", parsed, permissions)
+	}
+
+	// (parse shorthand forms)
+	if parsed, err := ParsePermissions([]byte(`[{"subject":["secrets"],"action":"read","conditions":{"environment":"prod"}}]`)); err != nil {
+		t.Errorf("failed to parse permissions: %s", err)
+	} else if len(parsed) != 1 || !reflect.DeepEqual(parsed[0], Permission{
+		Subject:    SubjectSecrets,
+		Actions:    []PermissionAction{ActionRead},
+		Conditions: []PermissionCondition{{Field: ConditionFieldEnvironment, Operator: OperatorEq, Values: []string{"prod"}}},
+	}) {
+		t.Errorf("unexpected parsed permissions: %+v", parsed)
+	}
+	if _, err := ParsePermissions([]byte(`[{"subject":["secrets","tags"],"action":"read"}]`)); err == nil {
+		t.Errorf("permissions with multiple subjects should not be parsed")
+	}
+
+	// (extend parsed permissions)
+	if extended, err := NewPermissionsBuilder(permissions...).Allow(SubjectTags, ActionRead).Build(); err != nil {
+		t.Errorf("failed to extend permissions: %s", err)
+	} else if len(extended) != 4 || len(permissions) != 3 {
+		t.Errorf("unexpected extended permissions: %+v", extended)
+	}
+
+	// (invalid permissions)
+	for name, builder := range map[string]*PermissionsBuilder{
+		"condition without permission": NewPermissionsBuilder().InEnvironments("prod"),
+		"missing actions":              NewPermissionsBuilder().Allow(SubjectSecrets),
+		"missing environments":         NewPermissionsBuilder().Allow(SubjectSecrets, ActionRead).InEnvironments(),
+		"duplicated conditions":        NewPermissionsBuilder().Allow(SubjectSecrets, ActionRead).WithSecretPath("/a/**").WithSecretPath("/b/**"),
+		"unsupported operator":         NewPermissionsBuilder().Allow(SubjectSecrets, ActionRead).Where(ConditionFieldSecretPath, "$regex", "^/a"),
+		"no permissions":               NewPermissionsBuilder(),
+	} {
+		if _, err := builder.Build(); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("%s: error should match `ErrInvalidParams`: %v", name, err)
+		}
+	}
+}
@@ -1,11 +1,224 @@
 package infisical
 
-/*
-TODO:
-* Project Identities
-- [ ] [Create Identity Membership](https://infisical.com/docs/api-reference/endpoints/project-identities/add-identity-membership)
-- [ ] [List Identity Memberships](https://infisical.com/docs/api-reference/endpoints/project-identities/list-identity-memberships)
-- [ ] [Get Identity By ID](https://infisical.com/docs/api-reference/endpoints/project-identities/get-by-id)
-- [ ] [Update Identity Membership](https://infisical.com/docs/api-reference/endpoints/project-identities/update-identity-membership)
-- [ ] [Delete Identity Membership](https://infisical.com/docs/api-reference/endpoints/project-identities/delete-identity-membership)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// IdentityMembershipsData struct for identity memberships response
+type IdentityMembershipsData struct {
+	IdentityMemberships []IdentityMembership `json:"identityMemberships"`
+	TotalCount          int                  `json:"totalCount"`
+}
+
+// IdentityMembershipData struct for identity membership response
+type IdentityMembershipData struct {
+	IdentityMembership IdentityMembership `json:"identityMembership"`
+}
+
+// IdentityMembership struct for a machine identity's membership of a project
+type IdentityMembership struct {
+	ID         string              `json:"id"`
+	IdentityID string              `json:"identityId"`
+	ProjectID  string              `json:"projectId"`
+	Identity   *MembershipIdentity `json:"identity,omitempty"`
+	Roles      []MembershipRole    `json:"roles"`
+	CreatedAt  string              `json:"createdAt"`
+	UpdatedAt  string              `json:"updatedAt"`
+}
+
+// MembershipIdentity struct for a machine identity of an identity membership
+type MembershipIdentity struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	AuthMethods []string `json:"authMethods,omitempty"`
+}
+
+// CreateIdentityMembership adds a machine identity to a project with given roles.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-identities/add-identity-membership
+func (c *Client) CreateIdentityMembership(workspaceID, identityID string, roles []RoleAssignment) (result IdentityMembershipData, err error) {
+	return c.CreateIdentityMembershipWithContext(context.Background(), workspaceID, identityID, roles)
+}
+
+// CreateIdentityMembershipWithContext is the same as `CreateIdentityMembership` with given context.
+func (c *Client) CreateIdentityMembershipWithContext(ctx context.Context, workspaceID, identityID string, roles []RoleAssignment) (result IdentityMembershipData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("identity id", identityID), validateRoleAssignments(roles)); err != nil {
+		return IdentityMembershipData{}, fmt.Errorf("failed to create an identity membership: %w", err)
+	}
+
+	// essential params
+	body := struct {
+		Roles []RoleAssignment `json:"roles"`
+	}{roles}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v2/workspace/%s/identity-memberships/%s", workspaceID, identityID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityMembershipData{}, fmt.Errorf("failed to create an identity membership: %w", err)
+}
+
+// ParamsListIdentityMemberships struct for listing identity memberships
+type ParamsListIdentityMemberships struct {
+	Offset       *int    `json:"offset,omitempty"`
+	Limit        *int    `json:"limit,omitempty"`
+	IdentityName *string `json:"identityName,omitempty"`
+}
+
+// NewParamsListIdentityMemberships returns a new params for listing identity memberships (with the server's default paging).
+func NewParamsListIdentityMemberships() *ParamsListIdentityMemberships {
+	return &ParamsListIdentityMemberships{}
+}
+
+func (p *ParamsListIdentityMemberships) SetOffset(offset int) *ParamsListIdentityMemberships {
+	p.Offset = &offset
+	return p
+}
+
+func (p *ParamsListIdentityMemberships) SetLimit(limit int) *ParamsListIdentityMemberships {
+	p.Limit = &limit
+	return p
+}
+
+func (p *ParamsListIdentityMemberships) SetIdentityName(name string) *ParamsListIdentityMemberships {
+	p.IdentityName = &name
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsListIdentityMemberships) validate() error {
+	errs := []error{}
+	if p.Offset != nil && *p.Offset < 0 {
+		errs = append(errs, fmt.Errorf("%w: offset should not be negative: %d", ErrInvalidParams, *p.Offset))
+	}
+	if p.Limit != nil && *p.Limit < 1 {
+		errs = append(errs, fmt.Errorf("%w: limit should be 1 or greater: %d", ErrInvalidParams, *p.Limit))
+	}
+	return errors.Join(errs...)
+}
+
+// ListIdentityMemberships lists machine identities' memberships of a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-identities/list-identity-memberships
+func (c *Client) ListIdentityMemberships(workspaceID string, params *ParamsListIdentityMemberships) (result IdentityMembershipsData, err error) {
+	return c.ListIdentityMembershipsWithContext(context.Background(), workspaceID, params)
+}
+
+// ListIdentityMembershipsWithContext is the same as `ListIdentityMemberships` with given context.
+func (c *Client) ListIdentityMembershipsWithContext(ctx context.Context, workspaceID string, params *ParamsListIdentityMemberships) (result IdentityMembershipsData, err error) {
+	if params == nil {
+		params = NewParamsListIdentityMemberships()
+	}
+	if err = errors.Join(validateID("workspace id", workspaceID), params.validate()); err != nil {
+		return IdentityMembershipsData{}, fmt.Errorf("failed to list identity memberships: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v2/workspace/%s/identity-memberships", workspaceID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityMembershipsData{}, fmt.Errorf("failed to list identity memberships: %w", err)
+}
+
+// GetIdentityMembership retrieves a machine identity's membership of a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-identities/get-by-id
+func (c *Client) GetIdentityMembership(workspaceID, identityID string) (result IdentityMembershipData, err error) {
+	return c.GetIdentityMembershipWithContext(context.Background(), workspaceID, identityID)
+}
+
+// GetIdentityMembershipWithContext is the same as `GetIdentityMembership` with given context.
+func (c *Client) GetIdentityMembershipWithContext(ctx context.Context, workspaceID, identityID string) (result IdentityMembershipData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("identity id", identityID)); err != nil {
+		return IdentityMembershipData{}, fmt.Errorf("failed to get an identity membership: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v2/workspace/%s/identity-memberships/%s", workspaceID, identityID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityMembershipData{}, fmt.Errorf("failed to get an identity membership: %w", err)
+}
+
+// UpdateIdentityMembership replaces roles of a machine identity's membership of a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-identities/update-identity-membership
+func (c *Client) UpdateIdentityMembership(workspaceID, identityID string, roles []RoleAssignment) (result MembershipRolesData, err error) {
+	return c.UpdateIdentityMembershipWithContext(context.Background(), workspaceID, identityID, roles)
+}
+
+// UpdateIdentityMembershipWithContext is the same as `UpdateIdentityMembership` with given context.
+func (c *Client) UpdateIdentityMembershipWithContext(ctx context.Context, workspaceID, identityID string, roles []RoleAssignment) (result MembershipRolesData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("identity id", identityID), validateRoleAssignments(roles)); err != nil {
+		return MembershipRolesData{}, fmt.Errorf("failed to update an identity membership: %w", err)
+	}
+
+	// essential params
+	body := struct {
+		Roles []RoleAssignment `json:"roles"`
+	}{roles}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v2/workspace/%s/identity-memberships/%s", workspaceID, identityID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return MembershipRolesData{}, fmt.Errorf("failed to update an identity membership: %w", err)
+}
+
+// DeleteIdentityMembership removes a machine identity from a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-identities/delete-identity-membership
+func (c *Client) DeleteIdentityMembership(workspaceID, identityID string) (result IdentityMembershipData, err error) {
+	return c.DeleteIdentityMembershipWithContext(context.Background(), workspaceID, identityID)
+}
+
+// DeleteIdentityMembershipWithContext is the same as `DeleteIdentityMembership` with given context.
+func (c *Client) DeleteIdentityMembershipWithContext(ctx context.Context, workspaceID, identityID string) (result IdentityMembershipData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("identity id", identityID)); err != nil {
+		return IdentityMembershipData{}, fmt.Errorf("failed to delete an identity membership: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "DELETE", fmt.Sprintf("/v2/workspace/%s/identity-memberships/%s", workspaceID, identityID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return IdentityMembershipData{}, fmt.Errorf("failed to delete an identity membership: %w", err)
+}
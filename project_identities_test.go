@@ -0,0 +1,117 @@
+package infisical
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestProjectIdentities(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+	workerID := server.AddUniversalAuthIdentity("payments-worker", "worker-client-id", "worker-client-secret")
+
+	client := target.newClient(t)
+
+	// (durations of temporary roles)
+	for duration, expected := range map[time.Duration]string{
+		48 * time.Hour:          "2d",
+		90 * time.Minute:        "90m",
+		1500 * time.Millisecond: "1500ms",
+		time.Microsecond:        "",
+	} {
+		if formatted := formatTemporaryRange(duration); formatted != expected {
+			t.Errorf("expected '%s' for %s, but got '%s'", expected, duration, formatted)
+		}
+	}
+
+	// (add identities with permanent and temporary roles)
+	start := time.Now()
+	if created, err := client.CreateIdentityMembership(workspace.ID, infisicaltest.DefaultIdentityID, []RoleAssignment{
+		PermanentRole(RoleViewer),
+		TemporaryRole(RoleAdmin, start, 90*time.Minute),
+	}); err != nil {
+		t.Errorf("failed to create an identity membership: %s", err)
+	} else if roles := created.IdentityMembership.Roles; len(roles) != 2 {
+		t.Errorf("unexpected roles: %+v", roles)
+	} else {
+		if roles[0].Slug() != RoleViewer || roles[0].IsTemporary || !roles[0].ActiveAt(start.Add(365*24*time.Hour)) {
+			t.Errorf("unexpected permanent role: %+v", roles[0])
+		}
+		if roles[1].Slug() != RoleAdmin || !roles[1].IsTemporary || deref(roles[1].TemporaryRange) != "90m" {
+			t.Errorf("unexpected temporary role: %+v", roles[1])
+		}
+		if !roles[1].ActiveAt(start.Add(time.Minute)) || roles[1].ActiveAt(start.Add(2*time.Hour)) {
+			t.Errorf("temporary role should be active only for its duration: %+v", roles[1])
+		}
+	}
+	if _, err := client.CreateIdentityMembership(workspace.ID, workerID, []RoleAssignment{PermanentRole(RoleMember)}); err != nil {
+		t.Errorf("failed to create an identity membership: %s", err)
+	}
+
+	// (list identity memberships)
+	if memberships, err := client.ListIdentityMemberships(workspace.ID, NewParamsListIdentityMemberships().SetLimit(1)); err != nil {
+		t.Errorf("failed to list identity memberships: %s", err)
+	} else if len(memberships.IdentityMemberships) != 1 || memberships.TotalCount != 2 {
+		t.Errorf("unexpected identity memberships: %+v", memberships)
+	}
+	if memberships, err := client.ListIdentityMemberships(workspace.ID, NewParamsListIdentityMemberships().SetIdentityName("payments")); err != nil {
+		t.Errorf("failed to list identity memberships: %s", err)
+	} else if len(memberships.IdentityMemberships) != 1 ||
+		memberships.IdentityMemberships[0].Identity == nil ||
+		memberships.IdentityMemberships[0].Identity.Name != "payments-worker" {
+		t.Errorf("unexpected identity memberships: %+v", memberships)
+	}
+
+	// (get and update an identity membership)
+	if updated, err := client.UpdateIdentityMembership(workspace.ID, workerID, []RoleAssignment{
+		TemporaryRole(RoleViewer, start, 7*24*time.Hour),
+	}); err != nil {
+		t.Errorf("failed to update an identity membership: %s", err)
+	} else if len(updated.Roles) != 1 || deref(updated.Roles[0].TemporaryRange) != "7d" {
+		t.Errorf("unexpected roles: %+v", updated.Roles)
+	}
+	if membership, err := client.GetIdentityMembership(workspace.ID, workerID); err != nil {
+		t.Errorf("failed to get an identity membership: %s", err)
+	} else if membership.IdentityMembership.IdentityID != workerID || len(membership.IdentityMembership.Roles) != 1 {
+		t.Errorf("unexpected identity membership: %+v", membership.IdentityMembership)
+	}
+
+	// (delete an identity membership)
+	if _, err := client.DeleteIdentityMembership(workspace.ID, workerID); err != nil {
+		t.Errorf("failed to delete an identity membership: %s", err)
+	}
+	if _, err := client.GetIdentityMembership(workspace.ID, workerID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted identity membership should not be found: %v", err)
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing roles": func(client *Client) error {
+			_, err := client.CreateIdentityMembership(workspace.ID, workerID, nil)
+			return err
+		},
+		"too short temporary role": func(client *Client) error {
+			_, err := client.CreateIdentityMembership(workspace.ID, workerID, []RoleAssignment{TemporaryRole(RoleAdmin, start, time.Microsecond)})
+			return err
+		},
+		"missing start time": func(client *Client) error {
+			role := TemporaryRole(RoleAdmin, start, time.Hour)
+			role.TemporaryAccessStartTime = nil
+			_, err := client.UpdateIdentityMembership(workspace.ID, workerID, []RoleAssignment{role})
+			return err
+		},
+		"invalid limit": func(client *Client) error {
+			_, err := client.ListIdentityMemberships(workspace.ID, NewParamsListIdentityMemberships().SetLimit(0))
+			return err
+		},
+		"missing identity id": func(client *Client) error {
+			_, err := client.DeleteIdentityMembership(workspace.ID, "")
+			return err
+		},
+	})
+}
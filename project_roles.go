@@ -1,11 +1,259 @@
 package infisical
 
-/*
-TODO:
-* Project Roles
-- [ ] [Create](https://infisical.com/docs/api-reference/endpoints/project-roles/create)
-- [ ] [Update](https://infisical.com/docs/api-reference/endpoints/project-roles/update)
-- [ ] [Delete](https://infisical.com/docs/api-reference/endpoints/project-roles/delete)
-- [ ] [Get By Slug](https://infisical.com/docs/api-reference/endpoints/project-roles/get-by-slug)
-- [ ] [List](https://infisical.com/docs/api-reference/endpoints/project-roles/list)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ProjectRolesData struct for project roles response
+type ProjectRolesData struct {
+	Roles []ProjectRole `json:"roles"`
+}
+
+// ProjectRoleData struct for project role response
+type ProjectRoleData struct {
+	Role ProjectRole `json:"role"`
+}
+
+// ProjectRole struct for a custom role of a project
+type ProjectRole struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Slug        string       `json:"slug"`
+	Description *string      `json:"description,omitempty"`
+	ProjectID   string       `json:"projectId"`
+	Permissions []Permission `json:"permissions"`
+	CreatedAt   string       `json:"createdAt"`
+	UpdatedAt   string       `json:"updatedAt"`
+}
+
+// ParamsCreateProjectRole struct for creating a project role
+type ParamsCreateProjectRole struct {
+	Description *string `json:"description,omitempty"`
+}
+
+// NewParamsCreateProjectRole returns a new params for creating a project role.
+func NewParamsCreateProjectRole() *ParamsCreateProjectRole {
+	return &ParamsCreateProjectRole{}
+}
+
+func (p *ParamsCreateProjectRole) SetDescription(description string) *ParamsCreateProjectRole {
+	p.Description = &description
+	return p
+}
+
+// CreateProjectRole creates a custom role of a project with given permissions (eg. built with `NewPermissionsBuilder`).
+//
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-roles/create
+func (c *Client) CreateProjectRole(projectSlug, slug, name string, permissions []Permission, params *ParamsCreateProjectRole) (result ProjectRoleData, err error) {
+	return c.CreateProjectRoleWithContext(context.Background(), projectSlug, slug, name, permissions, params)
+}
+
+// CreateProjectRoleWithContext is the same as `CreateProjectRole` with given context.
+func (c *Client) CreateProjectRoleWithContext(ctx context.Context, projectSlug, slug, name string, permissions []Permission, params *ParamsCreateProjectRole) (result ProjectRoleData, err error) {
+	if params == nil {
+		params = NewParamsCreateProjectRole()
+	}
+	errs := []error{validateID("project slug", projectSlug), validateSlug("role slug", slug), validatePermissions(permissions)}
+	if name == "" {
+		errs = append(errs, fmt.Errorf("%w: role name is missing", ErrInvalidParams))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return ProjectRoleData{}, fmt.Errorf("failed to create a project role: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		Slug        string       `json:"slug"`
+		Name        string       `json:"name"`
+		Permissions []Permission `json:"permissions"`
+		*ParamsCreateProjectRole
+	}{slug, name, permissions, params}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/workspace/%s/roles", projectSlug), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectRoleData{}, fmt.Errorf("failed to create a project role: %w", err)
+}
+
+// ParamsUpdateProjectRole struct for updating a project role
+type ParamsUpdateProjectRole struct {
+	Slug        *string      `json:"slug,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"` // replaces all permissions of the role
+}
+
+// NewParamsUpdateProjectRole returns a new params for updating a project role.
+func NewParamsUpdateProjectRole() *ParamsUpdateProjectRole {
+	return &ParamsUpdateProjectRole{}
+}
+
+func (p *ParamsUpdateProjectRole) SetSlug(slug string) *ParamsUpdateProjectRole {
+	p.Slug = &slug
+	return p
+}
+
+func (p *ParamsUpdateProjectRole) SetName(name string) *ParamsUpdateProjectRole {
+	p.Name = &name
+	return p
+}
+
+func (p *ParamsUpdateProjectRole) SetDescription(description string) *ParamsUpdateProjectRole {
+	p.Description = &description
+	return p
+}
+
+func (p *ParamsUpdateProjectRole) SetPermissions(permissions []Permission) *ParamsUpdateProjectRole {
+	p.Permissions = permissions
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsUpdateProjectRole) validate() error {
+	if p.Slug == nil && p.Name == nil && p.Description == nil && p.Permissions == nil {
+		return fmt.Errorf("%w: nothing to update", ErrInvalidParams)
+	}
+	errs := []error{}
+	if p.Slug != nil {
+		errs = append(errs, validateSlug("role slug", *p.Slug))
+	}
+	if p.Name != nil && *p.Name == "" {
+		errs = append(errs, fmt.Errorf("%w: role name is empty", ErrInvalidParams))
+	}
+	if p.Permissions != nil {
+		errs = append(errs, validatePermissions(p.Permissions))
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateProjectRole updates a custom role of a project.
+//
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-roles/update
+func (c *Client) UpdateProjectRole(projectSlug, roleID string, params *ParamsUpdateProjectRole) (result ProjectRoleData, err error) {
+	return c.UpdateProjectRoleWithContext(context.Background(), projectSlug, roleID, params)
+}
+
+// UpdateProjectRoleWithContext is the same as `UpdateProjectRole` with given context.
+func (c *Client) UpdateProjectRoleWithContext(ctx context.Context, projectSlug, roleID string, params *ParamsUpdateProjectRole) (result ProjectRoleData, err error) {
+	if params == nil {
+		params = NewParamsUpdateProjectRole()
+	}
+	if err = errors.Join(validateID("project slug", projectSlug), validateID("role id", roleID), params.validate()); err != nil {
+		return ProjectRoleData{}, fmt.Errorf("failed to update a project role: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/workspace/%s/roles/%s", projectSlug, roleID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectRoleData{}, fmt.Errorf("failed to update a project role: %w", err)
+}
+
+// DeleteProjectRole deletes a custom role of a project.
+//
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-roles/delete
+func (c *Client) DeleteProjectRole(projectSlug, roleID string) (result ProjectRoleData, err error) {
+	return c.DeleteProjectRoleWithContext(context.Background(), projectSlug, roleID)
+}
+
+// DeleteProjectRoleWithContext is the same as `DeleteProjectRole` with given context.
+func (c *Client) DeleteProjectRoleWithContext(ctx context.Context, projectSlug, roleID string) (result ProjectRoleData, err error) {
+	if err = errors.Join(validateID("project slug", projectSlug), validateID("role id", roleID)); err != nil {
+		return ProjectRoleData{}, fmt.Errorf("failed to delete a project role: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "DELETE", fmt.Sprintf("/v1/workspace/%s/roles/%s", projectSlug, roleID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectRoleData{}, fmt.Errorf("failed to delete a project role: %w", err)
+}
+
+// GetProjectRoleBySlug retrieves a custom role of a project by its slug.
+//
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-roles/get-by-slug
+func (c *Client) GetProjectRoleBySlug(projectSlug, slug string) (result ProjectRoleData, err error) {
+	return c.GetProjectRoleBySlugWithContext(context.Background(), projectSlug, slug)
+}
+
+// GetProjectRoleBySlugWithContext is the same as `GetProjectRoleBySlug` with given context.
+func (c *Client) GetProjectRoleBySlugWithContext(ctx context.Context, projectSlug, slug string) (result ProjectRoleData, err error) {
+	if err = errors.Join(validateID("project slug", projectSlug), validateSlug("role slug", slug)); err != nil {
+		return ProjectRoleData{}, fmt.Errorf("failed to get a project role: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s/roles/slug/%s", projectSlug, slug), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectRoleData{}, fmt.Errorf("failed to get a project role: %w", err)
+}
+
+// ListProjectRoles lists custom roles of a project.
+//
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-roles/list
+func (c *Client) ListProjectRoles(projectSlug string) (result ProjectRolesData, err error) {
+	return c.ListProjectRolesWithContext(context.Background(), projectSlug)
+}
+
+// ListProjectRolesWithContext is the same as `ListProjectRoles` with given context.
+func (c *Client) ListProjectRolesWithContext(ctx context.Context, projectSlug string) (result ProjectRolesData, err error) {
+	if err = validateID("project slug", projectSlug); err != nil {
+		return ProjectRolesData{}, fmt.Errorf("failed to list project roles: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s/roles", projectSlug), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectRolesData{}, fmt.Errorf("failed to list project roles: %w", err)
+}
@@ -0,0 +1,120 @@
+package infisical
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestProjectRoles(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	target.fakeServer(t)
+	workspace := target.workspace
+
+	client := target.newClient(t)
+
+	permissions, err := NewPermissionsBuilder().
+		Allow(SubjectSecrets, ActionRead).
+		InEnvironments("prod").
+		WithSecretPath("/payments/**").
+		Build()
+	if err != nil {
+		t.Fatalf("failed to build permissions: %s", err)
+	}
+
+	// (create a role)
+	var roleID string
+	if created, err := client.CreateProjectRole(workspace.Slug, "payments-reader", "Payments Reader", permissions, NewParamsCreateProjectRole().
+		SetDescription("reads prod secrets under /payments only"),
+	); err != nil {
+		t.Errorf("failed to create a project role: %s", err)
+	} else if created.Role.Slug != "payments-reader" || created.Role.ProjectID != workspace.ID || deref(created.Role.Description) == "" {
+		t.Errorf("unexpected project role: %+v", created.Role)
+	} else if !reflect.DeepEqual(created.Role.Permissions, permissions) {
+		t.Errorf("permissions were not round-tripped:\n%+v\n%+v", created.Role.Permissions, permissions)
+	} else {
+		roleID = created.Role.ID
+	}
+
+	// (assign the role to a membership)
+	if created, err := client.CreateIdentityMembership(workspace.ID, infisicaltest.DefaultIdentityID, []RoleAssignment{
+		PermanentRole("payments-reader"),
+	}); err != nil {
+		t.Errorf("failed to assign a custom role: %s", err)
+	} else if roles := created.IdentityMembership.Roles; len(roles) != 1 || roles[0].Role != "custom" || roles[0].Slug() != "payments-reader" || deref(roles[0].CustomRoleID) != roleID {
+		t.Errorf("unexpected roles: %+v", roles)
+	}
+
+	// (update a role)
+	if updated, err := client.UpdateProjectRole(workspace.Slug, roleID, NewParamsUpdateProjectRole().
+		SetSlug("payments-editor").
+		SetPermissions(append(permissions, Permission{Subject: SubjectSecrets, Actions: []PermissionAction{ActionEdit}})),
+	); err != nil {
+		t.Errorf("failed to update a project role: %s", err)
+	} else if updated.Role.Slug != "payments-editor" || updated.Role.Name != "Payments Reader" || len(updated.Role.Permissions) != 2 {
+		t.Errorf("unexpected project role: %+v", updated.Role)
+	}
+	if membership, err := client.GetIdentityMembership(workspace.ID, infisicaltest.DefaultIdentityID); err != nil {
+		t.Errorf("failed to get an identity membership: %s", err)
+	} else if roles := membership.IdentityMembership.Roles; len(roles) != 1 || roles[0].Slug() != "payments-editor" {
+		t.Errorf("membership should follow the renamed role: %+v", roles)
+	}
+
+	// (get and list roles)
+	if role, err := client.GetProjectRoleBySlug(workspace.Slug, "payments-editor"); err != nil {
+		t.Errorf("failed to get a project role: %s", err)
+	} else if role.Role.ID != roleID {
+		t.Errorf("unexpected project role: %+v", role.Role)
+	}
+	if roles, err := client.ListProjectRoles(workspace.Slug); err != nil {
+		t.Errorf("failed to list project roles: %s", err)
+	} else if len(roles.Roles) != 1 {
+		t.Errorf("unexpected number of project roles: %d", len(roles.Roles))
+	}
+
+	// (delete a role, after unassigning it)
+	if _, err := client.DeleteProjectRole(workspace.Slug, roleID); err == nil {
+		t.Errorf("assigned role should not be deleted")
+	}
+	if _, err := client.DeleteIdentityMembership(workspace.ID, infisicaltest.DefaultIdentityID); err != nil {
+		t.Errorf("failed to delete an identity membership: %s", err)
+	}
+	if _, err := client.DeleteProjectRole(workspace.Slug, roleID); err != nil {
+		t.Errorf("failed to delete a project role: %s", err)
+	}
+	if _, err := client.GetProjectRoleBySlug(workspace.Slug, "payments-editor"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted role should not be found: %v", err)
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing permissions": func(client *Client) error {
+			_, err := client.CreateProjectRole(workspace.Slug, "reader", "Reader", nil, nil)
+			return err
+		},
+		"invalid permissions": func(client *Client) error {
+			_, err := client.CreateProjectRole(workspace.Slug, "reader", "Reader", []Permission{{Subject: SubjectSecrets}}, nil)
+			return err
+		},
+		"invalid slug": func(client *Client) error {
+			_, err := client.CreateProjectRole(workspace.Slug, "Reader", "Reader", permissions, nil)
+			return err
+		},
+		"nothing to update": func(client *Client) error {
+			_, err := client.UpdateProjectRole(workspace.Slug, roleID, nil)
+			return err
+		},
+		"missing role id": func(client *Client) error {
+			_, err := client.DeleteProjectRole(workspace.Slug, "")
+			return err
+		},
+		"missing project slug": func(client *Client) error {
+			_, err := client.ListProjectRoles("")
+			return err
+		},
+	})
+}
@@ -1,11 +1,402 @@
 package infisical
 
-/*
-TODO:
-* Project Users
-- [ ] [Invite Member](https://infisical.com/docs/api-reference/endpoints/project-users/invite-member-to-workspace)
-- [ ] [Remove Member](https://infisical.com/docs/api-reference/endpoints/project-users/remove-member-from-workspace)
-- [ ] [Get User Memberships](https://infisical.com/docs/api-reference/endpoints/project-users/memberships)
-- [ ] [Get By Username](https://infisical.com/docs/api-reference/endpoints/project-users/get-by-username)
-- [ ] [Update User Membership](https://infisical.com/docs/api-reference/endpoints/project-users/update-membership)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// built-in project roles
+const (
+	RoleAdmin    = "admin"
+	RoleMember   = "member"
+	RoleViewer   = "viewer"
+	RoleNoAccess = "no-access"
+)
+
+// ProjectMembershipsData struct for project memberships response
+type ProjectMembershipsData struct {
+	Memberships []ProjectMembership `json:"memberships"`
+}
+
+// ProjectMembershipData struct for project membership response
+type ProjectMembershipData struct {
+	Membership ProjectMembership `json:"membership"`
+}
+
+// MembershipRolesData struct for membership roles response
+type MembershipRolesData struct {
+	Roles []MembershipRole `json:"roles"`
+}
+
+// ProjectMembership struct for a user's membership of a project
+//
+// (`User` and `Roles` are filled only when listed or retrieved)
+type ProjectMembership struct {
+	ID        string           `json:"id"`
+	UserID    string           `json:"userId"`
+	ProjectID string           `json:"projectId"`
+	User      *ProjectUser     `json:"user,omitempty"`
+	Roles     []MembershipRole `json:"roles,omitempty"`
+	CreatedAt string           `json:"createdAt"`
+	UpdatedAt string           `json:"updatedAt"`
+}
+
+// ProjectUser struct for a user of a project membership
+type ProjectUser struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// MembershipRole struct for a role assigned to a membership
+type MembershipRole struct {
+	ID             string  `json:"id"`
+	Role           string  `json:"role"` // slug of a built-in role, or "custom"
+	CustomRoleID   *string `json:"customRoleId,omitempty"`
+	CustomRoleName *string `json:"customRoleName,omitempty"`
+	CustomRoleSlug *string `json:"customRoleSlug,omitempty"`
+
+	IsTemporary              bool    `json:"isTemporary"`
+	TemporaryMode            *string `json:"temporaryMode,omitempty"`
+	TemporaryRange           *string `json:"temporaryRange,omitempty"`
+	TemporaryAccessStartTime *string `json:"temporaryAccessStartTime,omitempty"`
+	TemporaryAccessEndTime   *string `json:"temporaryAccessEndTime,omitempty"`
+}
+
+// Slug returns the slug of the role (of custom one if it is).
+func (r MembershipRole) Slug() string {
+	if r.CustomRoleSlug != nil && *r.CustomRoleSlug != "" {
+		return *r.CustomRoleSlug
+	}
+	return r.Role
+}
+
+// ActiveAt returns whether the role is in effect at given time (temporary ones only between their start and end times).
+func (r MembershipRole) ActiveAt(t time.Time) bool {
+	if !r.IsTemporary {
+		return true
+	}
+	start, err := time.Parse(time.RFC3339Nano, deref(r.TemporaryAccessStartTime))
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(time.RFC3339Nano, deref(r.TemporaryAccessEndTime))
+	if err != nil {
+		return false
+	}
+	return !t.Before(start) && t.Before(end)
+}
+
+// RoleAssignment struct for assigning a role to a membership
+type RoleAssignment struct {
+	Role        string `json:"role"` // slug of a built-in or custom role
+	IsTemporary bool   `json:"isTemporary"`
+
+	// for temporary roles only
+	TemporaryMode            *string `json:"temporaryMode,omitempty"`
+	TemporaryRange           *string `json:"temporaryRange,omitempty"` // eg. "90m", "1h", "7d"
+	TemporaryAccessStartTime *string `json:"temporaryAccessStartTime,omitempty"`
+}
+
+// PermanentRole returns a role assignment without expiration.
+func PermanentRole(slug string) RoleAssignment {
+	return RoleAssignment{Role: slug}
+}
+
+// TemporaryRole returns a role assignment which is in effect for `duration` from `start`.
+//
+// (`duration` is sent in milliseconds precision)
+func TemporaryRole(slug string, start time.Time, duration time.Duration) RoleAssignment {
+	return RoleAssignment{
+		Role:                     slug,
+		IsTemporary:              true,
+		TemporaryMode:            ptr("relative"),
+		TemporaryRange:           ptr(formatTemporaryRange(duration)),
+		TemporaryAccessStartTime: ptr(start.UTC().Format(time.RFC3339Nano)),
+	}
+}
+
+// formatTemporaryRange formats given duration with its largest unit, eg. 90 * time.Minute => "90m", 48 * time.Hour => "2d".
+//
+// (returns "" for durations shorter than a millisecond)
+func formatTemporaryRange(duration time.Duration) string {
+	for _, unit := range []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+	} {
+		if duration >= unit.duration && duration%unit.duration == 0 {
+			return fmt.Sprintf("%d%s", duration/unit.duration, unit.suffix)
+		}
+	}
+	if duration >= time.Millisecond {
+		return fmt.Sprintf("%dms", duration/time.Millisecond)
+	}
+	return ""
+}
+
+// validateRoleAssignments checks given role assignments.
+func validateRoleAssignments(roles []RoleAssignment) error {
+	if len(roles) == 0 {
+		return fmt.Errorf("%w: roles are missing", ErrInvalidParams)
+	}
+	errs := []error{}
+	for _, role := range roles {
+		errs = append(errs, validateSlug("role", role.Role))
+		if role.IsTemporary {
+			if deref(role.TemporaryRange) == "" {
+				errs = append(errs, fmt.Errorf("%w: duration of temporary role '%s' is missing or too short", ErrInvalidParams, role.Role))
+			}
+			if _, err := time.Parse(time.RFC3339Nano, deref(role.TemporaryAccessStartTime)); err != nil {
+				errs = append(errs, fmt.Errorf("%w: start time of temporary role '%s' is invalid: %s", ErrInvalidParams, role.Role, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ParamsInviteProjectMembers struct for inviting users to a project
+type ParamsInviteProjectMembers struct {
+	Emails    []string `json:"emails,omitempty"`
+	Usernames []string `json:"usernames,omitempty"`
+	RoleSlugs []string `json:"roleSlugs,omitempty"`
+}
+
+// NewParamsInviteProjectMembers returns a new params for inviting users to a project as members.
+func NewParamsInviteProjectMembers() *ParamsInviteProjectMembers {
+	return &ParamsInviteProjectMembers{
+		RoleSlugs: []string{RoleMember},
+	}
+}
+
+func (p *ParamsInviteProjectMembers) SetEmails(emails ...string) *ParamsInviteProjectMembers {
+	p.Emails = emails
+	return p
+}
+
+func (p *ParamsInviteProjectMembers) SetUsernames(usernames ...string) *ParamsInviteProjectMembers {
+	p.Usernames = usernames
+	return p
+}
+
+func (p *ParamsInviteProjectMembers) SetRoleSlugs(slugs ...string) *ParamsInviteProjectMembers {
+	p.RoleSlugs = slugs
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsInviteProjectMembers) validate() error {
+	errs := []error{validateUsers(p.Emails, p.Usernames)}
+	for _, slug := range p.RoleSlugs {
+		errs = append(errs, validateSlug("role", slug))
+	}
+	return errors.Join(errs...)
+}
+
+// validateUsers checks given emails and usernames of users.
+func validateUsers(emails, usernames []string) error {
+	if len(emails) == 0 && len(usernames) == 0 {
+		return fmt.Errorf("%w: emails or usernames are missing", ErrInvalidParams)
+	}
+	errs := []error{}
+	for _, email := range emails {
+		if email == "" {
+			errs = append(errs, fmt.Errorf("%w: email is empty", ErrInvalidParams))
+		}
+	}
+	for _, username := range usernames {
+		if username == "" {
+			errs = append(errs, fmt.Errorf("%w: username is empty", ErrInvalidParams))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// InviteProjectMembers invites users of the organization to a project with given roles.
+//
+// Users who are already members of the project are skipped.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-users/invite-member-to-workspace
+func (c *Client) InviteProjectMembers(workspaceID string, params *ParamsInviteProjectMembers) (result ProjectMembershipsData, err error) {
+	return c.InviteProjectMembersWithContext(context.Background(), workspaceID, params)
+}
+
+// InviteProjectMembersWithContext is the same as `InviteProjectMembers` with given context.
+func (c *Client) InviteProjectMembersWithContext(ctx context.Context, workspaceID string, params *ParamsInviteProjectMembers) (result ProjectMembershipsData, err error) {
+	if params == nil {
+		params = NewParamsInviteProjectMembers()
+	}
+	if err = errors.Join(validateID("workspace id", workspaceID), params.validate()); err != nil {
+		return ProjectMembershipsData{}, fmt.Errorf("failed to invite members: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v2/workspace/%s/memberships", workspaceID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectMembershipsData{}, fmt.Errorf("failed to invite members: %w", err)
+}
+
+// ParamsRemoveProjectMembers struct for removing users from a project
+type ParamsRemoveProjectMembers struct {
+	Emails    []string `json:"emails,omitempty"`
+	Usernames []string `json:"usernames,omitempty"`
+}
+
+// NewParamsRemoveProjectMembers returns a new params for removing users from a project.
+func NewParamsRemoveProjectMembers() *ParamsRemoveProjectMembers {
+	return &ParamsRemoveProjectMembers{}
+}
+
+func (p *ParamsRemoveProjectMembers) SetEmails(emails ...string) *ParamsRemoveProjectMembers {
+	p.Emails = emails
+	return p
+}
+
+func (p *ParamsRemoveProjectMembers) SetUsernames(usernames ...string) *ParamsRemoveProjectMembers {
+	p.Usernames = usernames
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsRemoveProjectMembers) validate() error {
+	return validateUsers(p.Emails, p.Usernames)
+}
+
+// RemoveProjectMembers removes users from a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-users/remove-member-from-workspace
+func (c *Client) RemoveProjectMembers(workspaceID string, params *ParamsRemoveProjectMembers) (result ProjectMembershipsData, err error) {
+	return c.RemoveProjectMembersWithContext(context.Background(), workspaceID, params)
+}
+
+// RemoveProjectMembersWithContext is the same as `RemoveProjectMembers` with given context.
+func (c *Client) RemoveProjectMembersWithContext(ctx context.Context, workspaceID string, params *ParamsRemoveProjectMembers) (result ProjectMembershipsData, err error) {
+	if params == nil {
+		params = NewParamsRemoveProjectMembers()
+	}
+	if err = errors.Join(validateID("workspace id", workspaceID), params.validate()); err != nil {
+		return ProjectMembershipsData{}, fmt.Errorf("failed to remove members: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "DELETE", fmt.Sprintf("/v2/workspace/%s/memberships", workspaceID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectMembershipsData{}, fmt.Errorf("failed to remove members: %w", err)
+}
+
+// ListProjectMemberships lists users' memberships of a project, with their users and roles.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-users/memberships
+func (c *Client) ListProjectMemberships(workspaceID string) (result ProjectMembershipsData, err error) {
+	return c.ListProjectMembershipsWithContext(context.Background(), workspaceID)
+}
+
+// ListProjectMembershipsWithContext is the same as `ListProjectMemberships` with given context.
+func (c *Client) ListProjectMembershipsWithContext(ctx context.Context, workspaceID string) (result ProjectMembershipsData, err error) {
+	if err = validateID("workspace id", workspaceID); err != nil {
+		return ProjectMembershipsData{}, fmt.Errorf("failed to list memberships: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s/memberships", workspaceID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectMembershipsData{}, fmt.Errorf("failed to list memberships: %w", err)
+}
+
+// GetProjectMembershipByUsername retrieves a user's membership of a project by the user's username.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-users/get-by-username
+func (c *Client) GetProjectMembershipByUsername(workspaceID, username string) (result ProjectMembershipData, err error) {
+	return c.GetProjectMembershipByUsernameWithContext(context.Background(), workspaceID, username)
+}
+
+// GetProjectMembershipByUsernameWithContext is the same as `GetProjectMembershipByUsername` with given context.
+func (c *Client) GetProjectMembershipByUsernameWithContext(ctx context.Context, workspaceID, username string) (result ProjectMembershipData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateUsers(nil, []string{username})); err != nil {
+		return ProjectMembershipData{}, fmt.Errorf("failed to get a membership: %w", err)
+	}
+
+	// essential params
+	body := struct {
+		Username string `json:"username"`
+	}{username}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/workspace/%s/memberships/details", workspaceID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectMembershipData{}, fmt.Errorf("failed to get a membership: %w", err)
+}
+
+// UpdateProjectMembership replaces roles of a user's membership of a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/project-users/update-membership
+func (c *Client) UpdateProjectMembership(workspaceID, membershipID string, roles []RoleAssignment) (result MembershipRolesData, err error) {
+	return c.UpdateProjectMembershipWithContext(context.Background(), workspaceID, membershipID, roles)
+}
+
+// UpdateProjectMembershipWithContext is the same as `UpdateProjectMembership` with given context.
+func (c *Client) UpdateProjectMembershipWithContext(ctx context.Context, workspaceID, membershipID string, roles []RoleAssignment) (result MembershipRolesData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("membership id", membershipID), validateRoleAssignments(roles)); err != nil {
+		return MembershipRolesData{}, fmt.Errorf("failed to update a membership: %w", err)
+	}
+
+	// essential params
+	body := struct {
+		Roles []RoleAssignment `json:"roles"`
+	}{roles}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/workspace/%s/memberships/%s", workspaceID, membershipID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return MembershipRolesData{}, fmt.Errorf("failed to update a membership: %w", err)
+}
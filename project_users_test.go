@@ -0,0 +1,113 @@
+package infisical
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestProjectUsers(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+	other := server.AddWorkspace("other-workspace", "dev")
+	server.AddUser("alice@example.com", "alice")
+	server.AddUser("bob@example.com", "bob")
+	server.AddUser("carol@example.com", "carol")
+
+	client := target.newClient(t)
+
+	// (invite users to multiple projects with multiple roles)
+	for _, workspaceID := range []string{workspace.ID, other.ID} {
+		if invited, err := client.InviteProjectMembers(workspaceID, NewParamsInviteProjectMembers().
+			SetEmails("alice@example.com", "bob@example.com").
+			SetUsernames("carol").
+			SetRoleSlugs(RoleMember, RoleViewer),
+		); err != nil {
+			t.Errorf("failed to invite members: %s", err)
+		} else if len(invited.Memberships) != 3 || invited.Memberships[0].ProjectID != workspaceID {
+			t.Errorf("unexpected memberships: %+v", invited.Memberships)
+		}
+	}
+
+	// (existing members are skipped)
+	if invited, err := client.InviteProjectMembers(workspace.ID, NewParamsInviteProjectMembers().SetUsernames("alice")); err != nil {
+		t.Errorf("failed to invite members: %s", err)
+	} else if len(invited.Memberships) != 0 {
+		t.Errorf("existing members should be skipped: %+v", invited.Memberships)
+	}
+
+	// (users not in the organization)
+	var apiErr *APIError
+	if _, err := client.InviteProjectMembers(workspace.ID, NewParamsInviteProjectMembers().SetEmails("mallory@example.com")); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("inviting unknown users should fail: %v", err)
+	}
+
+	// (list memberships with users and roles)
+	if memberships, err := client.ListProjectMemberships(workspace.ID); err != nil {
+		t.Errorf("failed to list memberships: %s", err)
+	} else if len(memberships.Memberships) != 3 {
+		t.Errorf("unexpected number of memberships: %d", len(memberships.Memberships))
+	} else if m := memberships.Memberships[0]; m.User == nil || m.User.Email != "alice@example.com" ||
+		len(m.Roles) != 2 || m.Roles[0].Slug() != RoleMember || m.Roles[1].Slug() != RoleViewer {
+		t.Errorf("unexpected membership: %+v", m)
+	}
+
+	// (get a membership by username, and update its roles)
+	if membership, err := client.GetProjectMembershipByUsername(workspace.ID, "bob"); err != nil {
+		t.Errorf("failed to get a membership: %s", err)
+	} else if membership.Membership.User == nil || membership.Membership.User.Username != "bob" {
+		t.Errorf("unexpected membership: %+v", membership.Membership)
+	} else if updated, err := client.UpdateProjectMembership(workspace.ID, membership.Membership.ID, []RoleAssignment{
+		PermanentRole(RoleAdmin),
+	}); err != nil {
+		t.Errorf("failed to update a membership: %s", err)
+	} else if len(updated.Roles) != 1 || updated.Roles[0].Role != RoleAdmin || updated.Roles[0].IsTemporary {
+		t.Errorf("unexpected roles: %+v", updated.Roles)
+	}
+
+	// (remove members)
+	if removed, err := client.RemoveProjectMembers(workspace.ID, NewParamsRemoveProjectMembers().
+		SetEmails("alice@example.com").
+		SetUsernames("carol"),
+	); err != nil {
+		t.Errorf("failed to remove members: %s", err)
+	} else if len(removed.Memberships) != 2 {
+		t.Errorf("unexpected removed memberships: %+v", removed.Memberships)
+	}
+	if _, err := client.GetProjectMembershipByUsername(workspace.ID, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("removed membership should not be found: %v", err)
+	}
+	if _, err := client.GetProjectMembershipByUsername(other.ID, "alice"); err != nil {
+		t.Errorf("memberships of other projects should not be removed: %s", err)
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing users": func(client *Client) error {
+			_, err := client.InviteProjectMembers(workspace.ID, nil)
+			return err
+		},
+		"invalid role slug": func(client *Client) error {
+			_, err := client.InviteProjectMembers(workspace.ID, NewParamsInviteProjectMembers().SetUsernames("bob").SetRoleSlugs("Admin"))
+			return err
+		},
+		"empty email": func(client *Client) error {
+			_, err := client.RemoveProjectMembers(workspace.ID, NewParamsRemoveProjectMembers().SetEmails(""))
+			return err
+		},
+		"missing username": func(client *Client) error {
+			_, err := client.GetProjectMembershipByUsername(workspace.ID, "")
+			return err
+		},
+		"missing roles": func(client *Client) error {
+			_, err := client.UpdateProjectMembership(workspace.ID, "membership-id", nil)
+			return err
+		},
+		"missing workspace id": func(client *Client) error {
+			_, err := client.ListProjectMemberships("")
+			return err
+		},
+	})
+}
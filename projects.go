@@ -1,12 +1,679 @@
 package infisical
 
-/*
-TODO:
-* Projects
-- [ ] [Create Project](https://infisical.com/docs/api-reference/endpoints/workspaces/create-workspace)
-- [ ] [Delete Project](https://infisical.com/docs/api-reference/endpoints/workspaces/delete-workspace)
-- [ ] [Get Project](https://infisical.com/docs/api-reference/endpoints/workspaces/get-workspace)
-- [ ] [Update Project](https://infisical.com/docs/api-reference/endpoints/workspaces/update-workspace)
-- [ ] [Get Snapshots](https://infisical.com/docs/api-reference/endpoints/workspaces/secret-snapshots)
-- [ ] [Roll Back to Snapshot](https://infisical.com/docs/api-reference/endpoints/workspaces/rollback-snapshot)
-*/
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// ProjectData struct for project response
+type ProjectData struct {
+	Workspace Workspace `json:"workspace"`
+}
+
+// ParamsCreateProject struct for creating a project
+type ParamsCreateProject struct {
+	Slug               *string `json:"slug,omitempty"`
+	ProjectDescription *string `json:"projectDescription,omitempty"`
+
+	Environments []WorkspaceEnvironment `json:"-"` // initial environments (in order) replacing the default ones
+}
+
+// NewParamsCreateProject returns a new params for creating a project.
+func NewParamsCreateProject() *ParamsCreateProject {
+	return &ParamsCreateProject{}
+}
+
+func (p *ParamsCreateProject) SetSlug(slug string) *ParamsCreateProject {
+	p.Slug = &slug
+	return p
+}
+
+func (p *ParamsCreateProject) SetDescription(description string) *ParamsCreateProject {
+	p.ProjectDescription = &description
+	return p
+}
+
+func (p *ParamsCreateProject) SetEnvironments(environments ...WorkspaceEnvironment) *ParamsCreateProject {
+	p.Environments = environments
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsCreateProject) validate() error {
+	errs := []error{}
+	if p.Slug != nil {
+		errs = append(errs, validateSlug("project slug", *p.Slug))
+	}
+	slugs := map[string]bool{}
+	for _, env := range p.Environments {
+		if env.Name == "" {
+			errs = append(errs, fmt.Errorf("%w: environment name is missing", ErrInvalidParams))
+		}
+		if slugs[env.Slug] {
+			errs = append(errs, fmt.Errorf("%w: environment slug '%s' is duplicated", ErrInvalidParams, env.Slug))
+		}
+		slugs[env.Slug] = true
+		errs = append(errs, validateSlug("environment slug", env.Slug))
+	}
+	return errors.Join(errs...)
+}
+
+// CreateProject creates a new project with given name.
+//
+// When initial environments are given in `params`, they replace the default ones (eg. "dev", "staging", and "prod")
+// with additional requests. If any of them fails, the created project is returned with the error.
+//
+// https://infisical.com/docs/api-reference/endpoints/workspaces/create-workspace
+func (c *Client) CreateProject(name string, params *ParamsCreateProject) (result ProjectData, err error) {
+	return c.CreateProjectWithContext(context.Background(), name, params)
+}
+
+// CreateProjectWithContext is the same as `CreateProject` with given context.
+func (c *Client) CreateProjectWithContext(ctx context.Context, name string, params *ParamsCreateProject) (result ProjectData, err error) {
+	if params == nil {
+		params = NewParamsCreateProject()
+	}
+	errs := []error{params.validate()}
+	if name == "" {
+		errs = append(errs, fmt.Errorf("%w: project name is missing", ErrInvalidParams))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return ProjectData{}, fmt.Errorf("failed to create a project: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		ProjectName string `json:"projectName"`
+		*ParamsCreateProject
+	}{name, params}
+
+	var created struct {
+		Project Workspace `json:"project"`
+	}
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", "/v2/workspace", AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &created); err == nil {
+				result = ProjectData{Workspace: created.Project}
+
+				if len(params.Environments) > 0 {
+					if result, err = c.replaceEnvironments(ctx, result.Workspace, params.Environments); err != nil {
+						return result, fmt.Errorf("failed to set up environments of the created project '%s': %w", created.Project.ID, err)
+					}
+				}
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectData{}, fmt.Errorf("failed to create a project: %w", err)
+}
+
+// replaceEnvironments replaces environments of given workspace with given ones (in order).
+func (c *Client) replaceEnvironments(ctx context.Context, workspace Workspace, environments []WorkspaceEnvironment) (result ProjectData, err error) {
+	result = ProjectData{Workspace: workspace}
+
+	existing := map[string]bool{}
+	for _, env := range workspace.Environments {
+		existing[env.Slug] = true
+	}
+	wanted := map[string]bool{}
+	for _, env := range environments {
+		wanted[env.Slug] = true
+	}
+
+	// create missing environments,
+	for _, env := range environments {
+		if !existing[env.Slug] {
+			if _, err = c.CreateEnvironmentWithContext(ctx, workspace.ID, env.Name, env.Slug); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	// delete unwanted ones,
+	for _, env := range workspace.Environments {
+		if !wanted[env.Slug] {
+			if _, err = c.DeleteEnvironmentWithContext(ctx, workspace.ID, env.ID); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	// and reorder them
+	if result, err = c.GetProjectWithContext(ctx, workspace.ID); err != nil {
+		return ProjectData{Workspace: workspace}, err
+	}
+	for i, env := range environments {
+		for _, current := range result.Workspace.Environments {
+			if current.Slug == env.Slug && current.Position != i+1 {
+				var updated EnvironmentData
+				if updated, err = c.UpdateEnvironmentWithContext(ctx, workspace.ID, current.ID, NewParamsUpdateEnvironment().SetPosition(i+1)); err != nil {
+					return result, err
+				}
+				result.Workspace.Environments = updated.Workspace.Environments
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetProject retrieves a project with given id.
+//
+// https://infisical.com/docs/api-reference/endpoints/workspaces/get-workspace
+func (c *Client) GetProject(workspaceID string) (result ProjectData, err error) {
+	return c.GetProjectWithContext(context.Background(), workspaceID)
+}
+
+// GetProjectWithContext is the same as `GetProject` with given context.
+func (c *Client) GetProjectWithContext(ctx context.Context, workspaceID string) (result ProjectData, err error) {
+	if err = validateID("workspace id", workspaceID); err != nil {
+		return ProjectData{}, fmt.Errorf("failed to get a project: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s", workspaceID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectData{}, fmt.Errorf("failed to get a project: %w", err)
+}
+
+// ParamsUpdateProject struct for updating a project
+type ParamsUpdateProject struct {
+	Name               *string `json:"name,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	AutoCapitalization *bool   `json:"autoCapitalization,omitempty"`
+}
+
+// NewParamsUpdateProject returns a new params for updating a project.
+func NewParamsUpdateProject() *ParamsUpdateProject {
+	return &ParamsUpdateProject{}
+}
+
+func (p *ParamsUpdateProject) SetName(name string) *ParamsUpdateProject {
+	p.Name = &name
+	return p
+}
+
+func (p *ParamsUpdateProject) SetDescription(description string) *ParamsUpdateProject {
+	p.Description = &description
+	return p
+}
+
+func (p *ParamsUpdateProject) SetAutoCapitalization(autoCapitalization bool) *ParamsUpdateProject {
+	p.AutoCapitalization = &autoCapitalization
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsUpdateProject) validate() error {
+	if p.Name == nil && p.Description == nil && p.AutoCapitalization == nil {
+		return fmt.Errorf("%w: nothing to update", ErrInvalidParams)
+	}
+	if p.Name != nil && *p.Name == "" {
+		return fmt.Errorf("%w: project name is empty", ErrInvalidParams)
+	}
+	return nil
+}
+
+// UpdateProject updates the name, description, or settings (eg. auto-capitalization of secret keys) of a project.
+//
+// https://infisical.com/docs/api-reference/endpoints/workspaces/update-workspace
+func (c *Client) UpdateProject(workspaceID string, params *ParamsUpdateProject) (result ProjectData, err error) {
+	return c.UpdateProjectWithContext(context.Background(), workspaceID, params)
+}
+
+// UpdateProjectWithContext is the same as `UpdateProject` with given context.
+func (c *Client) UpdateProjectWithContext(ctx context.Context, workspaceID string, params *ParamsUpdateProject) (result ProjectData, err error) {
+	if params == nil {
+		params = NewParamsUpdateProject()
+	}
+	if err = errors.Join(validateID("workspace id", workspaceID), params.validate()); err != nil {
+		return ProjectData{}, fmt.Errorf("failed to update a project: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/workspace/%s", workspaceID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectData{}, fmt.Errorf("failed to update a project: %w", err)
+}
+
+// DeleteProject deletes a project with given id.
+//
+// https://infisical.com/docs/api-reference/endpoints/workspaces/delete-workspace
+func (c *Client) DeleteProject(workspaceID string) (result ProjectData, err error) {
+	return c.DeleteProjectWithContext(context.Background(), workspaceID)
+}
+
+// DeleteProjectWithContext is the same as `DeleteProject` with given context.
+func (c *Client) DeleteProjectWithContext(ctx context.Context, workspaceID string) (result ProjectData, err error) {
+	if err = validateID("workspace id", workspaceID); err != nil {
+		return ProjectData{}, fmt.Errorf("failed to delete a project: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "DELETE", fmt.Sprintf("/v1/workspace/%s", workspaceID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				c.invalidateWorkspaceCache(workspaceID)
+				c.invalidateOfflineWorkspace(ctx, workspaceID)
+				return result, nil
+			}
+		}
+	}
+
+	return ProjectData{}, fmt.Errorf("failed to delete a project: %w", err)
+}
+
+// SnapshotsData struct for secret snapshots response
+type SnapshotsData struct {
+	SecretSnapshots []Snapshot `json:"secretSnapshots"`
+}
+
+// SnapshotData struct for secret snapshot response
+type SnapshotData struct {
+	SecretSnapshot Snapshot `json:"secretSnapshot"`
+}
+
+// Snapshot struct for a secret snapshot of a folder
+//
+// (`Environment`, `SecretVersions`, and `FolderVersion` are filled only when retrieved one by one)
+type Snapshot struct {
+	ID            string `json:"id"`
+	ProjectID     string `json:"projectId,omitempty"`
+	EnvironmentID string `json:"envId,omitempty"`
+	FolderID      string `json:"folderId,omitempty"`
+	CreatedAt     string `json:"createdAt"`
+	UpdatedAt     string `json:"updatedAt,omitempty"`
+
+	Environment    *WorkspaceEnvironment `json:"environment,omitempty"`
+	SecretVersions []Secret              `json:"secretVersions,omitempty"`
+	FolderVersion  []SnapshotFolder      `json:"folderVersion,omitempty"`
+}
+
+// SnapshotFolder struct for a folder in a secret snapshot
+type SnapshotFolder struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DefaultSnapshotsLimit is the default number of snapshots in a page.
+const DefaultSnapshotsLimit = 20
+
+// ParamsListSnapshots struct for listing secret snapshots
+type ParamsListSnapshots struct {
+	Path   *string `json:"path,omitempty"`
+	Offset *int    `json:"offset,omitempty"`
+	Limit  *int    `json:"limit,omitempty"`
+}
+
+// NewParamsListSnapshots returns a new params for listing the first page of secret snapshots at the root path.
+func NewParamsListSnapshots() *ParamsListSnapshots {
+	return &ParamsListSnapshots{
+		Path:   ptr("/"),
+		Offset: ptr(0),
+		Limit:  ptr(DefaultSnapshotsLimit),
+	}
+}
+
+func (p *ParamsListSnapshots) SetPath(path string) *ParamsListSnapshots {
+	p.Path = &path
+	return p
+}
+
+func (p *ParamsListSnapshots) SetOffset(offset int) *ParamsListSnapshots {
+	p.Offset = &offset
+	return p
+}
+
+func (p *ParamsListSnapshots) SetLimit(limit int) *ParamsListSnapshots {
+	p.Limit = &limit
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsListSnapshots) validate() error {
+	errs := []error{validatePath(p.Path)}
+	if p.Offset != nil && *p.Offset < 0 {
+		errs = append(errs, fmt.Errorf("%w: offset should not be negative: %d", ErrInvalidParams, *p.Offset))
+	}
+	if p.Limit != nil && *p.Limit < 1 {
+		errs = append(errs, fmt.Errorf("%w: limit should be 1 or greater: %d", ErrInvalidParams, *p.Limit))
+	}
+	return errors.Join(errs...)
+}
+
+// ListSnapshots lists a page of secret snapshots of a folder, from the newest one.
+//
+// https://infisical.com/docs/api-reference/endpoints/workspaces/secret-snapshots
+func (c *Client) ListSnapshots(workspaceID, environment string, params *ParamsListSnapshots) (result SnapshotsData, err error) {
+	return c.ListSnapshotsWithContext(context.Background(), workspaceID, environment, params)
+}
+
+// ListSnapshotsWithContext is the same as `ListSnapshots` with given context.
+func (c *Client) ListSnapshotsWithContext(ctx context.Context, workspaceID, environment string, params *ParamsListSnapshots) (result SnapshotsData, err error) {
+	if params == nil {
+		params = NewParamsListSnapshots()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), params.validate()); err != nil {
+		return SnapshotsData{}, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	// essential parameters
+	query := struct {
+		Environment string `json:"environment"`
+		*ParamsListSnapshots
+	}{environment, params}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s/secret-snapshots", workspaceID), AuthMethodNormal, query)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return SnapshotsData{}, fmt.Errorf("failed to list snapshots: %w", err)
+}
+
+// ListAllSnapshots lists all secret snapshots of a folder, from the newest one, paging through them.
+//
+// `Offset` and `Limit` of `params` are used as the first offset and the size of each page.
+//
+// Just a helper function for `ListSnapshots`.
+func (c *Client) ListAllSnapshots(workspaceID, environment string, params *ParamsListSnapshots) (snapshots []Snapshot, err error) {
+	return c.ListAllSnapshotsWithContext(context.Background(), workspaceID, environment, params)
+}
+
+// ListAllSnapshotsWithContext is the same as `ListAllSnapshots` with given context.
+func (c *Client) ListAllSnapshotsWithContext(ctx context.Context, workspaceID, environment string, params *ParamsListSnapshots) (snapshots []Snapshot, err error) {
+	page := NewParamsListSnapshots()
+	if params != nil {
+		page.Path = params.Path
+		if params.Offset != nil {
+			page.Offset = ptr(*params.Offset)
+		}
+		if params.Limit != nil {
+			page.Limit = ptr(*params.Limit)
+		}
+	}
+
+	snapshots = []Snapshot{}
+	for {
+		var result SnapshotsData
+		if result, err = c.ListSnapshotsWithContext(ctx, workspaceID, environment, page); err != nil {
+			return snapshots, err
+		}
+		snapshots = append(snapshots, result.SecretSnapshots...)
+
+		if len(result.SecretSnapshots) < *page.Limit {
+			return snapshots, nil
+		}
+		page.Offset = ptr(*page.Offset + len(result.SecretSnapshots))
+	}
+}
+
+// ParamsCountSnapshots struct for counting secret snapshots
+type ParamsCountSnapshots struct {
+	Path *string `json:"path,omitempty"`
+}
+
+// NewParamsCountSnapshots returns a new params for counting secret snapshots at the root path.
+func NewParamsCountSnapshots() *ParamsCountSnapshots {
+	return &ParamsCountSnapshots{
+		Path: ptr("/"),
+	}
+}
+
+func (p *ParamsCountSnapshots) SetPath(path string) *ParamsCountSnapshots {
+	p.Path = &path
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsCountSnapshots) validate() error {
+	return validatePath(p.Path)
+}
+
+// CountSnapshots counts secret snapshots of a folder.
+func (c *Client) CountSnapshots(workspaceID, environment string, params *ParamsCountSnapshots) (count int, err error) {
+	return c.CountSnapshotsWithContext(context.Background(), workspaceID, environment, params)
+}
+
+// CountSnapshotsWithContext is the same as `CountSnapshots` with given context.
+func (c *Client) CountSnapshotsWithContext(ctx context.Context, workspaceID, environment string, params *ParamsCountSnapshots) (count int, err error) {
+	if params == nil {
+		params = NewParamsCountSnapshots()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), params.validate()); err != nil {
+		return 0, fmt.Errorf("failed to count snapshots: %w", err)
+	}
+
+	// essential parameters
+	query := struct {
+		Environment string `json:"environment"`
+		*ParamsCountSnapshots
+	}{environment, params}
+
+	var result struct {
+		Count int `json:"count"`
+	}
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s/secret-snapshots/count", workspaceID), AuthMethodNormal, query)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result.Count, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("failed to count snapshots: %w", err)
+}
+
+// GetSnapshot retrieves a secret snapshot with its secrets and folders.
+func (c *Client) GetSnapshot(snapshotID string) (result SnapshotData, err error) {
+	return c.GetSnapshotWithContext(context.Background(), snapshotID)
+}
+
+// GetSnapshotWithContext is the same as `GetSnapshot` with given context.
+func (c *Client) GetSnapshotWithContext(ctx context.Context, snapshotID string) (result SnapshotData, err error) {
+	if err = validateID("snapshot id", snapshotID); err != nil {
+		return SnapshotData{}, fmt.Errorf("failed to get a snapshot: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/secret-snapshot/%s", snapshotID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return SnapshotData{}, fmt.Errorf("failed to get a snapshot: %w", err)
+}
+
+// RollbackToSnapshot rolls back secrets of a folder to a secret snapshot.
+//
+// https://infisical.com/docs/api-reference/endpoints/workspaces/rollback-snapshot
+func (c *Client) RollbackToSnapshot(snapshotID string) (result SnapshotData, err error) {
+	return c.RollbackToSnapshotWithContext(context.Background(), snapshotID)
+}
+
+// RollbackToSnapshotWithContext is the same as `RollbackToSnapshot` with given context.
+func (c *Client) RollbackToSnapshotWithContext(ctx context.Context, snapshotID string) (result SnapshotData, err error) {
+	if err = validateID("snapshot id", snapshotID); err != nil {
+		return SnapshotData{}, fmt.Errorf("failed to roll back to a snapshot: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/secret-snapshot/%s/rollback", snapshotID), AuthMethodNormal, struct{}{})
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				// (path of the snapshot is unknown, so drop all cached secrets of the workspace)
+				if result.SecretSnapshot.ProjectID != "" {
+					c.invalidateWorkspaceCache(result.SecretSnapshot.ProjectID)
+				} else {
+					c.ClearSecretCache()
+				}
+				c.invalidateOfflineWorkspace(ctx, result.SecretSnapshot.ProjectID)
+				return result, nil
+			}
+		}
+	}
+
+	return SnapshotData{}, fmt.Errorf("failed to roll back to a snapshot: %w", err)
+}
+
+// SecretChange struct for a changed secret between snapshots
+type SecretChange struct {
+	SecretKey string
+	Type      SecretType
+
+	Before *Secret // nil if added
+	After  *Secret // nil if removed
+}
+
+// SnapshotDiff struct for differences of secrets between snapshots (or a snapshot and current secrets)
+type SnapshotDiff struct {
+	Added    []SecretChange
+	Removed  []SecretChange
+	Modified []SecretChange // changed values or comments
+}
+
+// Empty returns whether there is no difference.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffSecrets compares secrets of `before` and `after` by their types and keys, sorted by keys.
+//
+// eg. `DiffSecrets(older.SecretVersions, newer.SecretVersions)`
+func DiffSecrets(before, after []Secret) (diff SnapshotDiff) {
+	type key struct {
+		typ       SecretType
+		secretKey string
+	}
+	keyOf := func(secret Secret) key {
+		if secret.Type == "" {
+			return key{SecretTypeShared, secret.SecretKey}
+		}
+		return key{secret.Type, secret.SecretKey}
+	}
+
+	befores := map[key]*Secret{}
+	for i := range before {
+		befores[keyOf(before[i])] = &before[i]
+	}
+	afters := map[key]*Secret{}
+	for i := range after {
+		afters[keyOf(after[i])] = &after[i]
+	}
+
+	for k, a := range afters {
+		b, exists := befores[k]
+		if !exists {
+			diff.Added = append(diff.Added, SecretChange{SecretKey: k.secretKey, Type: k.typ, After: a})
+		} else if b.SecretValue != a.SecretValue || deref(b.SecretComment) != deref(a.SecretComment) {
+			diff.Modified = append(diff.Modified, SecretChange{SecretKey: k.secretKey, Type: k.typ, Before: b, After: a})
+		}
+	}
+	for k, b := range befores {
+		if _, exists := afters[k]; !exists {
+			diff.Removed = append(diff.Removed, SecretChange{SecretKey: k.secretKey, Type: k.typ, Before: b})
+		}
+	}
+
+	for _, changes := range [][]SecretChange{diff.Added, diff.Removed, diff.Modified} {
+		slices.SortFunc(changes, func(a, b SecretChange) int {
+			if c := cmp.Compare(a.SecretKey, b.SecretKey); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Type, b.Type)
+		})
+	}
+	return diff
+}
+
+// DiffSnapshots retrieves two secret snapshots, and compares their secrets.
+//
+// Just a helper function for `GetSnapshot` and `DiffSecrets`.
+func (c *Client) DiffSnapshots(beforeSnapshotID, afterSnapshotID string) (diff SnapshotDiff, err error) {
+	return c.DiffSnapshotsWithContext(context.Background(), beforeSnapshotID, afterSnapshotID)
+}
+
+// DiffSnapshotsWithContext is the same as `DiffSnapshots` with given context.
+func (c *Client) DiffSnapshotsWithContext(ctx context.Context, beforeSnapshotID, afterSnapshotID string) (diff SnapshotDiff, err error) {
+	var before, after SnapshotData
+	if before, err = c.GetSnapshotWithContext(ctx, beforeSnapshotID); err != nil {
+		return SnapshotDiff{}, err
+	}
+	if after, err = c.GetSnapshotWithContext(ctx, afterSnapshotID); err != nil {
+		return SnapshotDiff{}, err
+	}
+	return DiffSecrets(before.SecretSnapshot.SecretVersions, after.SecretSnapshot.SecretVersions), nil
+}
+
+// DiffSnapshotWithCurrent retrieves a secret snapshot, and compares its secrets with current ones of the folder.
+//
+// (what would be changed by `RollbackToSnapshot` is the reverse of the result)
+//
+// Just a helper function for `GetSnapshot`, `ListSecrets`, and `DiffSecrets`,
+// but current secrets are always fetched from the server, bypassing the secret cache and the offline cache.
+func (c *Client) DiffSnapshotWithCurrent(workspaceID, environment, secretPath, snapshotID string) (diff SnapshotDiff, err error) {
+	return c.DiffSnapshotWithCurrentWithContext(context.Background(), workspaceID, environment, secretPath, snapshotID)
+}
+
+// DiffSnapshotWithCurrentWithContext is the same as `DiffSnapshotWithCurrent` with given context.
+func (c *Client) DiffSnapshotWithCurrentWithContext(ctx context.Context, workspaceID, environment, secretPath, snapshotID string) (diff SnapshotDiff, err error) {
+	var snapshot SnapshotData
+	if snapshot, err = c.GetSnapshotWithContext(ctx, snapshotID); err != nil {
+		return SnapshotDiff{}, err
+	}
+	// (current secrets are fetched from the server, as cached ones may be stale)
+	params := NewParamsListSecrets().
+		SetWorkspaceID(workspaceID).
+		SetEnvironment(environment).
+		SetSecretPath(secretPath)
+	if err = params.validate(); err != nil {
+		return SnapshotDiff{}, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	var current SecretsData
+	if current, err = c.fetchSecrets(ctx, params); err != nil {
+		return SnapshotDiff{}, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	return DiffSecrets(snapshot.SecretSnapshot.SecretVersions, current.Secrets), nil
+}
@@ -0,0 +1,126 @@
+package infisical
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestProjects(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t)
+	server := target.fakeServer(t)
+
+	client := target.newClient(t,
+		WithRetryPolicy(nil),
+		WithOfflineCache(&OfflineCachePolicy{Dir: t.TempDir()}),
+	)
+
+	// (create a project with default environments)
+	if created, err := client.CreateProject("Billing Service", nil); err != nil {
+		t.Errorf("failed to create a project: %s", err)
+	} else if created.Workspace.Slug != "billing-service" || len(created.Workspace.Environments) != 3 {
+		t.Errorf("unexpected project: %+v", created.Workspace)
+	}
+
+	// (create a project with initial environments)
+	var workspaceID string
+	if created, err := client.CreateProject("Payments Service", NewParamsCreateProject().
+		SetSlug("payments").
+		SetDescription("payments microservice").
+		SetEnvironments(
+			WorkspaceEnvironment{Name: "Production", Slug: "prod"},
+			WorkspaceEnvironment{Name: "QA", Slug: "qa"},
+			WorkspaceEnvironment{Name: "Development", Slug: "dev"},
+		),
+	); err != nil {
+		t.Errorf("failed to create a project: %s", err)
+	} else {
+		workspaceID = created.Workspace.ID
+
+		if created.Workspace.Slug != "payments" || created.Workspace.Description == nil || *created.Workspace.Description != "payments microservice" {
+			t.Errorf("unexpected project: %+v", created.Workspace)
+		}
+		if len(created.Workspace.Environments) != 3 {
+			t.Errorf("unexpected environments: %+v", created.Workspace.Environments)
+		} else {
+			for i, expected := range []string{"prod", "qa", "dev"} {
+				if env := created.Workspace.Environments[i]; env.Slug != expected || env.Position != i+1 {
+					t.Errorf("unexpected environment at %d: %+v", i, env)
+				}
+			}
+		}
+	}
+
+	// (get a project)
+	if project, err := client.GetProject(workspaceID); err != nil {
+		t.Errorf("failed to get a project: %s", err)
+	} else if project.Workspace.Name != "Payments Service" || !project.Workspace.AutoCapitalization {
+		t.Errorf("unexpected project: %+v", project.Workspace)
+	}
+
+	// (update a project)
+	if updated, err := client.UpdateProject(workspaceID, NewParamsUpdateProject().
+		SetName("Payments").
+		SetAutoCapitalization(false),
+	); err != nil {
+		t.Errorf("failed to update a project: %s", err)
+	} else if updated.Workspace.Name != "Payments" || updated.Workspace.AutoCapitalization {
+		t.Errorf("unexpected project: %+v", updated.Workspace)
+	}
+
+	// (delete a project, dropping its secrets from the offline cache)
+	server.SetSecret(workspaceID, "prod", "/", "KEY", "value")
+	params := NewParamsListSecrets().SetWorkspaceID(workspaceID).SetEnvironment("prod")
+	if _, err := client.ListSecrets(params); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	}
+	if _, err := client.DeleteProject(workspaceID); err != nil {
+		t.Errorf("failed to delete a project: %s", err)
+	}
+	if _, err := client.GetProject(workspaceID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted project should not be found: %v", err)
+	}
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	})
+	if _, err := client.ListSecrets(params); err == nil {
+		t.Errorf("secrets of the deleted project should not be served from the offline cache")
+	}
+	server.ClearFaults()
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing name": func(client *Client) error {
+			_, err := client.CreateProject("", nil)
+			return err
+		},
+		"missing organization id": func(client *Client) error {
+			_, err := client.RetrieveProjects("")
+			return err
+		},
+		"invalid slug": func(client *Client) error {
+			_, err := client.CreateProject("Project", NewParamsCreateProject().SetSlug("Project"))
+			return err
+		},
+		"duplicated environments": func(client *Client) error {
+			_, err := client.CreateProject("Project", NewParamsCreateProject().SetEnvironments(
+				WorkspaceEnvironment{Name: "Development", Slug: "dev"},
+				WorkspaceEnvironment{Name: "Development", Slug: "dev"},
+			))
+			return err
+		},
+		"nothing to update": func(client *Client) error {
+			_, err := client.UpdateProject(workspaceID, nil)
+			return err
+		},
+		"missing workspace id": func(client *Client) error {
+			_, err := client.DeleteProject("")
+			return err
+		},
+	})
+}
@@ -0,0 +1,116 @@
+package infisical
+
+import (
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy struct for retrying failed requests
+type RetryPolicy struct {
+	// maximum number of attempts, including the first one (no retry if <= 1)
+	MaxAttempts int
+
+	// delay before the first retry, doubled on each retry
+	BaseDelay time.Duration
+
+	// upper bound of delays (no bound if <= 0)
+	MaxDelay time.Duration
+
+	// ratio (0.0 ~ 1.0) of each delay to be randomly subtracted
+	Jitter float64
+
+	// HTTP status codes which will be retried
+	RetryableStatusCodes []int
+
+	// HTTP methods which will be retried
+	//
+	// NOTE: add mutating methods (eg. "POST", "PATCH", "DELETE") only when they are safe to be sent more than once
+	RetryableMethods []string
+}
+
+// DefaultRetryPolicy returns a retry policy with sane defaults.
+//
+// Only idempotent, non-mutating requests are retried with it.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+		},
+	}
+}
+
+// allowsMethod checks if given HTTP method can be retried with this policy.
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	return p != nil && slices.Contains(p.RetryableMethods, method)
+}
+
+// retryableStatus checks if given HTTP status code can be retried with this policy.
+func (p *RetryPolicy) retryableStatus(status int) bool {
+	return slices.Contains(p.RetryableStatusCodes, status)
+}
+
+// delay returns the duration to wait before given attempt (starting from 1 for the first retry).
+func (p *RetryPolicy) delay(attempt int, res *http.Response) time.Duration {
+	// honour `Retry-After` header if there is one
+	if res != nil {
+		if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				after = p.MaxDelay
+			}
+			return after
+		}
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && delay > 0 {
+		jitter := min(p.Jitter, 1.0)
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// retryAfter parses the value of `Retry-After` header (in seconds or HTTP-date).
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
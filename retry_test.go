@@ -0,0 +1,153 @@
+package infisical
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	////////////////////////////////
+	// run a stand-in server which fails for the first few requests
+	var loginFailures, listFailures, createFailures atomic.Int32
+	loginFailures.Store(1)
+
+	var loginAttempts, listAttempts, createAttempts atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		loginAttempts.Add(1)
+		if loginFailures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(UniversalAuthToken{
+			AccessToken:       "test-access-token",
+			AccessTokenMaxTTL: 3600,
+			ExpiresIn:         600,
+			TokenType:         "Bearer",
+		})
+	})
+	mux.HandleFunc("/api/v3/secrets/raw", func(w http.ResponseWriter, r *http.Request) {
+		listAttempts.Add(1)
+		if listFailures.Add(-1) >= 0 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SecretsData{})
+	})
+	mux.HandleFunc("/api/v3/secrets/raw/KEY", func(w http.ResponseWriter, r *http.Request) {
+		createAttempts.Add(1)
+		if createFailures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["secretValue"] != "value" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	////////////////////////////////
+	// initialize client
+	client := NewClientWithoutAPIKey("test-client-id", "test-client-secret")
+	client.SetAPIBaseURL(server.URL)
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	client.SetRetryPolicy(policy)
+
+	////////////////////////////////
+	// test api functions
+
+	// (login & list: retried)
+	listFailures.Store(2)
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID("workspace").SetEnvironment("dev")); err != nil {
+		t.Errorf("failed to list secrets with retries: %s", err)
+	}
+	if loginAttempts.Load() != 2 {
+		t.Errorf("login should have been tried 2 times, but was tried %d times", loginAttempts.Load())
+	}
+	if listAttempts.Load() != 3 {
+		t.Errorf("listing secrets should have been tried 3 times, but was tried %d times", listAttempts.Load())
+	}
+
+	// (list: too many failures)
+	listAttempts.Store(0)
+	listFailures.Store(int32(policy.MaxAttempts))
+	if _, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID("workspace").SetEnvironment("dev")); err == nil {
+		t.Errorf("listing secrets should have failed after %d attempts", policy.MaxAttempts)
+	}
+	if listAttempts.Load() != int32(policy.MaxAttempts) {
+		t.Errorf("listing secrets should have been tried %d times, but was tried %d times", policy.MaxAttempts, listAttempts.Load())
+	}
+
+	// (create: not retried by default)
+	createFailures.Store(1)
+	if err := client.CreateSecret("workspace", "dev", "KEY", "value", NewParamsCreateSecret()); err == nil {
+		t.Errorf("creating a secret should not have been retried")
+	}
+	if createAttempts.Load() != 1 {
+		t.Errorf("creating a secret should have been tried once, but was tried %d times", createAttempts.Load())
+	}
+
+	// (create: retried when opted in)
+	policy.RetryableMethods = append(policy.RetryableMethods, http.MethodPost)
+	createAttempts.Store(0)
+	createFailures.Store(1)
+	if err := client.CreateSecret("workspace", "dev", "KEY", "value", NewParamsCreateSecret()); err != nil {
+		t.Errorf("failed to create a secret with retries: %s", err)
+	}
+	if createAttempts.Load() != 2 {
+		t.Errorf("creating a secret should have been tried 2 times, but was tried %d times", createAttempts.Load())
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	// (exponential backoff)
+	for attempt, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+	} {
+		if delay := policy.delay(attempt, nil); delay != expected {
+			t.Errorf("delay for attempt %d should be %s, but was %s", attempt, expected, delay)
+		}
+	}
+
+	// (jitter)
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		if delay := policy.delay(1, nil); delay < 50*time.Millisecond || delay > 100*time.Millisecond {
+			t.Errorf("jittered delay is out of range: %s", delay)
+		}
+	}
+
+	// (`Retry-After` header)
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "1")
+	if delay := policy.delay(1, res); delay != time.Second {
+		t.Errorf("delay should follow `Retry-After` header, but was %s", delay)
+	}
+	res.Header.Set("Retry-After", "120")
+	if delay := policy.delay(1, res); delay != policy.MaxDelay {
+		t.Errorf("delay from `Retry-After` header should be bounded by max delay, but was %s", delay)
+	}
+	res.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	if delay := policy.delay(1, res); delay != 0 {
+		t.Errorf("delay from past `Retry-After` date should be 0, but was %s", delay)
+	}
+}
@@ -0,0 +1,451 @@
+package infisical
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UniversalAuthCredential struct for universal-auth credentials which are persisted by `CredentialStore`s
+type UniversalAuthCredential struct {
+	ClientID       string `json:"clientId"`
+	ClientSecret   string `json:"clientSecret"`
+	ClientSecretID string `json:"clientSecretId,omitempty"` // empty if unknown (eg. initial ones)
+}
+
+// CredentialStore is an interface for persisting universal-auth credentials rotated by the client.
+type CredentialStore interface {
+	// Load loads the stored credential. (nil if nothing was stored yet)
+	Load(ctx context.Context) (*UniversalAuthCredential, error)
+
+	// Save persists given credential.
+	//
+	// It is called with a newly-created client secret, before the old one is revoked.
+	Save(ctx context.Context, credential UniversalAuthCredential) error
+}
+
+// FileCredentialStore is a credential store which persists credentials in a JSON file
+type FileCredentialStore struct {
+	path string
+}
+
+// NewFileCredentialStore returns a new credential store with given file path.
+//
+// The file is written with permission 0600.
+func NewFileCredentialStore(path string) *FileCredentialStore {
+	return &FileCredentialStore{
+		path: path,
+	}
+}
+
+// Load reads the credential from the file.
+func (s *FileCredentialStore) Load(ctx context.Context) (*UniversalAuthCredential, error) {
+	bytes, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read credential file: %w", err)
+	}
+
+	var credential UniversalAuthCredential
+	if err := json.Unmarshal(bytes, &credential); err != nil {
+		return nil, fmt.Errorf("failed to parse credential file: %w", err)
+	}
+	return &credential, nil
+}
+
+// Save writes the credential to the file atomically.
+func (s *FileCredentialStore) Save(ctx context.Context, credential UniversalAuthCredential) error {
+	bytes, err := json.Marshal(credential)
+	if err != nil {
+		return fmt.Errorf("failed to encode credential: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create credential file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(bytes); err == nil {
+		err = tmp.Chmod(0600)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write credential file: %w", err)
+	}
+	return nil
+}
+
+// EnvCredentialStore is a credential store which keeps credentials in environment variables
+//
+// NOTE: saved credentials are visible only to the current process (and its child processes started afterwards).
+type EnvCredentialStore struct {
+	clientIDKey       string
+	clientSecretKey   string
+	clientSecretIDKey string
+}
+
+// NewEnvCredentialStore returns a new credential store with given names of environment variables.
+//
+// The id of the client secret is kept in `{clientSecretKey}_ID`.
+func NewEnvCredentialStore(clientIDKey, clientSecretKey string) *EnvCredentialStore {
+	return &EnvCredentialStore{
+		clientIDKey:       clientIDKey,
+		clientSecretKey:   clientSecretKey,
+		clientSecretIDKey: clientSecretKey + "_ID",
+	}
+}
+
+// Load reads the credential from the environment variables.
+func (s *EnvCredentialStore) Load(ctx context.Context) (*UniversalAuthCredential, error) {
+	clientID, clientSecret := os.Getenv(s.clientIDKey), os.Getenv(s.clientSecretKey)
+	if clientID == "" || clientSecret == "" {
+		return nil, nil
+	}
+
+	return &UniversalAuthCredential{
+		ClientID:       clientID,
+		ClientSecret:   clientSecret,
+		ClientSecretID: os.Getenv(s.clientSecretIDKey),
+	}, nil
+}
+
+// Save sets the credential to the environment variables.
+func (s *EnvCredentialStore) Save(ctx context.Context, credential UniversalAuthCredential) error {
+	return errors.Join(
+		os.Setenv(s.clientIDKey, credential.ClientID),
+		os.Setenv(s.clientSecretKey, credential.ClientSecret),
+		os.Setenv(s.clientSecretIDKey, credential.ClientSecretID),
+	)
+}
+
+// ClientSecretRotationPolicy struct for rotating the client's own universal-auth client secret
+type ClientSecretRotationPolicy struct {
+	// id of the machine identity which owns the client id
+	//
+	// NOTE: the identity should have permission to manage its own universal-auth client secrets.
+	IdentityID string
+
+	// store for persisting rotated credentials
+	//
+	// (if it has a stored credential, it takes precedence over the one given to the client)
+	Store CredentialStore
+
+	// rotate when the client secret expires within this duration (a quarter of its TTL if <= 0)
+	RotateBefore time.Duration
+
+	// rotate when the remaining uses of the client secret are this or fewer (a quarter of its usage limit if <= 0)
+	MinRemainingUses int64
+
+	// params for creating new client secrets (description, TTL, and usage limit are defaulted for nil fields,
+	// the latter two inherited from the current client secret)
+	NewClientSecret *ParamsCreateClientSecret
+}
+
+// WithClientSecretRotation makes the client rotate its own universal-auth client secret with given policy.
+//
+// After each login or renewal of access tokens, the current client secret is checked in the background,
+// and if it is near its expiry or usage limit, a new one is created, saved to the policy's store,
+// switched in place, and then the old one is revoked.
+//
+// (rotation can also be done manually with `RotateClientSecret` or `RotateClientSecretIfNeeded`)
+func WithClientSecretRotation(policy *ClientSecretRotationPolicy) Option {
+	return func(cfg *clientConfig) error {
+		if policy != nil {
+			if err := validateID("identity id", policy.IdentityID); err != nil {
+				return err
+			}
+			if policy.Store == nil {
+				return fmt.Errorf("credential store of client secret rotation is missing")
+			}
+			if policy.NewClientSecret != nil {
+				if err := policy.NewClientSecret.validate(); err != nil {
+					return err
+				}
+			}
+		}
+		cfg.rotationPolicy = policy
+		return nil
+	}
+}
+
+// clientSecretRotation struct for the state of client secret rotation
+type clientSecretRotation struct {
+	policy ClientSecretRotationPolicy
+
+	lock           sync.Mutex // held while checking or rotating
+	clientSecretID string     // id of the current client secret (guarded by `lock`; empty if unknown)
+	clientSecret   string     // client secret which `clientSecretID` belongs to (guarded by `lock`)
+}
+
+// newClientSecretRotation creates a new rotation with given policy and authenticator,
+// loading the stored credential into the authenticator.
+//
+// (returns nil if `policy` is nil)
+func newClientSecretRotation(policy *ClientSecretRotationPolicy, authenticator Authenticator) (*clientSecretRotation, Authenticator, error) {
+	if policy == nil {
+		return nil, authenticator, nil
+	}
+
+	rotation := &clientSecretRotation{
+		policy: *policy,
+	}
+
+	stored, err := policy.Store.Load(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load credential: %w", err)
+	}
+	if stored != nil {
+		authenticator = NewUniversalAuth(stored.ClientID, stored.ClientSecret)
+		rotation.clientSecretID, rotation.clientSecret = stored.ClientSecretID, stored.ClientSecret
+	} else if _, ok := authenticator.(*UniversalAuth); !ok {
+		return nil, nil, fmt.Errorf("client secret rotation requires universal-auth")
+	}
+
+	return rotation, authenticator, nil
+}
+
+// universalAuth returns the client's current authenticator for client secret rotation.
+//
+// (read at each rotation, as it can be changed with `SetAuthenticator`)
+func (c *Client) universalAuth() (*UniversalAuth, error) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	if ua, ok := c.authenticator.(*UniversalAuth); ok {
+		return ua, nil
+	}
+	return nil, fmt.Errorf("client secret rotation requires universal-auth")
+}
+
+// RotateClientSecret creates a new universal-auth client secret, saves it to the credential store,
+// switches to it, and then revokes the current one.
+//
+// It requires the client to be created with `WithClientSecretRotation`.
+func (c *Client) RotateClientSecret() (result UniversalAuthCredential, err error) {
+	return c.RotateClientSecretWithContext(context.Background())
+}
+
+// RotateClientSecretWithContext is the same as `RotateClientSecret` with given context.
+func (c *Client) RotateClientSecretWithContext(ctx context.Context) (result UniversalAuthCredential, err error) {
+	if c.rotation == nil {
+		return UniversalAuthCredential{}, fmt.Errorf("failed to rotate client secret: client secret rotation is not configured")
+	}
+
+	c.rotation.lock.Lock()
+	defer c.rotation.lock.Unlock()
+
+	var current ClientSecret
+	if current, err = c.currentClientSecret(ctx); err == nil {
+		if result, err = c.rotateClientSecret(ctx, current); err == nil {
+			return result, nil
+		}
+	}
+
+	return result, fmt.Errorf("failed to rotate client secret: %w", err)
+}
+
+// RotateClientSecretIfNeeded rotates the universal-auth client secret
+// only when it is near its expiry or usage limit, and returns whether it was rotated.
+//
+// It requires the client to be created with `WithClientSecretRotation`.
+func (c *Client) RotateClientSecretIfNeeded() (rotated bool, err error) {
+	return c.RotateClientSecretIfNeededWithContext(context.Background())
+}
+
+// RotateClientSecretIfNeededWithContext is the same as `RotateClientSecretIfNeeded` with given context.
+func (c *Client) RotateClientSecretIfNeededWithContext(ctx context.Context) (rotated bool, err error) {
+	if c.rotation == nil {
+		return false, fmt.Errorf("failed to rotate client secret: client secret rotation is not configured")
+	}
+
+	c.rotation.lock.Lock()
+	defer c.rotation.lock.Unlock()
+
+	return c.rotateClientSecretIfNeeded(ctx)
+}
+
+// rotateClientSecretInBackground rotates the client secret if needed, unless another check or rotation is running.
+func (c *Client) rotateClientSecretInBackground(ctx context.Context) {
+	if !c.rotation.lock.TryLock() {
+		return
+	}
+	defer c.rotation.lock.Unlock()
+
+	_, _ = c.rotateClientSecretIfNeeded(ctx)
+}
+
+// rotateClientSecretIfNeeded checks the current client secret and rotates it if needed.
+//
+// NOTE: `rotation.lock` should be held by the caller.
+func (c *Client) rotateClientSecretIfNeeded(ctx context.Context) (rotated bool, err error) {
+	var current ClientSecret
+	if current, err = c.currentClientSecret(ctx); err == nil {
+		if !c.rotation.needed(current, time.Now()) {
+			return false, nil
+		}
+		var result UniversalAuthCredential
+		if result, err = c.rotateClientSecret(ctx, current); err == nil {
+			return true, nil
+		} else if result.ClientSecretID != "" {
+			// (switched to the new one, but the old one is still valid)
+			c.logEvent(ctx, slog.LevelWarn, "rotated, but failed to revoke the old client secret",
+				slog.String("identity_id", c.rotation.policy.IdentityID),
+				slog.String("client_secret_id", current.ID),
+				slog.Any("error", err),
+			)
+			return true, err
+		}
+	}
+
+	c.logEvent(ctx, slog.LevelWarn, "failed to rotate client secret",
+		slog.String("identity_id", c.rotation.policy.IdentityID),
+		slog.Any("error", err),
+	)
+
+	return false, fmt.Errorf("failed to rotate client secret: %w", err)
+}
+
+// currentClientSecret finds the metadata of the current client secret.
+//
+// (when its id is unknown, it is identified with its prefix)
+//
+// NOTE: `rotation.lock` should be held by the caller.
+func (c *Client) currentClientSecret(ctx context.Context) (ClientSecret, error) {
+	ua, err := c.universalAuth()
+	if err != nil {
+		return ClientSecret{}, err
+	}
+	_, clientSecret := ua.credential()
+	if clientSecret != c.rotation.clientSecret {
+		// (changed with `SetAuthenticator`)
+		c.rotation.clientSecretID, c.rotation.clientSecret = "", clientSecret
+	}
+
+	secrets, err := c.ListClientSecretsWithContext(ctx, c.rotation.policy.IdentityID)
+	if err != nil {
+		return ClientSecret{}, err
+	}
+
+	candidates := []ClientSecret{}
+	for _, secret := range secrets.ClientSecretData {
+		if c.rotation.clientSecretID != "" {
+			if secret.ID == c.rotation.clientSecretID {
+				return secret, nil
+			}
+		} else if secret.ClientSecretPrefix != "" && strings.HasPrefix(clientSecret, secret.ClientSecretPrefix) {
+			candidates = append(candidates, secret)
+		}
+	}
+	if len(candidates) == 1 {
+		c.rotation.clientSecretID = candidates[0].ID
+		return candidates[0], nil
+	} else if len(candidates) > 1 {
+		return ClientSecret{}, fmt.Errorf("cannot identify the current client secret among %d candidates", len(candidates))
+	}
+
+	return ClientSecret{}, fmt.Errorf("%w: current client secret is not found (or revoked)", ErrNotFound)
+}
+
+// needed checks if given client secret should be rotated at given time.
+func (r *clientSecretRotation) needed(current ClientSecret, now time.Time) bool {
+	if expiresAt, ok := current.ExpiresAt(); ok {
+		before := r.policy.RotateBefore
+		if before <= 0 {
+			before = time.Duration(current.TTL) * time.Second / 4
+		}
+		if !now.Add(before).Before(expiresAt) {
+			return true
+		}
+	}
+
+	if remaining, ok := current.RemainingUses(); ok {
+		minimum := r.policy.MinRemainingUses
+		if minimum <= 0 {
+			minimum = current.NumUsesLimit / 4
+		}
+		if remaining <= minimum {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rotateClientSecret replaces given current client secret with a new one.
+//
+// NOTE: `rotation.lock` should be held by the caller.
+func (c *Client) rotateClientSecret(ctx context.Context, current ClientSecret) (result UniversalAuthCredential, err error) {
+	identityID := c.rotation.policy.IdentityID
+
+	var ua *UniversalAuth
+	if ua, err = c.universalAuth(); err != nil {
+		return UniversalAuthCredential{}, err
+	}
+
+	// (fields of the policy's params take precedence over the defaults)
+	params := NewParamsCreateClientSecret().
+		SetDescription(fmt.Sprintf("rotated at %s", time.Now().UTC().Format(time.RFC3339)))
+	params.TTL, params.NumUsesLimit = ptr(current.TTL), ptr(current.NumUsesLimit)
+	if policy := c.rotation.policy.NewClientSecret; policy != nil {
+		if policy.Description != nil {
+			params.Description = ptr(*policy.Description)
+		}
+		if policy.TTL != nil {
+			params.TTL = ptr(*policy.TTL)
+		}
+		if policy.NumUsesLimit != nil {
+			params.NumUsesLimit = ptr(*policy.NumUsesLimit)
+		}
+	}
+
+	var created CreatedClientSecretData
+	if created, err = c.CreateClientSecretWithContext(ctx, identityID, params); err != nil {
+		return UniversalAuthCredential{}, err
+	}
+
+	clientID, _ := ua.credential()
+	result = UniversalAuthCredential{
+		ClientID:       clientID,
+		ClientSecret:   created.ClientSecret,
+		ClientSecretID: created.ClientSecretData.ID,
+	}
+
+	// persist the new one first, or it will be lost
+	if err = c.rotation.policy.Store.Save(ctx, result); err != nil {
+		if _, revokeErr := c.RevokeClientSecretWithContext(ctx, identityID, result.ClientSecretID); revokeErr != nil {
+			err = errors.Join(err, revokeErr)
+		}
+		return UniversalAuthCredential{}, fmt.Errorf("failed to save credential: %w", err)
+	}
+
+	ua.setClientSecret(result.ClientSecret)
+	c.rotation.clientSecretID, c.rotation.clientSecret = result.ClientSecretID, result.ClientSecret
+
+	c.logEvent(ctx, slog.LevelInfo, "client secret rotated",
+		slog.String("identity_id", identityID),
+		slog.String("client_secret_id", result.ClientSecretID),
+		slog.String("revoked_client_secret_id", current.ID),
+	)
+
+	if _, err = c.RevokeClientSecretWithContext(ctx, identityID, current.ID); err != nil {
+		return result, fmt.Errorf("rotated, but failed to revoke the old client secret: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,307 @@
+package infisical
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestClientSecretRotation(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	admin := NewClientWithoutAPIKey(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret)
+	admin.SetAPIBaseURL(server.URL)
+
+	// (an identity with a client secret which can be used only twice)
+	var identityID, clientID, clientSecret string
+	if created, err := admin.CreateIdentity(infisicaltest.DefaultOrganizationID, "rotating-service", OrganizationRoleMember); err != nil {
+		t.Fatalf("failed to create an identity: %s", err)
+	} else {
+		identityID = created.Identity.ID
+	}
+	if attached, err := admin.AttachUniversalAuth(identityID, nil); err != nil {
+		t.Fatalf("failed to attach universal auth: %s", err)
+	} else {
+		clientID = attached.IdentityUniversalAuth.ClientID
+	}
+	if created, err := admin.CreateClientSecret(identityID, NewParamsCreateClientSecret().SetNumUsesLimit(2).SetTTL(time.Hour)); err != nil {
+		t.Fatalf("failed to create a client secret: %s", err)
+	} else {
+		clientSecret = created.ClientSecret
+	}
+
+	path := filepath.Join(t.TempDir(), "credential.json")
+	store := NewFileCredentialStore(path)
+	newClient := func() *Client {
+		client, err := New(
+			WithUniversalAuth(clientID, clientSecret),
+			WithBaseURL(server.URL),
+			WithClientSecretRotation(&ClientSecretRotationPolicy{
+				IdentityID: identityID,
+				Store:      store,
+			}),
+		)
+		if err != nil {
+			t.Fatalf("failed to create client: %s", err)
+		}
+		return client
+	}
+	client := newClient()
+	discardToken := func(client *Client) {
+		client.tokenLock.Lock()
+		defer client.tokenLock.Unlock()
+
+		client.clearToken()
+	}
+
+	// (not rotated while enough uses are left)
+	if _, err := client.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	}
+	if rotated, err := client.RotateClientSecretIfNeeded(); err != nil {
+		t.Errorf("failed to check client secret: %s", err)
+	} else if rotated {
+		t.Errorf("client secret should not be rotated yet")
+	}
+	if stored, err := store.Load(context.Background()); err != nil || stored != nil {
+		t.Errorf("nothing should be stored yet: %+v, %v", stored, err)
+	}
+
+	// (rotated automatically after the last use by login)
+	discardToken(client)
+	if _, err := client.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	}
+	if _, err := client.RotateClientSecretIfNeeded(); err != nil { // (waits for the one in background)
+		t.Errorf("failed to check client secret: %s", err)
+	}
+	var rotatedID string
+	if stored, err := store.Load(context.Background()); err != nil || stored == nil {
+		t.Fatalf("rotated client secret should be stored: %v", err)
+	} else if stored.ClientID != clientID || stored.ClientSecret == clientSecret || stored.ClientSecretID == "" {
+		t.Errorf("unexpected stored credential: %+v", stored)
+	} else {
+		rotatedID = stored.ClientSecretID
+	}
+	if secrets, err := admin.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	} else if len(secrets.ClientSecretData) != 1 || secrets.ClientSecretData[0].ID != rotatedID {
+		t.Errorf("old client secret should be revoked: %+v", secrets.ClientSecretData)
+	} else if secrets.ClientSecretData[0].NumUsesLimit != 2 || secrets.ClientSecretData[0].TTL != 3600 {
+		t.Errorf("usage limit and TTL should be inherited: %+v", secrets.ClientSecretData[0])
+	}
+	discardToken(client)
+	if _, err := client.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to login with the rotated client secret: %s", err)
+	}
+
+	// (rotate manually)
+	if rotated, err := client.RotateClientSecret(); err != nil {
+		t.Errorf("failed to rotate client secret: %s", err)
+	} else if rotated.ClientSecretID == rotatedID {
+		t.Errorf("client secret should be rotated: %+v", rotated)
+	} else {
+		rotatedID = rotated.ClientSecretID
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Errorf("failed to stat credential file: %s", err)
+	} else if info.Mode().Perm() != 0600 {
+		t.Errorf("unexpected permission of credential file: %s", info.Mode().Perm())
+	}
+
+	// (stored credential takes precedence over the given one)
+	restarted := newClient()
+	if secrets, err := restarted.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to login with the stored credential: %s", err)
+	} else if len(secrets.ClientSecretData) != 1 || secrets.ClientSecretData[0].ID != rotatedID {
+		t.Errorf("unexpected client secrets: %+v", secrets.ClientSecretData)
+	}
+	if _, err := restarted.RotateClientSecretIfNeeded(); err != nil {
+		t.Errorf("failed to check client secret: %s", err)
+	}
+
+	// (params of the policy are merged with the defaults)
+	merged, err := New(
+		WithUniversalAuth(clientID, clientSecret),
+		WithBaseURL(server.URL),
+		WithClientSecretRotation(&ClientSecretRotationPolicy{
+			IdentityID:      identityID,
+			Store:           store,
+			NewClientSecret: NewParamsCreateClientSecret().SetTTL(2 * time.Hour),
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	if rotated, err := merged.RotateClientSecret(); err != nil {
+		t.Errorf("failed to rotate client secret: %s", err)
+	} else if secrets, err := admin.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	} else if len(secrets.ClientSecretData) != 1 || secrets.ClientSecretData[0].ID != rotated.ClientSecretID {
+		t.Errorf("unexpected client secrets: %+v", secrets.ClientSecretData)
+	} else if secret := secrets.ClientSecretData[0]; secret.TTL != 7200 || secret.NumUsesLimit != 2 || !strings.HasPrefix(secret.Description, "rotated at ") {
+		t.Errorf("given TTL should be merged with the inherited usage limit and the default description: %+v", secret)
+	} else {
+		rotatedID = rotated.ClientSecretID
+	}
+
+	// (authenticator changed after creation is rotated)
+	var otherID, otherSecret string
+	if created, err := admin.CreateClientSecret(identityID, nil); err != nil {
+		t.Fatalf("failed to create a client secret: %s", err)
+	} else {
+		otherID, otherSecret = created.ClientSecretData.ID, created.ClientSecret
+	}
+	changed := NewUniversalAuth(clientID, otherSecret)
+	merged.SetAuthenticator(changed)
+	if rotated, err := merged.RotateClientSecret(); err != nil {
+		t.Errorf("failed to rotate client secret: %s", err)
+	} else if _, secret := changed.credential(); secret != rotated.ClientSecret {
+		t.Errorf("changed authenticator should have been switched to the rotated client secret")
+	} else if secrets, err := admin.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	} else {
+		ids := []string{}
+		for _, secret := range secrets.ClientSecretData {
+			ids = append(ids, secret.ID)
+		}
+		if slices.Contains(ids, otherID) || !slices.Contains(ids, rotatedID) || !slices.Contains(ids, rotated.ClientSecretID) {
+			t.Errorf("client secret of the changed authenticator should have been revoked: %v", ids)
+		}
+	}
+
+	// (rotated, but failed to revoke the old one)
+	var revokingID, revokingSecret string
+	if created, err := admin.CreateClientSecret(identityID, NewParamsCreateClientSecret().SetTTL(time.Hour)); err != nil {
+		t.Fatalf("failed to create a client secret: %s", err)
+	} else {
+		revokingID, revokingSecret = created.ClientSecretData.ID, created.ClientSecret
+	}
+	revokingStore := NewFileCredentialStore(filepath.Join(t.TempDir(), "credential.json"))
+	revoking, err := New(
+		WithUniversalAuth(clientID, revokingSecret),
+		WithBaseURL(server.URL),
+		WithRetryPolicy(nil),
+		WithClientSecretRotation(&ClientSecretRotationPolicy{
+			IdentityID:   identityID,
+			Store:        revokingStore,
+			RotateBefore: 2 * time.Hour,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	server.InjectFault(infisicaltest.Fault{
+		Method:     "POST",
+		Path:       fmt.Sprintf("/api/v1/auth/universal-auth/identities/%s/client-secrets/%s/revoke", identityID, revokingID),
+		StatusCode: http.StatusForbidden,
+	})
+	if rotated, err := revoking.RotateClientSecretIfNeeded(); err == nil || !strings.Contains(err.Error(), "failed to revoke the old client secret") {
+		t.Errorf("failure of revoking the old client secret should be returned: %v", err)
+	} else if !rotated {
+		t.Errorf("client secret should be reported as rotated")
+	}
+	server.ClearFaults()
+	if stored, err := revokingStore.Load(context.Background()); err != nil || stored == nil || stored.ClientSecret == revokingSecret {
+		t.Errorf("rotated client secret should be stored: %+v, %v", stored, err)
+	} else if secrets, err := admin.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	} else {
+		ids := []string{}
+		for _, secret := range secrets.ClientSecretData {
+			ids = append(ids, secret.ID)
+		}
+		if !slices.Contains(ids, revokingID) || !slices.Contains(ids, stored.ClientSecretID) {
+			t.Errorf("both the old and the rotated client secrets should be valid: %v", ids)
+		}
+	}
+
+	// (rotated near the expiry)
+	rotation := &clientSecretRotation{}
+	for name, test := range map[string]struct {
+		secret   ClientSecret
+		expected bool
+	}{
+		"fresh": {
+			secret:   ClientSecret{TTL: 3600, CreatedAt: time.Now().Format(time.RFC3339)},
+			expected: false,
+		},
+		"near expiry": {
+			secret:   ClientSecret{TTL: 3600, CreatedAt: time.Now().Add(-50 * time.Minute).Format(time.RFC3339)},
+			expected: true,
+		},
+		"enough uses": {
+			secret:   ClientSecret{NumUses: 5, NumUsesLimit: 10},
+			expected: false,
+		},
+		"near usage limit": {
+			secret:   ClientSecret{NumUses: 8, NumUsesLimit: 10},
+			expected: true,
+		},
+		"unlimited": {
+			secret:   ClientSecret{NumUses: 100},
+			expected: false,
+		},
+	} {
+		if needed := rotation.needed(test.secret, time.Now()); needed != test.expected {
+			t.Errorf("%s: expected %v, got %v", name, test.expected, needed)
+		}
+	}
+
+	// (environment variables)
+	t.Setenv("TEST_CLIENT_ID", "env-client-id")
+	t.Setenv("TEST_CLIENT_SECRET", "env-client-secret")
+	t.Setenv("TEST_CLIENT_SECRET_ID", "")
+	envStore := NewEnvCredentialStore("TEST_CLIENT_ID", "TEST_CLIENT_SECRET")
+	if stored, err := envStore.Load(context.Background()); err != nil || stored == nil || stored.ClientSecret != "env-client-secret" {
+		t.Errorf("unexpected credential from environment variables: %+v, %v", stored, err)
+	}
+	if err := envStore.Save(context.Background(), UniversalAuthCredential{
+		ClientID:       "env-client-id",
+		ClientSecret:   "rotated-client-secret",
+		ClientSecretID: "rotated-client-secret-id",
+	}); err != nil {
+		t.Errorf("failed to save credential to environment variables: %s", err)
+	} else if os.Getenv("TEST_CLIENT_SECRET") != "rotated-client-secret" || os.Getenv("TEST_CLIENT_SECRET_ID") != "rotated-client-secret-id" {
+		t.Errorf("credential should be saved to environment variables")
+	}
+
+	// (invalid configurations)
+	for name, opts := range map[string][]Option{
+		"missing identity id": {
+			WithUniversalAuth(clientID, clientSecret),
+			WithClientSecretRotation(&ClientSecretRotationPolicy{Store: store}),
+		},
+		"missing store": {
+			WithUniversalAuth(clientID, clientSecret),
+			WithClientSecretRotation(&ClientSecretRotationPolicy{IdentityID: identityID}),
+		},
+		"not universal-auth": {
+			WithAuthenticator(NewAccessTokenAuth("token")),
+			WithClientSecretRotation(&ClientSecretRotationPolicy{IdentityID: identityID, Store: NewFileCredentialStore(filepath.Join(t.TempDir(), "missing.json"))}),
+		},
+		"offline cache without key": {
+			WithUniversalAuth(clientID, clientSecret),
+			WithClientSecretRotation(&ClientSecretRotationPolicy{IdentityID: identityID, Store: store}),
+			WithOfflineCache(&OfflineCachePolicy{Dir: t.TempDir()}),
+		},
+	} {
+		if _, err := New(opts...); err == nil {
+			t.Errorf("%s: client should not be created", name)
+		}
+	}
+	if _, err := admin.RotateClientSecret(); err == nil {
+		t.Errorf("client secret should not be rotated without configuration")
+	}
+}
@@ -1,10 +1,399 @@
 package infisical
 
-/*
-TODO:
-* Secret imports
-- [ ] [List](https://infisical.com/docs/api-reference/endpoints/secret-imports/list)
-- [ ] [Create](https://infisical.com/docs/api-reference/endpoints/secret-imports/create)
-- [ ] [Update](https://infisical.com/docs/api-reference/endpoints/secret-imports/update)
-- [ ] [Delete](https://infisical.com/docs/api-reference/endpoints/secret-imports/delete)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SecretImportsData struct for secret imports response
+type SecretImportsData struct {
+	SecretImports []SecretImportEntry `json:"secretImports"`
+}
+
+// SecretImportData struct for secret import response
+type SecretImportData struct {
+	Message      string            `json:"message,omitempty"`
+	SecretImport SecretImportEntry `json:"secretImport"`
+}
+
+// SecretImportEntry struct for one secret import of a path
+//
+// (not to be confused with `SecretImport`, which contains imported secrets)
+type SecretImportEntry struct {
+	ID                string                  `json:"id"`
+	ImportPath        string                  `json:"importPath"`
+	ImportEnvironment SecretImportEnvironment `json:"importEnv"`
+	Position          int                     `json:"position"`
+	FolderID          string                  `json:"folderId,omitempty"`
+	CreatedAt         string                  `json:"createdAt,omitempty"`
+	UpdatedAt         string                  `json:"updatedAt,omitempty"`
+}
+
+// SecretImportEnvironment struct for the environment of a secret import
+type SecretImportEnvironment struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// ParamsListSecretImports struct for listing secret imports
+type ParamsListSecretImports struct {
+	Path *string `json:"path,omitempty"`
+}
+
+// NewParamsListSecretImports returns a new params for listing secret imports at the root path.
+func NewParamsListSecretImports() *ParamsListSecretImports {
+	return &ParamsListSecretImports{
+		Path: ptr("/"),
+	}
+}
+
+func (p *ParamsListSecretImports) SetPath(path string) *ParamsListSecretImports {
+	p.Path = &path
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsListSecretImports) validate() error {
+	return validatePath(p.Path)
+}
+
+// ListSecretImports lists secret imports of a path, in order of their positions.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-imports/list
+func (c *Client) ListSecretImports(workspaceID, environment string, params *ParamsListSecretImports) (result SecretImportsData, err error) {
+	return c.ListSecretImportsWithContext(context.Background(), workspaceID, environment, params)
+}
+
+// ListSecretImportsWithContext is the same as `ListSecretImports` with given context.
+func (c *Client) ListSecretImportsWithContext(ctx context.Context, workspaceID, environment string, params *ParamsListSecretImports) (result SecretImportsData, err error) {
+	if params == nil {
+		params = NewParamsListSecretImports()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), params.validate()); err != nil {
+		return SecretImportsData{}, fmt.Errorf("failed to list secret imports: %w", err)
+	}
+
+	// essential parameters
+	query := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		*ParamsListSecretImports
+	}{workspaceID, environment, params}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", "/v1/secret-imports", AuthMethodNormal, query)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return SecretImportsData{}, fmt.Errorf("failed to list secret imports: %w", err)
+}
+
+// ParamsCreateSecretImport struct for creating a secret import
+type ParamsCreateSecretImport struct {
+	Path *string `json:"path,omitempty"`
+}
+
+// NewParamsCreateSecretImport returns a new params for creating a secret import at the root path.
+func NewParamsCreateSecretImport() *ParamsCreateSecretImport {
+	return &ParamsCreateSecretImport{
+		Path: ptr("/"),
+	}
+}
+
+func (p *ParamsCreateSecretImport) SetPath(path string) *ParamsCreateSecretImport {
+	p.Path = &path
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsCreateSecretImport) validate() error {
+	return validatePath(p.Path)
+}
+
+// CreateSecretImport imports secrets of `importEnvironment`:`importPath` into a path, at the last position.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-imports/create
+func (c *Client) CreateSecretImport(workspaceID, environment, importEnvironment, importPath string, params *ParamsCreateSecretImport) (result SecretImportData, err error) {
+	return c.CreateSecretImportWithContext(context.Background(), workspaceID, environment, importEnvironment, importPath, params)
+}
+
+// CreateSecretImportWithContext is the same as `CreateSecretImport` with given context.
+func (c *Client) CreateSecretImportWithContext(ctx context.Context, workspaceID, environment, importEnvironment, importPath string, params *ParamsCreateSecretImport) (result SecretImportData, err error) {
+	if params == nil {
+		params = NewParamsCreateSecretImport()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateID("import environment", importEnvironment), validatePath(&importPath), params.validate()); err != nil {
+		return SecretImportData{}, fmt.Errorf("failed to create a secret import: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		Import      struct {
+			Environment string `json:"environment"`
+			Path        string `json:"path"`
+		} `json:"import"`
+		*ParamsCreateSecretImport
+	}{WorkspaceID: workspaceID, Environment: environment, ParamsCreateSecretImport: params}
+	body.Import.Environment, body.Import.Path = importEnvironment, importPath
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", "/v1/secret-imports", AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				c.invalidateSecretImports(ctx, workspaceID, environment, deref(params.Path))
+				return result, nil
+			}
+		}
+	}
+
+	return SecretImportData{}, fmt.Errorf("failed to create a secret import: %w", err)
+}
+
+// ParamsUpdateSecretImport struct for updating a secret import
+type ParamsUpdateSecretImport struct {
+	Path *string `json:"path,omitempty"`
+
+	ImportEnvironment *string `json:"-"`
+	ImportPath        *string `json:"-"`
+	Position          *int    `json:"-"` // 1-based; other imports are shifted
+}
+
+// NewParamsUpdateSecretImport returns a new params for updating a secret import at the root path.
+func NewParamsUpdateSecretImport() *ParamsUpdateSecretImport {
+	return &ParamsUpdateSecretImport{
+		Path: ptr("/"),
+	}
+}
+
+func (p *ParamsUpdateSecretImport) SetPath(path string) *ParamsUpdateSecretImport {
+	p.Path = &path
+	return p
+}
+
+func (p *ParamsUpdateSecretImport) SetImportEnvironment(importEnvironment string) *ParamsUpdateSecretImport {
+	p.ImportEnvironment = &importEnvironment
+	return p
+}
+
+func (p *ParamsUpdateSecretImport) SetImportPath(importPath string) *ParamsUpdateSecretImport {
+	p.ImportPath = &importPath
+	return p
+}
+
+func (p *ParamsUpdateSecretImport) SetPosition(position int) *ParamsUpdateSecretImport {
+	p.Position = &position
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsUpdateSecretImport) validate() error {
+	errs := []error{validatePath(p.Path), validatePath(p.ImportPath)}
+	if p.ImportEnvironment == nil && p.ImportPath == nil && p.Position == nil {
+		errs = append(errs, fmt.Errorf("%w: nothing to update", ErrInvalidParams))
+	}
+	if p.ImportEnvironment != nil {
+		errs = append(errs, validateID("import environment", *p.ImportEnvironment))
+	}
+	if p.Position != nil && *p.Position < 1 {
+		errs = append(errs, fmt.Errorf("%w: position should be 1 or greater: %d", ErrInvalidParams, *p.Position))
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateSecretImport updates the source or position of a secret import.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-imports/update
+func (c *Client) UpdateSecretImport(workspaceID, environment, secretImportID string, params *ParamsUpdateSecretImport) (result SecretImportData, err error) {
+	return c.UpdateSecretImportWithContext(context.Background(), workspaceID, environment, secretImportID, params)
+}
+
+// UpdateSecretImportWithContext is the same as `UpdateSecretImport` with given context.
+func (c *Client) UpdateSecretImportWithContext(ctx context.Context, workspaceID, environment, secretImportID string, params *ParamsUpdateSecretImport) (result SecretImportData, err error) {
+	if params == nil {
+		params = NewParamsUpdateSecretImport()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateID("secret import id", secretImportID), params.validate()); err != nil {
+		return SecretImportData{}, fmt.Errorf("failed to update a secret import: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		Import      struct {
+			Environment *string `json:"environment,omitempty"`
+			Path        *string `json:"path,omitempty"`
+			Position    *int    `json:"position,omitempty"`
+		} `json:"import"`
+		*ParamsUpdateSecretImport
+	}{WorkspaceID: workspaceID, Environment: environment, ParamsUpdateSecretImport: params}
+	body.Import.Environment, body.Import.Path, body.Import.Position = params.ImportEnvironment, params.ImportPath, params.Position
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/secret-imports/%s", secretImportID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				c.invalidateSecretImports(ctx, workspaceID, environment, deref(params.Path))
+				return result, nil
+			}
+		}
+	}
+
+	return SecretImportData{}, fmt.Errorf("failed to update a secret import: %w", err)
+}
+
+// ParamsDeleteSecretImport struct for deleting a secret import
+type ParamsDeleteSecretImport struct {
+	Path *string `json:"path,omitempty"`
+}
+
+// NewParamsDeleteSecretImport returns a new params for deleting a secret import at the root path.
+func NewParamsDeleteSecretImport() *ParamsDeleteSecretImport {
+	return &ParamsDeleteSecretImport{
+		Path: ptr("/"),
+	}
+}
+
+func (p *ParamsDeleteSecretImport) SetPath(path string) *ParamsDeleteSecretImport {
+	p.Path = &path
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsDeleteSecretImport) validate() error {
+	return validatePath(p.Path)
+}
+
+// DeleteSecretImport deletes a secret import.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-imports/delete
+func (c *Client) DeleteSecretImport(workspaceID, environment, secretImportID string, params *ParamsDeleteSecretImport) (result SecretImportData, err error) {
+	return c.DeleteSecretImportWithContext(context.Background(), workspaceID, environment, secretImportID, params)
+}
+
+// DeleteSecretImportWithContext is the same as `DeleteSecretImport` with given context.
+func (c *Client) DeleteSecretImportWithContext(ctx context.Context, workspaceID, environment, secretImportID string, params *ParamsDeleteSecretImport) (result SecretImportData, err error) {
+	if params == nil {
+		params = NewParamsDeleteSecretImport()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateID("secret import id", secretImportID), params.validate()); err != nil {
+		return SecretImportData{}, fmt.Errorf("failed to delete a secret import: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		*ParamsDeleteSecretImport
+	}{workspaceID, environment, params}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "DELETE", fmt.Sprintf("/v1/secret-imports/%s", secretImportID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				c.invalidateSecretImports(ctx, workspaceID, environment, deref(params.Path))
+				return result, nil
+			}
+		}
+	}
+
+	return SecretImportData{}, fmt.Errorf("failed to delete a secret import: %w", err)
+}
+
+// invalidateSecretImports drops cached secrets (listed with imports, or retrieved) of a path, after its imports were changed.
+//
+// (retrieved secrets are dropped too, as they may have been resolved from imports)
+func (c *Client) invalidateSecretImports(ctx context.Context, workspaceID, environment, path string) {
+	path = normalizeSecretPath(path)
+	c.secretCache.invalidate(func(key secretCacheKey) bool {
+		return key.workspaceID == workspaceID &&
+			key.environment == environment &&
+			key.secretPath == path &&
+			(key.includeImports || key.secretKey != "")
+	})
+	c.invalidateOfflineImports(ctx, workspaceID, environment, path)
+}
+
+// ResolvedSecret struct for an effective secret, resolved from local and imported secrets
+type ResolvedSecret struct {
+	Secret
+
+	ImportEnvironment string // environment of the import which the secret came from (empty for local secrets)
+	ImportPath        string // path of the import which the secret came from (empty for local secrets)
+}
+
+// Imported returns whether the secret came from an import.
+func (s ResolvedSecret) Imported() bool {
+	return s.ImportEnvironment != ""
+}
+
+// Resolve flattens local and imported secrets into the effective ones, keyed with their secret keys.
+//
+// Precedence (from the highest):
+//
+//  1. local secrets
+//  2. imported secrets (later imports over earlier ones)
+//
+// and personal secrets over shared ones in each of them.
+func (d SecretsData) Resolve() (resolved map[string]ResolvedSecret) {
+	resolved = map[string]ResolvedSecret{}
+
+	put := func(secrets []Secret, importEnvironment, importPath string) {
+		for _, typ := range []SecretType{SecretTypeShared, SecretTypePersonal} {
+			for _, secret := range secrets {
+				if secret.Type == typ || (typ == SecretTypeShared && secret.Type == "") {
+					resolved[secret.SecretKey] = ResolvedSecret{
+						Secret:            cloneSecret(secret),
+						ImportEnvironment: importEnvironment,
+						ImportPath:        importPath,
+					}
+				}
+			}
+		}
+	}
+	for _, imported := range d.Imports {
+		put(imported.Secrets, imported.Environment, imported.SecretPath)
+	}
+	put(d.Secrets, "", "")
+
+	return resolved
+}
+
+// ResolveSecrets lists secrets with their imports, and resolves them into the effective ones.
+//
+// Just a helper function for `ListSecrets` and `SecretsData.Resolve`.
+func (c *Client) ResolveSecrets(params *ParamsListSecrets) (resolved map[string]ResolvedSecret, err error) {
+	return c.ResolveSecretsWithContext(context.Background(), params)
+}
+
+// ResolveSecretsWithContext is the same as `ResolveSecrets` with given context.
+func (c *Client) ResolveSecretsWithContext(ctx context.Context, params *ParamsListSecrets) (resolved map[string]ResolvedSecret, err error) {
+	var copied ParamsListSecrets
+	if params != nil {
+		copied = *params
+	}
+	copied.IncludeImports = ptr(true)
+
+	var data SecretsData
+	if data, err = c.ListSecretsWithContext(ctx, &copied); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+	return data.Resolve(), nil
+}
@@ -0,0 +1,121 @@
+package infisical
+
+import (
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestSecretImports(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+	server.SetSecret(workspace.ID, "dev", "/shared/common", "LOG_LEVEL", "info")
+	server.SetSecret(workspace.ID, "dev", "/shared/common", "REGION", "us-east-1")
+	server.SetSecret(workspace.ID, "dev", "/shared/payments", "REGION", "eu-west-1")
+	server.SetSecret(workspace.ID, "dev", "/shared/payments", "API_URL", "https://payments")
+	server.SetSecret(workspace.ID, "prod", "/", "API_URL", "https://local")
+	server.SetPersonalSecret(workspace.ID, "prod", "/", "API_URL", "https://personal", infisicaltest.DefaultIdentityID)
+
+	client := target.newClient(t,
+		WithSecretCache(DefaultSecretCachePolicy()),
+	)
+
+	// (create secret imports)
+	var commonImportID string
+	if created, err := client.CreateSecretImport(workspace.ID, "prod", "dev", "/shared/common", nil); err != nil {
+		t.Errorf("failed to create a secret import: %s", err)
+	} else if created.SecretImport.Position != 1 || created.SecretImport.ImportEnvironment.Slug != "dev" {
+		t.Errorf("unexpected secret import: %+v", created.SecretImport)
+	} else {
+		commonImportID = created.SecretImport.ID
+	}
+	if _, err := client.CreateSecretImport(workspace.ID, "prod", "dev", "/shared/payments", nil); err != nil {
+		t.Errorf("failed to create a secret import: %s", err)
+	}
+
+	// (list secret imports)
+	if listed, err := client.ListSecretImports(workspace.ID, "prod", nil); err != nil {
+		t.Errorf("failed to list secret imports: %s", err)
+	} else if len(listed.SecretImports) != 2 || listed.SecretImports[1].ImportPath != "/shared/payments" {
+		t.Errorf("unexpected secret imports: %+v", listed.SecretImports)
+	}
+
+	// (resolve secrets: local over imported, personal over shared, later imports over earlier ones)
+	params := NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("prod")
+	if resolved, err := client.ResolveSecrets(params); err != nil {
+		t.Errorf("failed to resolve secrets: %s", err)
+	} else {
+		if len(resolved) != 3 {
+			t.Errorf("unexpected number of resolved secrets: %d", len(resolved))
+		}
+		if secret := resolved["API_URL"]; secret.SecretValue != "https://personal" || secret.Imported() {
+			t.Errorf("local personal secret should have been resolved: %+v", secret)
+		}
+		if secret := resolved["REGION"]; secret.SecretValue != "eu-west-1" || secret.ImportPath != "/shared/payments" {
+			t.Errorf("secret of the later import should have been resolved: %+v", secret)
+		}
+		if secret := resolved["LOG_LEVEL"]; secret.SecretValue != "info" || secret.ImportEnvironment != "dev" || secret.ImportPath != "/shared/common" {
+			t.Errorf("imported secret should have been resolved with its source: %+v", secret)
+		}
+	}
+	if params.IncludeImports != nil {
+		t.Errorf("params were mutated: %+v", *params)
+	}
+
+	// (reorder secret imports, invalidating cached secrets)
+	if updated, err := client.UpdateSecretImport(workspace.ID, "prod", commonImportID, NewParamsUpdateSecretImport().SetPosition(2)); err != nil {
+		t.Errorf("failed to update a secret import: %s", err)
+	} else if updated.SecretImport.Position != 2 {
+		t.Errorf("secret import should have been moved: %+v", updated.SecretImport)
+	}
+	if resolved, err := client.ResolveSecrets(params); err != nil {
+		t.Errorf("failed to resolve secrets: %s", err)
+	} else if secret := resolved["REGION"]; secret.SecretValue != "us-east-1" || secret.ImportPath != "/shared/common" {
+		t.Errorf("secret of the reordered import should have been resolved: %+v", secret)
+	}
+
+	// (delete a secret import, invalidating cached secrets retrieved through it)
+	if value, err := client.RetrieveSecretValue(workspace.ID, "prod", SecretTypeShared, "/LOG_LEVEL"); err != nil {
+		t.Errorf("failed to retrieve an imported secret: %s", err)
+	} else if value != "info" {
+		t.Errorf("unexpected value of an imported secret: %s", value)
+	}
+	if _, err := client.DeleteSecretImport(workspace.ID, "prod", commonImportID, nil); err != nil {
+		t.Errorf("failed to delete a secret import: %s", err)
+	}
+	if listed, err := client.ListSecretImports(workspace.ID, "prod", nil); err != nil {
+		t.Errorf("failed to list secret imports: %s", err)
+	} else if len(listed.SecretImports) != 1 || listed.SecretImports[0].Position != 1 {
+		t.Errorf("unexpected secret imports: %+v", listed.SecretImports)
+	}
+	if resolved, err := client.ResolveSecrets(params); err != nil {
+		t.Errorf("failed to resolve secrets: %s", err)
+	} else if _, exists := resolved["LOG_LEVEL"]; exists {
+		t.Errorf("secret of the deleted import should not be resolved")
+	}
+	if _, err := client.RetrieveSecretValue(workspace.ID, "prod", SecretTypeShared, "/LOG_LEVEL"); err == nil {
+		t.Errorf("secret of the deleted import should not be retrieved")
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"relative import path": func(client *Client) error {
+			_, err := client.CreateSecretImport(workspace.ID, "prod", "dev", "shared", nil)
+			return err
+		},
+		"nothing to update": func(client *Client) error {
+			_, err := client.UpdateSecretImport(workspace.ID, "prod", commonImportID, nil)
+			return err
+		},
+		"invalid position": func(client *Client) error {
+			_, err := client.UpdateSecretImport(workspace.ID, "prod", commonImportID, NewParamsUpdateSecretImport().SetPosition(0))
+			return err
+		},
+		"missing secret import id": func(client *Client) error {
+			_, err := client.DeleteSecretImport(workspace.ID, "prod", "", nil)
+			return err
+		},
+	})
+}
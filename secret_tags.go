@@ -1,9 +1,141 @@
 package infisical
 
-/*
-TODO:
-* Secret Tags
-- [ ] [List](https://infisical.com/docs/api-reference/endpoints/secret-tags/list)
-- [ ] [Create](https://infisical.com/docs/api-reference/endpoints/secret-tags/create)
-- [ ] [Delete](https://infisical.com/docs/api-reference/endpoints/secret-tags/delete)
-*/
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SecretTagsData struct for secret tags response
+type SecretTagsData struct {
+	WorkspaceTags []SecretTag `json:"workspaceTags"`
+}
+
+// SecretTagData struct for secret tag response
+type SecretTagData struct {
+	WorkspaceTag SecretTag `json:"workspaceTag"`
+}
+
+// SecretTag struct for one secret tag
+type SecretTag struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Slug      string  `json:"slug"`
+	Color     *string `json:"color,omitempty"`
+	ProjectID string  `json:"projectId,omitempty"`
+	CreatedAt string  `json:"createdAt,omitempty"`
+	UpdatedAt string  `json:"updatedAt,omitempty"`
+}
+
+// ListSecretTags lists secret tags of given workspace.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-tags/list
+func (c *Client) ListSecretTags(workspaceID string) (result SecretTagsData, err error) {
+	return c.ListSecretTagsWithContext(context.Background(), workspaceID)
+}
+
+// ListSecretTagsWithContext is the same as `ListSecretTags` with given context.
+func (c *Client) ListSecretTagsWithContext(ctx context.Context, workspaceID string) (result SecretTagsData, err error) {
+	if err = validateID("workspace id", workspaceID); err != nil {
+		return SecretTagsData{}, fmt.Errorf("failed to list secret tags: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/workspace/%s/tags", workspaceID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return SecretTagsData{}, fmt.Errorf("failed to list secret tags: %w", err)
+}
+
+// ParamsCreateSecretTag struct for creating a secret tag
+type ParamsCreateSecretTag struct {
+	Color *string `json:"color,omitempty"`
+}
+
+// NewParamsCreateSecretTag returns a new params for creating a secret tag.
+func NewParamsCreateSecretTag() *ParamsCreateSecretTag {
+	return &ParamsCreateSecretTag{}
+}
+
+func (p *ParamsCreateSecretTag) SetColor(color string) *ParamsCreateSecretTag {
+	p.Color = &color
+	return p
+}
+
+// CreateSecretTag creates a secret tag with given name and slug in the workspace.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-tags/create
+func (c *Client) CreateSecretTag(workspaceID, name, slug string, params *ParamsCreateSecretTag) (result SecretTagData, err error) {
+	return c.CreateSecretTagWithContext(context.Background(), workspaceID, name, slug, params)
+}
+
+// CreateSecretTagWithContext is the same as `CreateSecretTag` with given context.
+func (c *Client) CreateSecretTagWithContext(ctx context.Context, workspaceID, name, slug string, params *ParamsCreateSecretTag) (result SecretTagData, err error) {
+	if params == nil {
+		params = NewParamsCreateSecretTag()
+	}
+	errs := []error{validateID("workspace id", workspaceID), validateSlug("tag slug", slug)}
+	if name == "" {
+		errs = append(errs, fmt.Errorf("%w: tag name is missing", ErrInvalidParams))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return SecretTagData{}, fmt.Errorf("failed to create a secret tag: %w", err)
+	}
+
+	// essential parameters
+	body := struct {
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+		*ParamsCreateSecretTag
+	}{name, slug, params}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/workspace/%s/tags", workspaceID), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return SecretTagData{}, fmt.Errorf("failed to create a secret tag: %w", err)
+}
+
+// DeleteSecretTag deletes a secret tag with given id from the workspace.
+//
+// https://infisical.com/docs/api-reference/endpoints/secret-tags/delete
+func (c *Client) DeleteSecretTag(workspaceID, tagID string) (result SecretTagData, err error) {
+	return c.DeleteSecretTagWithContext(context.Background(), workspaceID, tagID)
+}
+
+// DeleteSecretTagWithContext is the same as `DeleteSecretTag` with given context.
+func (c *Client) DeleteSecretTagWithContext(ctx context.Context, workspaceID, tagID string) (result SecretTagData, err error) {
+	if err = errors.Join(validateID("workspace id", workspaceID), validateID("tag id", tagID)); err != nil {
+		return SecretTagData{}, fmt.Errorf("failed to delete a secret tag: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "DELETE", fmt.Sprintf("/v1/workspace/%s/tags/%s", workspaceID, tagID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				// (deleted tag is detached from all secrets of the workspace)
+				c.invalidateWorkspaceCache(workspaceID)
+				return result, nil
+			}
+		}
+	}
+
+	return SecretTagData{}, fmt.Errorf("failed to delete a secret tag: %w", err)
+}
@@ -0,0 +1,107 @@
+package infisical
+
+import (
+	"testing"
+)
+
+func TestSecretTags(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev")
+	server, workspace := target.fakeServer(t), target.workspace
+	server.SetSecret(workspace.ID, "dev", "/", "KEY1", "value1")
+	server.SetSecret(workspace.ID, "dev", "/", "KEY2", "value2")
+	server.SetSecret(workspace.ID, "dev", "/folder", "KEY3", "value3")
+
+	client := target.newClient(t,
+		WithSecretCache(DefaultSecretCachePolicy()),
+	)
+
+	// (create tags)
+	var paymentsTagID string
+	if created, err := client.CreateSecretTag(workspace.ID, "Team Payments", "team-payments", NewParamsCreateSecretTag().SetColor("#ff0000")); err != nil {
+		t.Errorf("failed to create a secret tag: %s", err)
+	} else if created.WorkspaceTag.Slug != "team-payments" || created.WorkspaceTag.Color == nil || *created.WorkspaceTag.Color != "#ff0000" {
+		t.Errorf("unexpected secret tag: %+v", created.WorkspaceTag)
+	} else {
+		paymentsTagID = created.WorkspaceTag.ID
+	}
+	if _, err := client.CreateSecretTag(workspace.ID, "Rotate Quarterly", "rotate-quarterly", nil); err != nil {
+		t.Errorf("failed to create a secret tag: %s", err)
+	}
+
+	// (list tags)
+	if listed, err := client.ListSecretTags(workspace.ID); err != nil {
+		t.Errorf("failed to list secret tags: %s", err)
+	} else if len(listed.WorkspaceTags) != 2 {
+		t.Errorf("unexpected number of secret tags: %d", len(listed.WorkspaceTags))
+	}
+
+	// (attach tags)
+	if attached, err := client.AttachSecretTags(workspace.Slug, "dev", "KEY1", []string{"team-payments", "rotate-quarterly"}, nil); err != nil {
+		t.Errorf("failed to attach tags: %s", err)
+	} else if len(attached.Secret.Tags) != 2 {
+		t.Errorf("unexpected tags of the secret: %+v", attached.Secret.Tags)
+	}
+	if _, err := client.AttachSecretTags(workspace.Slug, "dev", "KEY3", []string{"team-payments"}, NewParamsAttachSecretTags().SetSecretPath("/folder/")); err != nil {
+		t.Errorf("failed to attach tags: %s", err)
+	}
+
+	// (list secrets by tags)
+	params := NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev")
+	if listed, err := client.ListSecrets(params); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	} else if len(listed.Secrets) != 2 {
+		t.Errorf("all secrets should have been listed: %d", len(listed.Secrets))
+	}
+	if listed, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev").SetTagSlugs("team-payments")); err != nil {
+		t.Errorf("failed to list secrets by tags: %s", err)
+	} else if len(listed.Secrets) != 1 || listed.Secrets[0].SecretKey != "KEY1" {
+		t.Errorf("only tagged secrets should have been listed: %+v", listed.Secrets)
+	}
+
+	// (detach tags, invalidating cached secrets)
+	if secret, err := client.RetrieveSecret(workspace.ID, "dev", "KEY1", NewParamsRetrieveSecret().SetType(SecretTypeShared)); err != nil {
+		t.Errorf("failed to retrieve a secret: %s", err)
+	} else if len(secret.Secret.Tags) != 2 {
+		t.Errorf("unexpected tags of the secret: %+v", secret.Secret.Tags)
+	}
+	if _, err := client.DetachSecretTags(workspace.Slug, "dev", "KEY1", []string{"rotate-quarterly"}, nil); err != nil {
+		t.Errorf("failed to detach tags: %s", err)
+	}
+	if secret, err := client.RetrieveSecret(workspace.ID, "dev", "KEY1", NewParamsRetrieveSecret().SetType(SecretTypeShared)); err != nil {
+		t.Errorf("failed to retrieve a secret: %s", err)
+	} else if len(secret.Secret.Tags) != 1 || secret.Secret.Tags[0].Slug != "team-payments" {
+		t.Errorf("detached tag should not be returned: %+v", secret.Secret.Tags)
+	}
+
+	// (delete a tag)
+	if _, err := client.DeleteSecretTag(workspace.ID, paymentsTagID); err != nil {
+		t.Errorf("failed to delete a secret tag: %s", err)
+	}
+	if listed, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev").SetTagSlugs("team-payments")); err != nil {
+		t.Errorf("failed to list secrets by tags: %s", err)
+	} else if len(listed.Secrets) != 0 {
+		t.Errorf("secrets with the deleted tag should not be listed: %+v", listed.Secrets)
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"invalid tag slug": func(client *Client) error {
+			_, err := client.CreateSecretTag(workspace.ID, "Invalid", "Invalid Slug", nil)
+			return err
+		},
+		"missing tag slugs": func(client *Client) error {
+			_, err := client.AttachSecretTags(workspace.Slug, "dev", "KEY1", nil, nil)
+			return err
+		},
+		"missing project slug": func(client *Client) error {
+			_, err := client.DetachSecretTags("", "dev", "KEY1", []string{"team-payments"}, nil)
+			return err
+		},
+		"invalid tag slug filter": func(client *Client) error {
+			_, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev").SetTagSlugs("team-payments", ""))
+			return err
+		},
+	})
+}
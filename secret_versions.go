@@ -0,0 +1,189 @@
+package infisical
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SecretVersionsData struct for secret versions response
+type SecretVersionsData struct {
+	SecretVersions []SecretVersion `json:"secretVersions"`
+}
+
+// SecretVersion struct for a version of a secret
+type SecretVersion struct {
+	ID            string     `json:"id"`
+	SecretID      string     `json:"secretId"`
+	Version       int        `json:"version"`
+	Type          SecretType `json:"type"`
+	SecretKey     string     `json:"secretKey"`
+	SecretValue   string     `json:"secretValue"`
+	SecretComment *string    `json:"secretComment,omitempty"`
+	Environment   string     `json:"environment,omitempty"`
+	Workspace     string     `json:"workspace,omitempty"`
+
+	// who made this version: "user", "identity", or "platform"
+	ActorType       string  `json:"actorType,omitempty"`
+	UserActorID     *string `json:"userActorId,omitempty"`
+	IdentityActorID *string `json:"identityActorId,omitempty"`
+
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// DefaultSecretVersionsLimit is the default number of secret versions in a page.
+const DefaultSecretVersionsLimit = 20
+
+// ParamsListSecretVersions struct for listing versions of a secret
+type ParamsListSecretVersions struct {
+	Offset *int `json:"offset,omitempty"`
+	Limit  *int `json:"limit,omitempty"`
+}
+
+// NewParamsListSecretVersions returns a new params for listing the first page of secret versions.
+func NewParamsListSecretVersions() *ParamsListSecretVersions {
+	return &ParamsListSecretVersions{
+		Offset: ptr(0),
+		Limit:  ptr(DefaultSecretVersionsLimit),
+	}
+}
+
+func (p *ParamsListSecretVersions) SetOffset(offset int) *ParamsListSecretVersions {
+	p.Offset = &offset
+	return p
+}
+
+func (p *ParamsListSecretVersions) SetLimit(limit int) *ParamsListSecretVersions {
+	p.Limit = &limit
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsListSecretVersions) validate() error {
+	errs := []error{}
+	if p.Offset != nil && *p.Offset < 0 {
+		errs = append(errs, fmt.Errorf("%w: offset should not be negative: %d", ErrInvalidParams, *p.Offset))
+	}
+	if p.Limit != nil && *p.Limit < 1 {
+		errs = append(errs, fmt.Errorf("%w: limit should be 1 or greater: %d", ErrInvalidParams, *p.Limit))
+	}
+	return errors.Join(errs...)
+}
+
+// ListSecretVersions lists a page of versions of a secret, from the newest one.
+func (c *Client) ListSecretVersions(secretID string, params *ParamsListSecretVersions) (result SecretVersionsData, err error) {
+	return c.ListSecretVersionsWithContext(context.Background(), secretID, params)
+}
+
+// ListSecretVersionsWithContext is the same as `ListSecretVersions` with given context.
+func (c *Client) ListSecretVersionsWithContext(ctx context.Context, secretID string, params *ParamsListSecretVersions) (result SecretVersionsData, err error) {
+	if params == nil {
+		params = NewParamsListSecretVersions()
+	}
+	if err = errors.Join(validateID("secret id", secretID), params.validate()); err != nil {
+		return SecretVersionsData{}, fmt.Errorf("failed to list secret versions: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/secret/%s/secret-versions", secretID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return SecretVersionsData{}, fmt.Errorf("failed to list secret versions: %w", err)
+}
+
+// ListAllSecretVersions lists all versions of a secret, from the newest one, paging through them.
+//
+// `Offset` and `Limit` of `params` are used as the first offset and the size of each page.
+//
+// Just a helper function for `ListSecretVersions`.
+func (c *Client) ListAllSecretVersions(secretID string, params *ParamsListSecretVersions) (versions []SecretVersion, err error) {
+	return c.ListAllSecretVersionsWithContext(context.Background(), secretID, params)
+}
+
+// ListAllSecretVersionsWithContext is the same as `ListAllSecretVersions` with given context.
+func (c *Client) ListAllSecretVersionsWithContext(ctx context.Context, secretID string, params *ParamsListSecretVersions) (versions []SecretVersion, err error) {
+	page := NewParamsListSecretVersions()
+	if params != nil {
+		if params.Offset != nil {
+			page.Offset = ptr(*params.Offset)
+		}
+		if params.Limit != nil {
+			page.Limit = ptr(*params.Limit)
+		}
+	}
+
+	versions = []SecretVersion{}
+	for {
+		var result SecretVersionsData
+		if result, err = c.ListSecretVersionsWithContext(ctx, secretID, page); err != nil {
+			return versions, err
+		}
+		versions = append(versions, result.SecretVersions...)
+
+		if len(result.SecretVersions) < *page.Limit {
+			return versions, nil
+		}
+		page.Offset = ptr(*page.Offset + len(result.SecretVersions))
+	}
+}
+
+// RevertSecretToVersion updates a secret at given path with the value of its earlier version, and returns that version.
+//
+// The secret is updated with its current key, so it can be reverted even after being renamed.
+// `secretPath` takes precedence over `SecretPath` of `params`, and the current comment is kept unless `SecretComment` of `params` is set.
+//
+// Just a helper function for `ListAllSecretVersions` and `UpdateSecret`.
+func (c *Client) RevertSecretToVersion(workspaceID, environment, secretPath, secretID string, version int, params *ParamsUpdateSecret) (reverted SecretVersion, err error) {
+	return c.RevertSecretToVersionWithContext(context.Background(), workspaceID, environment, secretPath, secretID, version, params)
+}
+
+// RevertSecretToVersionWithContext is the same as `RevertSecretToVersion` with given context.
+func (c *Client) RevertSecretToVersionWithContext(ctx context.Context, workspaceID, environment, secretPath, secretID string, version int, params *ParamsUpdateSecret) (reverted SecretVersion, err error) {
+	// copy params, not to alter the given one
+	copied := NewParamsUpdateSecret()
+	if params != nil {
+		*copied = *params
+	}
+	copied.SetSecretPath(secretPath)
+	errs := []error{validateWorkspace(workspaceID, environment), validateID("secret id", secretID), copied.validate()}
+	if version < 1 {
+		errs = append(errs, fmt.Errorf("%w: version should be 1 or greater: %d", ErrInvalidParams, version))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return SecretVersion{}, fmt.Errorf("failed to revert a secret: %w", err)
+	}
+
+	var versions []SecretVersion
+	if versions, err = c.ListAllSecretVersionsWithContext(ctx, secretID, nil); err != nil {
+		return SecretVersion{}, fmt.Errorf("failed to revert a secret: %w", err)
+	}
+	found := false
+	for _, v := range versions {
+		if v.Version == version {
+			reverted, found = v, true
+			break
+		}
+	}
+	if !found {
+		return SecretVersion{}, fmt.Errorf("failed to revert a secret: %w: version %d of secret '%s'", ErrNotFound, version, secretID)
+	}
+
+	if reverted.Type != "" {
+		copied.Type = ptr(reverted.Type)
+	}
+
+	// (versions are listed from the newest one, so the first one has the current key)
+	if err = c.UpdateSecretWithContext(ctx, workspaceID, environment, versions[0].SecretKey, reverted.SecretValue, copied); err != nil {
+		return SecretVersion{}, fmt.Errorf("failed to revert a secret: %w", err)
+	}
+	return reverted, nil
+}
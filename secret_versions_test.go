@@ -0,0 +1,126 @@
+package infisical
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSecretVersions(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+	server.SetSecret(workspace.ID, "prod", "/", "API_KEY", "first-key")
+	server.AddFolder(workspace.ID, "prod", "/", "folder")
+	server.SetSecret(workspace.ID, "prod", "/folder", "API_KEY", "first-folder-key")
+
+	client := target.newClient(t)
+
+	// (versions are added with each update)
+	for _, value := range []string{"second-key", "leaked-key"} {
+		if err := client.UpdateSecret(workspace.ID, "prod", "API_KEY", value, NewParamsUpdateSecret().SetSecretComment("rotated")); err != nil {
+			t.Fatalf("failed to update a secret: %s", err)
+		}
+	}
+	retrieved, err := client.RetrieveSecret(workspace.ID, "prod", "API_KEY", nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve a secret: %s", err)
+	}
+	secretID := retrieved.Secret.ID
+
+	// (list versions, from the newest one)
+	if versions, err := client.ListSecretVersions(secretID, nil); err != nil {
+		t.Errorf("failed to list secret versions: %s", err)
+	} else if len(versions.SecretVersions) != 3 {
+		t.Errorf("unexpected number of secret versions: %d", len(versions.SecretVersions))
+	} else {
+		for i, expected := range []string{"leaked-key", "second-key", "first-key"} {
+			if v := versions.SecretVersions[i]; v.SecretValue != expected || v.Version != 3-i || v.CreatedAt == "" {
+				t.Errorf("unexpected secret version at %d: %+v", i, v)
+			}
+		}
+		if newest := versions.SecretVersions[0]; newest.ActorType != "identity" || newest.IdentityActorID == nil || deref(newest.SecretComment) != "rotated" {
+			t.Errorf("unexpected actor or comment of the newest version: %+v", newest)
+		}
+		if oldest := versions.SecretVersions[2]; oldest.ActorType != "platform" || oldest.SecretComment != nil {
+			t.Errorf("unexpected actor or comment of the oldest version: %+v", oldest)
+		}
+	}
+
+	// (list all versions with small pages)
+	if versions, err := client.ListAllSecretVersions(secretID, NewParamsListSecretVersions().SetLimit(2)); err != nil {
+		t.Errorf("failed to list all secret versions: %s", err)
+	} else if len(versions) != 3 {
+		t.Errorf("unexpected number of secret versions: %d", len(versions))
+	}
+
+	// (revert to an earlier version)
+	if reverted, err := client.RevertSecretToVersion(workspace.ID, "prod", "/", secretID, 1, nil); err != nil {
+		t.Errorf("failed to revert a secret: %s", err)
+	} else if reverted.Version != 1 || reverted.SecretValue != "first-key" {
+		t.Errorf("unexpected reverted version: %+v", reverted)
+	}
+	if value, exists := server.Secret(workspace.ID, "prod", "/", "API_KEY"); !exists || value != "first-key" {
+		t.Errorf("secret should have been reverted: %s", value)
+	}
+	if versions, err := client.ListSecretVersions(secretID, nil); err != nil {
+		t.Errorf("failed to list secret versions: %s", err)
+	} else if len(versions.SecretVersions) != 4 ||
+		versions.SecretVersions[0].Version != 4 ||
+		deref(versions.SecretVersions[0].SecretComment) != "rotated" {
+		t.Errorf("revert should be added as a new version with the current comment: %+v", versions.SecretVersions)
+	}
+
+	// (revert a secret in a folder, with a comment)
+	if err := client.UpdateSecret(workspace.ID, "prod", "API_KEY", "second-folder-key", NewParamsUpdateSecret().SetSecretPath("/folder")); err != nil {
+		t.Fatalf("failed to update a secret: %s", err)
+	}
+	if retrieved, err := client.RetrieveSecret(workspace.ID, "prod", "API_KEY", NewParamsRetrieveSecret().SetSecretPath("/folder")); err != nil {
+		t.Errorf("failed to retrieve a secret: %s", err)
+	} else if _, err := client.RevertSecretToVersion(workspace.ID, "prod", "/folder", retrieved.Secret.ID, 1, NewParamsUpdateSecret().SetSecretComment("reverted")); err != nil {
+		t.Errorf("failed to revert a secret in a folder: %s", err)
+	} else if versions, err := client.ListSecretVersions(retrieved.Secret.ID, nil); err != nil {
+		t.Errorf("failed to list secret versions: %s", err)
+	} else if len(versions.SecretVersions) != 3 || deref(versions.SecretVersions[0].SecretComment) != "reverted" {
+		t.Errorf("revert should be added as a new version with the given comment: %+v", versions.SecretVersions)
+	}
+	if value, exists := server.Secret(workspace.ID, "prod", "/folder", "API_KEY"); !exists || value != "first-folder-key" {
+		t.Errorf("secret in a folder should have been reverted: %s", value)
+	}
+	if value, exists := server.Secret(workspace.ID, "prod", "/", "API_KEY"); !exists || value != "first-key" {
+		t.Errorf("secret at the root path should not have been changed: %s", value)
+	}
+
+	// (versions which do not exist)
+	if _, err := client.RevertSecretToVersion(workspace.ID, "prod", "/", secretID, 99, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("reverting to a missing version should fail with `ErrNotFound`: %v", err)
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing secret id": func(client *Client) error {
+			_, err := client.ListSecretVersions("", nil)
+			return err
+		},
+		"negative offset": func(client *Client) error {
+			_, err := client.ListSecretVersions(secretID, NewParamsListSecretVersions().SetOffset(-1))
+			return err
+		},
+		"invalid limit": func(client *Client) error {
+			_, err := client.ListAllSecretVersions(secretID, NewParamsListSecretVersions().SetLimit(0))
+			return err
+		},
+		"invalid version": func(client *Client) error {
+			_, err := client.RevertSecretToVersion(workspace.ID, "prod", "/", secretID, 0, nil)
+			return err
+		},
+		"missing environment": func(client *Client) error {
+			_, err := client.RevertSecretToVersion(workspace.ID, "", "/", secretID, 1, nil)
+			return err
+		},
+		"missing secret path": func(client *Client) error {
+			_, err := client.RevertSecretToVersion(workspace.ID, "prod", "", secretID, 1, nil)
+			return err
+		},
+	})
+}
@@ -1,6 +1,8 @@
 package infisical
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -23,43 +25,71 @@ type SecretImport struct {
 
 // Secret struct for one secret
 type Secret struct {
-	ID_           string     `json:"_id"`
-	Environment   string     `json:"environment"`
-	ID            string     `json:"id"`
-	SecretComment *string    `json:"secretComment,omitempty"`
-	SecretKey     string     `json:"secretKey"`
-	SecretValue   string     `json:"secretValue"`
-	Type          SecretType `json:"type"`
-	Version       int        `json:"version"`
-	Workspace     string     `json:"workspace"`
+	ID_           string      `json:"_id"`
+	Environment   string      `json:"environment"`
+	ID            string      `json:"id"`
+	SecretComment *string     `json:"secretComment,omitempty"`
+	SecretKey     string      `json:"secretKey"`
+	SecretValue   string      `json:"secretValue"`
+	Type          SecretType  `json:"type"`
+	Version       int         `json:"version"`
+	Workspace     string      `json:"workspace"`
+	Tags          []SecretTag `json:"tags,omitempty"`
 }
 
-type ParamsListSecrets map[string]any
+// ParamsListSecrets struct for listing secrets
+type ParamsListSecrets struct {
+	WorkspaceID    *string `json:"workspaceId,omitempty"`
+	Environment    *string `json:"environment,omitempty"`
+	SecretPath     *string `json:"secretPath,omitempty"`
+	IncludeImports *bool   `json:"include_imports,omitempty"`
+	TagSlugs       *string `json:"tagSlugs,omitempty"` // comma-separated slugs of tags
+}
+
+// NewParamsListSecrets returns a new params for listing secrets.
+func NewParamsListSecrets() *ParamsListSecrets {
+	return &ParamsListSecrets{}
+}
 
-func NewParamsListSecrets() ParamsListSecrets {
-	return ParamsListSecrets{}
+func (p *ParamsListSecrets) SetWorkspaceID(workspaceID string) *ParamsListSecrets {
+	p.WorkspaceID = &workspaceID
+	return p
 }
 
-func (p ParamsListSecrets) SetWorkspaceID(workspaceID string) ParamsListSecrets {
-	p["workspaceId"] = workspaceID
+func (p *ParamsListSecrets) SetEnvironment(environment string) *ParamsListSecrets {
+	p.Environment = &environment
 	return p
 }
 
-func (p ParamsListSecrets) SetEnvironment(environment string) ParamsListSecrets {
-	p["environment"] = environment
+func (p *ParamsListSecrets) SetSecretPath(secretPath string) *ParamsListSecrets {
+	p.SecretPath = &secretPath
 	return p
 }
 
-func (p ParamsListSecrets) SetSecretPath(secretPath string) ParamsListSecrets {
-	p["secretPath"] = secretPath
+func (p *ParamsListSecrets) SetIncludeImports(includeImports bool) *ParamsListSecrets {
+	p.IncludeImports = &includeImports
 	return p
 }
 
-func (p ParamsListSecrets) SetIncludeImports(includeImports bool) ParamsListSecrets {
-	p["include_imports"] = includeImports
+func (p *ParamsListSecrets) SetTagSlugs(tagSlugs ...string) *ParamsListSecrets {
+	p.TagSlugs = ptr(strings.Join(tagSlugs, ","))
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsListSecrets) validate() error {
+	if err := validateWorkspace(deref(p.WorkspaceID), deref(p.Environment)); err != nil {
+		return err
+	}
+	errs := []error{validatePath(p.SecretPath)}
+	if p.TagSlugs != nil {
+		for _, slug := range strings.Split(*p.TagSlugs, ",") {
+			errs = append(errs, validateSlug("tag slug", slug))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // SecretsData struct for secrets response
 type SecretsData struct {
 	Imports []SecretImport `json:"imports"`
@@ -69,109 +99,178 @@ type SecretsData struct {
 // ListSecrets lists all secrets for given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/secrets/list
-func (c *Client) ListSecrets(params ParamsListSecrets) (result SecretsData, err error) {
+func (c *Client) ListSecrets(params *ParamsListSecrets) (result SecretsData, err error) {
+	return c.ListSecretsWithContext(context.Background(), params)
+}
+
+// ListSecretsWithContext is the same as `ListSecrets` with given context.
+func (c *Client) ListSecretsWithContext(ctx context.Context, params *ParamsListSecrets) (result SecretsData, err error) {
 	if params == nil {
 		params = NewParamsListSecrets()
 	}
+	if err = params.validate(); err != nil {
+		return SecretsData{}, fmt.Errorf("failed to list secrets: %w", err)
+	}
 
+	// cached secrets
+	key := secretCacheKey{
+		workspaceID:    deref(params.WorkspaceID),
+		environment:    deref(params.Environment),
+		secretPath:     normalizeSecretPath(deref(params.SecretPath)),
+		includeImports: deref(params.IncludeImports),
+		tagSlugs:       deref(params.TagSlugs),
+	}
+	if cached, found := c.secretCache.lookup(key); found {
+		return cloneSecretsData(cached.(SecretsData)), nil
+	}
+
+	if result, err = c.fetchSecrets(ctx, params); err == nil {
+		c.secretCache.store(key, cloneSecretsData(result))
+		c.storeOfflineList(ctx, key, result)
+		return result, nil
+	}
+
+	// stale secrets
+	if stale, found := c.secretCache.lookupStale(key, err); found {
+		c.logStale(ctx, key, err)
+		return cloneSecretsData(stale.(SecretsData)), nil
+	}
+	if offline, found := c.loadOfflineList(ctx, key, err); found {
+		return offline, nil
+	}
+
+	return SecretsData{}, fmt.Errorf("failed to list secrets: %w", err)
+}
+
+// fetchSecrets lists secrets from the server, bypassing the secret cache and the offline cache.
+func (c *Client) fetchSecrets(ctx context.Context, params *ParamsListSecrets) (result SecretsData, err error) {
 	var req *http.Request
-	req, err = c.newRequestWithQueryParams("GET", "/v3/secrets/raw", AuthMethodNormal, params)
+	req, err = c.newRequestWithQueryParams(ctx, "GET", "/v3/secrets/raw", AuthMethodNormal, params)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
-			if err = c.parseResponse(res, &result); err == nil {
-				return result, nil
-			}
+		if res, err = c.do(req); err == nil {
+			err = c.parseResponse(res, &result)
 		}
 	}
-
-	return SecretsData{}, fmt.Errorf("failed to list secrets: %s", err)
+	return result, err
 }
 
-type ParamsCreateSecret map[string]any
+// ParamsCreateSecret struct for creating a secret
+type ParamsCreateSecret struct {
+	SecretPath    *string     `json:"secretPath,omitempty"`
+	Type          *SecretType `json:"type,omitempty"`
+	SecretComment *string     `json:"secretComment,omitempty"`
+}
 
-func NewParamsCreateSecret() ParamsCreateSecret {
-	return ParamsCreateSecret{
-		"secretPath": "/",
-		"type":       SecretTypeShared,
+// NewParamsCreateSecret returns a new params for creating a shared secret at the root path.
+func NewParamsCreateSecret() *ParamsCreateSecret {
+	return &ParamsCreateSecret{
+		SecretPath: ptr("/"),
+		Type:       ptr(SecretTypeShared),
 	}
 }
 
-func (p ParamsCreateSecret) SetSecretComment(secretComment string) ParamsCreateSecret {
-	p["secretComment"] = secretComment
+func (p *ParamsCreateSecret) SetSecretComment(secretComment string) *ParamsCreateSecret {
+	p.SecretComment = &secretComment
 	return p
 }
 
-func (p ParamsCreateSecret) SetSecretPath(secretPath string) ParamsCreateSecret {
+func (p *ParamsCreateSecret) SetSecretPath(secretPath string) *ParamsCreateSecret {
 	if secretPath != "/" {
 		secretPath = strings.TrimSuffix(secretPath, "/")
 	}
-	p["secretPath"] = secretPath
+	p.SecretPath = &secretPath
 	return p
 }
 
-func (p ParamsCreateSecret) SetType(typ SecretType) ParamsCreateSecret {
-	p["type"] = typ
+func (p *ParamsCreateSecret) SetType(typ SecretType) *ParamsCreateSecret {
+	p.Type = &typ
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsCreateSecret) validate() error {
+	if err := validatePath(p.SecretPath); err != nil {
+		return err
+	}
+	return validateSecretType(p.Type)
+}
+
 // CreateSecret creates a secret with given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/secrets/create
-func (c *Client) CreateSecret(workspaceID, environment, secretKey, secretValue string, params ParamsCreateSecret) (err error) {
+func (c *Client) CreateSecret(workspaceID, environment, secretKey, secretValue string, params *ParamsCreateSecret) (err error) {
+	return c.CreateSecretWithContext(context.Background(), workspaceID, environment, secretKey, secretValue, params)
+}
+
+// CreateSecretWithContext is the same as `CreateSecret` with given context.
+func (c *Client) CreateSecretWithContext(ctx context.Context, workspaceID, environment, secretKey, secretValue string, params *ParamsCreateSecret) (err error) {
 	if params == nil {
 		params = NewParamsCreateSecret()
 	}
-
-	// set essential params
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
-	params["secretValue"] = secretValue
-
-	var req *http.Request
-	req, err = c.newRequestWithJSONBody("POST", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, params)
-	if err != nil {
-		return err
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateSecretKey(secretKey), params.validate()); err != nil {
+		return fmt.Errorf("failed to create a secret: %w", err)
 	}
 
-	c.dumpRequest(req)
-
-	var res *http.Response
-	if res, err = c.httpClient.Do(req); err == nil {
-		c.dumpResponse(res)
+	// essential params
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		SecretValue string `json:"secretValue"`
+		*ParamsCreateSecret
+	}{workspaceID, environment, secretValue, params}
 
-		return c.parseResponse(res, nil)
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, nil); err == nil {
+				c.secretCache.invalidateSecret(workspaceID, environment, deref(params.SecretPath), secretKey)
+				c.invalidateOfflineSecrets(ctx, workspaceID, environment, deref(params.SecretPath), secretKey)
+				return nil
+			}
+		}
 	}
 
-	return err
+	return fmt.Errorf("failed to create a secret: %w", err)
 }
 
-type ParamsRetrieveSecret map[string]any
+// ParamsRetrieveSecret struct for retrieving a secret
+type ParamsRetrieveSecret struct {
+	SecretPath *string     `json:"secretPath,omitempty"`
+	Type       *SecretType `json:"type,omitempty"`
+}
 
-func NewParamsRetrieveSecret() ParamsRetrieveSecret {
-	return ParamsRetrieveSecret{
-		"secretPath": "/",
-		"type":       SecretTypePersonal,
+// NewParamsRetrieveSecret returns a new params for retrieving a personal (or shared if missing) secret at the root path.
+func NewParamsRetrieveSecret() *ParamsRetrieveSecret {
+	return &ParamsRetrieveSecret{
+		SecretPath: ptr("/"),
+		Type:       ptr(SecretTypePersonal),
 	}
 }
 
-func (p ParamsRetrieveSecret) SetSecretPath(secretPath string) ParamsRetrieveSecret {
+func (p *ParamsRetrieveSecret) SetSecretPath(secretPath string) *ParamsRetrieveSecret {
 	if secretPath != "/" {
 		secretPath = strings.TrimSuffix(secretPath, "/")
 	}
-	p["secretPath"] = secretPath
+	p.SecretPath = &secretPath
 	return p
 }
 
-func (p ParamsRetrieveSecret) SetType(typ SecretType) ParamsRetrieveSecret {
-	p["type"] = typ
+func (p *ParamsRetrieveSecret) SetType(typ SecretType) *ParamsRetrieveSecret {
+	p.Type = &typ
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsRetrieveSecret) validate() error {
+	if err := validatePath(p.SecretPath); err != nil {
+		return err
+	}
+	return validateSecretType(p.Type)
+}
+
 // SecretData struct for secret response
 type SecretData struct {
 	Secret Secret `json:"secret"`
@@ -180,31 +279,61 @@ type SecretData struct {
 // RetrieveSecret retrieves a secret for given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/secrets/read
-func (c *Client) RetrieveSecret(workspaceID, environment, secretKey string, params ParamsRetrieveSecret) (result SecretData, err error) {
+func (c *Client) RetrieveSecret(workspaceID, environment, secretKey string, params *ParamsRetrieveSecret) (result SecretData, err error) {
+	return c.RetrieveSecretWithContext(context.Background(), workspaceID, environment, secretKey, params)
+}
+
+// RetrieveSecretWithContext is the same as `RetrieveSecret` with given context.
+func (c *Client) RetrieveSecretWithContext(ctx context.Context, workspaceID, environment, secretKey string, params *ParamsRetrieveSecret) (result SecretData, err error) {
 	if params == nil {
 		params = NewParamsRetrieveSecret()
 	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateSecretKey(secretKey), params.validate()); err != nil {
+		return SecretData{}, fmt.Errorf("failed to retrieve secret: %w", err)
+	}
 
-	// set essential params
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
+	// cached secret
+	key := secretCacheKey{
+		workspaceID: workspaceID,
+		environment: environment,
+		secretPath:  normalizeSecretPath(deref(params.SecretPath)),
+		secretType:  deref(params.Type),
+		secretKey:   secretKey,
+	}
+	if cached, found := c.secretCache.lookup(key); found {
+		return SecretData{Secret: cloneSecret(cached.(SecretData).Secret)}, nil
+	}
+
+	// essential params
+	query := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		*ParamsRetrieveSecret
+	}{workspaceID, environment, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithQueryParams("GET", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, params)
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, query)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
+				c.secretCache.store(key, SecretData{Secret: cloneSecret(result.Secret)})
+				c.storeOfflineSecret(ctx, key, result.Secret)
 				return result, nil
 			}
 		}
 	}
 
-	return SecretData{}, fmt.Errorf("failed to retrieve secret: %s", err)
+	// stale secret
+	if stale, found := c.secretCache.lookupStale(key, err); found {
+		c.logStale(ctx, key, err)
+		return SecretData{Secret: cloneSecret(stale.(SecretData).Secret)}, nil
+	}
+	if offline, found := c.loadOfflineSecret(ctx, key, err); found {
+		return SecretData{Secret: offline}, nil
+	}
+
+	return SecretData{}, fmt.Errorf("failed to retrieve secret: %w", err)
 }
 
 // RetrieveSecretValue retrieves a secret value for given path + key.
@@ -213,137 +342,494 @@ func (c *Client) RetrieveSecret(workspaceID, environment, secretKey string, para
 //
 // `secretKeyWithPath` is in form of: "/folder1/folder2/.../secret_key_name"
 func (c *Client) RetrieveSecretValue(workspaceID, environment string, secretType SecretType, secretKeyWithPath string) (value string, err error) {
+	return c.RetrieveSecretValueWithContext(context.Background(), workspaceID, environment, secretType, secretKeyWithPath)
+}
+
+// RetrieveSecretValueWithContext is the same as `RetrieveSecretValue` with given context.
+func (c *Client) RetrieveSecretValueWithContext(ctx context.Context, workspaceID, environment string, secretType SecretType, secretKeyWithPath string) (value string, err error) {
 	// secretKeyWithPath => secretKey + secretPath
 	splitted := strings.Split(secretKeyWithPath, "/")
 	secretKey := splitted[len(splitted)-1]
 	secretPath := strings.TrimSuffix(secretKeyWithPath, secretKey)
+	if secretPath == "" {
+		secretPath = "/"
+	}
 
 	params := NewParamsRetrieveSecret().
 		SetSecretPath(secretPath).
 		SetType(secretType)
 
 	var retrieved SecretData
-	if retrieved, err = c.RetrieveSecret(workspaceID, environment, secretKey, params); err == nil {
+	if retrieved, err = c.RetrieveSecretWithContext(ctx, workspaceID, environment, secretKey, params); err == nil {
 		return retrieved.Secret.SecretValue, nil
 	}
 
-	return "", fmt.Errorf("failed to retrieve secret value for key path '%s': %s", secretKeyWithPath, err)
+	return "", fmt.Errorf("failed to retrieve secret value for key path '%s': %w", secretKeyWithPath, err)
 }
 
-type ParamsUpdateSecret map[string]any
+// ParamsUpdateSecret struct for updating a secret
+type ParamsUpdateSecret struct {
+	SecretPath    *string     `json:"secretPath,omitempty"`
+	Type          *SecretType `json:"type,omitempty"`
+	SecretComment *string     `json:"secretComment,omitempty"`
+}
 
-func NewParamsUpdateSecret() ParamsUpdateSecret {
-	return ParamsUpdateSecret{
-		"secretPath": "/",
-		"type":       SecretTypeShared,
+// NewParamsUpdateSecret returns a new params for updating a shared secret at the root path.
+func NewParamsUpdateSecret() *ParamsUpdateSecret {
+	return &ParamsUpdateSecret{
+		SecretPath: ptr("/"),
+		Type:       ptr(SecretTypeShared),
 	}
 }
 
-func (p ParamsUpdateSecret) SetSecretPath(secretPath string) ParamsUpdateSecret {
+func (p *ParamsUpdateSecret) SetSecretPath(secretPath string) *ParamsUpdateSecret {
 	if secretPath != "/" {
 		secretPath = strings.TrimSuffix(secretPath, "/")
 	}
-	p["secretPath"] = secretPath
+	p.SecretPath = &secretPath
 	return p
 }
 
-func (p ParamsUpdateSecret) SetType(typ SecretType) ParamsUpdateSecret {
-	p["type"] = typ
+func (p *ParamsUpdateSecret) SetType(typ SecretType) *ParamsUpdateSecret {
+	p.Type = &typ
 	return p
 }
 
-func (p ParamsUpdateSecret) SetSecretComment(comment string) ParamsUpdateSecret {
-	p["secretComment"] = comment
+func (p *ParamsUpdateSecret) SetSecretComment(comment string) *ParamsUpdateSecret {
+	p.SecretComment = &comment
 	return p
 }
 
+// validate checks the params.
+func (p *ParamsUpdateSecret) validate() error {
+	if err := validatePath(p.SecretPath); err != nil {
+		return err
+	}
+	return validateSecretType(p.Type)
+}
+
 // UpdateSecret updates a secret with given parameters.
 //
 // https://infisical.com/docs/api-reference/endpoints/secrets/update
-func (c *Client) UpdateSecret(workspaceID, environment, secretKey, secretValue string, params ParamsUpdateSecret) (err error) {
+func (c *Client) UpdateSecret(workspaceID, environment, secretKey, secretValue string, params *ParamsUpdateSecret) (err error) {
+	return c.UpdateSecretWithContext(context.Background(), workspaceID, environment, secretKey, secretValue, params)
+}
+
+// UpdateSecretWithContext is the same as `UpdateSecret` with given context.
+func (c *Client) UpdateSecretWithContext(ctx context.Context, workspaceID, environment, secretKey, secretValue string, params *ParamsUpdateSecret) (err error) {
 	if params == nil {
 		params = NewParamsUpdateSecret()
 	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateSecretKey(secretKey), params.validate()); err != nil {
+		return fmt.Errorf("failed to update a secret: %w", err)
+	}
 
-	// set essential params
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
-	params["secretValue"] = secretValue
+	// essential params
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		SecretValue string `json:"secretValue"`
+		*ParamsUpdateSecret
+	}{workspaceID, environment, secretValue, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithJSONBody("PATCH", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, params)
-	if err != nil {
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, nil); err == nil {
+				c.secretCache.invalidateSecret(workspaceID, environment, deref(params.SecretPath), secretKey)
+				c.invalidateOfflineSecrets(ctx, workspaceID, environment, deref(params.SecretPath), secretKey)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("failed to update a secret: %w", err)
+}
+
+// ParamsDeleteSecret struct for deleting a secret
+type ParamsDeleteSecret struct {
+	SecretPath *string     `json:"secretPath,omitempty"`
+	Type       *SecretType `json:"type,omitempty"`
+}
+
+// NewParamsDeleteSecret returns a new params for deleting a personal secret at the root path.
+func NewParamsDeleteSecret() *ParamsDeleteSecret {
+	return &ParamsDeleteSecret{
+		SecretPath: ptr("/"),
+		Type:       ptr(SecretTypePersonal),
+	}
+}
+
+func (p *ParamsDeleteSecret) SetSecretPath(secretPath string) *ParamsDeleteSecret {
+	if secretPath != "/" {
+		secretPath = strings.TrimSuffix(secretPath, "/")
+	}
+	p.SecretPath = &secretPath
+	return p
+}
+
+func (p *ParamsDeleteSecret) SetType(typ SecretType) *ParamsDeleteSecret {
+	p.Type = &typ
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsDeleteSecret) validate() error {
+	if err := validatePath(p.SecretPath); err != nil {
 		return err
 	}
+	return validateSecretType(p.Type)
+}
 
-	c.dumpRequest(req)
+// DeleteSecret deletes a secret for given parameters.
+//
+// https://infisical.com/docs/api-reference/endpoints/secrets/delete
+func (c *Client) DeleteSecret(workspaceID, environment, secretKey string, params *ParamsDeleteSecret) (err error) {
+	return c.DeleteSecretWithContext(context.Background(), workspaceID, environment, secretKey, params)
+}
 
-	var res *http.Response
-	if res, err = c.httpClient.Do(req); err == nil {
-		c.dumpResponse(res)
+// DeleteSecretWithContext is the same as `DeleteSecret` with given context.
+func (c *Client) DeleteSecretWithContext(ctx context.Context, workspaceID, environment, secretKey string, params *ParamsDeleteSecret) (err error) {
+	if params == nil {
+		params = NewParamsDeleteSecret()
+	}
+	if err = errors.Join(validateWorkspace(workspaceID, environment), validateSecretKey(secretKey), params.validate()); err != nil {
+		return fmt.Errorf("failed to delete a secret: %w", err)
+	}
 
-		return c.parseResponse(res, nil)
+	// essential params
+	body := struct {
+		WorkspaceID string `json:"workspaceId"`
+		Environment string `json:"environment"`
+		*ParamsDeleteSecret
+	}{workspaceID, environment, params}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "DELETE", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, body)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, nil); err == nil {
+				c.secretCache.invalidateSecret(workspaceID, environment, deref(params.SecretPath), secretKey)
+				c.invalidateOfflineSecrets(ctx, workspaceID, environment, deref(params.SecretPath), secretKey)
+				return nil
+			}
+		}
 	}
 
-	return err
+	return fmt.Errorf("failed to delete a secret: %w", err)
 }
 
-type ParamsDeleteSecret map[string]any
+// ParamsAttachSecretTags struct for attaching tags to a secret
+type ParamsAttachSecretTags struct {
+	SecretPath *string     `json:"secretPath,omitempty"`
+	Type       *SecretType `json:"type,omitempty"`
+}
 
-func NewParamsDeleteSecret() ParamsDeleteSecret {
-	return ParamsDeleteSecret{
-		"secretPath": "/",
-		"type":       SecretTypePersonal,
+// NewParamsAttachSecretTags returns a new params for attaching tags to a shared secret at the root path.
+func NewParamsAttachSecretTags() *ParamsAttachSecretTags {
+	return &ParamsAttachSecretTags{
+		SecretPath: ptr("/"),
+		Type:       ptr(SecretTypeShared),
 	}
 }
 
-func (p ParamsDeleteSecret) SetSecretPath(secretPath string) ParamsDeleteSecret {
+func (p *ParamsAttachSecretTags) SetSecretPath(secretPath string) *ParamsAttachSecretTags {
 	if secretPath != "/" {
 		secretPath = strings.TrimSuffix(secretPath, "/")
 	}
-	p["secretPath"] = secretPath
+	p.SecretPath = &secretPath
 	return p
 }
 
-func (p ParamsDeleteSecret) SetType(typ SecretType) ParamsDeleteSecret {
-	p["type"] = typ
+func (p *ParamsAttachSecretTags) SetType(typ SecretType) *ParamsAttachSecretTags {
+	p.Type = &typ
 	return p
 }
 
-// DeleteSecret deletes a secret for given parameters.
+// validate checks the params.
+func (p *ParamsAttachSecretTags) validate() error {
+	return errors.Join(validatePath(p.SecretPath), validateSecretType(p.Type))
+}
+
+// AttachSecretTags attaches tags with given slugs to a secret.
 //
-// https://infisical.com/docs/api-reference/endpoints/secrets/delete
-func (c *Client) DeleteSecret(workspaceID, environment, secretKey string, params ParamsDeleteSecret) (err error) {
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/secrets/attach-tags
+func (c *Client) AttachSecretTags(projectSlug, environment, secretKey string, tagSlugs []string, params *ParamsAttachSecretTags) (result SecretData, err error) {
+	return c.AttachSecretTagsWithContext(context.Background(), projectSlug, environment, secretKey, tagSlugs, params)
+}
+
+// AttachSecretTagsWithContext is the same as `AttachSecretTags` with given context.
+func (c *Client) AttachSecretTagsWithContext(ctx context.Context, projectSlug, environment, secretKey string, tagSlugs []string, params *ParamsAttachSecretTags) (result SecretData, err error) {
 	if params == nil {
-		params = NewParamsDeleteSecret()
+		params = NewParamsAttachSecretTags()
 	}
+	if result, err = c.sendSecretTags(ctx, "POST", projectSlug, environment, secretKey, tagSlugs, params); err != nil {
+		return SecretData{}, fmt.Errorf("failed to attach tags to a secret: %w", err)
+	}
+	return result, nil
+}
 
-	// set essential params
-	params["workspaceId"] = workspaceID
-	params["environment"] = environment
+// ParamsDetachSecretTags struct for detaching tags from a secret
+type ParamsDetachSecretTags = ParamsAttachSecretTags
+
+// NewParamsDetachSecretTags returns a new params for detaching tags from a shared secret at the root path.
+func NewParamsDetachSecretTags() *ParamsDetachSecretTags {
+	return NewParamsAttachSecretTags()
+}
+
+// DetachSecretTags detaches tags with given slugs from a secret.
+//
+// NOTE: the project is identified with its slug, not its id.
+//
+// https://infisical.com/docs/api-reference/endpoints/secrets/detach-tags
+func (c *Client) DetachSecretTags(projectSlug, environment, secretKey string, tagSlugs []string, params *ParamsDetachSecretTags) (result SecretData, err error) {
+	return c.DetachSecretTagsWithContext(context.Background(), projectSlug, environment, secretKey, tagSlugs, params)
+}
+
+// DetachSecretTagsWithContext is the same as `DetachSecretTags` with given context.
+func (c *Client) DetachSecretTagsWithContext(ctx context.Context, projectSlug, environment, secretKey string, tagSlugs []string, params *ParamsDetachSecretTags) (result SecretData, err error) {
+	if params == nil {
+		params = NewParamsDetachSecretTags()
+	}
+	if result, err = c.sendSecretTags(ctx, "DELETE", projectSlug, environment, secretKey, tagSlugs, params); err != nil {
+		return SecretData{}, fmt.Errorf("failed to detach tags from a secret: %w", err)
+	}
+	return result, nil
+}
+
+// sendSecretTags attaches (POST) or detaches (DELETE) tags of a secret.
+func (c *Client) sendSecretTags(ctx context.Context, method, projectSlug, environment, secretKey string, tagSlugs []string, params *ParamsAttachSecretTags) (result SecretData, err error) {
+	errs := []error{validateSlug("project slug", projectSlug), validateID("environment", environment), validateSecretKey(secretKey), params.validate()}
+	if len(tagSlugs) == 0 {
+		errs = append(errs, fmt.Errorf("%w: tag slugs are missing", ErrInvalidParams))
+	}
+	for _, slug := range tagSlugs {
+		errs = append(errs, validateSlug("tag slug", slug))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return SecretData{}, err
+	}
+
+	// essential parameters
+	body := struct {
+		ProjectSlug string   `json:"projectSlug"`
+		Environment string   `json:"environment"`
+		TagSlugs    []string `json:"tagSlugs"`
+		*ParamsAttachSecretTags
+	}{projectSlug, environment, tagSlugs, params}
 
 	var req *http.Request
-	req, err = c.newRequestWithJSONBody("DELETE", fmt.Sprintf("/v3/secrets/raw/%s", secretKey), AuthMethodNormal, params)
+	req, err = c.newRequestWithJSONBody(ctx, method, fmt.Sprintf("/v3/secrets/tags/%s", secretKey), AuthMethodNormal, body)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
-			return c.parseResponse(res, nil)
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				// (cached secrets are keyed with workspace ids)
+				c.secretCache.invalidateSecret(result.Secret.Workspace, environment, deref(params.SecretPath), secretKey)
+				c.invalidateOfflineSecrets(ctx, result.Secret.Workspace, environment, deref(params.SecretPath), secretKey)
+				return result, nil
+			}
 		}
 	}
 
-	return err
+	return SecretData{}, err
+}
+
+// MaxSecretsPerBatch is the maximum number of secrets in one batch request (larger ones are split into chunks)
+const MaxSecretsPerBatch = 100
+
+// BulkSecret struct for creating or updating multiple secrets
+type BulkSecret struct {
+	SecretPath    string  `json:"-"` // "/" if empty
+	SecretKey     string  `json:"secretKey"`
+	SecretValue   string  `json:"secretValue"`
+	SecretComment *string `json:"secretComment,omitempty"`
+}
+
+// BulkSecretKey struct for deleting multiple secrets
+type BulkSecretKey struct {
+	SecretPath string      `json:"-"` // "/" if empty
+	SecretKey  string      `json:"secretKey"`
+	Type       *SecretType `json:"type,omitempty"`
 }
 
-/*
-TODO:
-* Secrets
-- [ ] [Bulk Create](https://infisical.com/docs/api-reference/endpoints/secrets/create-many)
-- [ ] [Bulk Update](https://infisical.com/docs/api-reference/endpoints/secrets/update-many)
-- [ ] [Bulk Delete](https://infisical.com/docs/api-reference/endpoints/secrets/delete-many)
-- [ ] [Attach Tags](https://infisical.com/docs/api-reference/endpoints/secrets/attach-tags)
-- [ ] [Detach Tags](https://infisical.com/docs/api-reference/endpoints/secrets/detach-tags)
-*/
+// BulkSecretResult struct for the result of each secret in bulk operations
+type BulkSecretResult struct {
+	SecretPath string
+	SecretKey  string
+
+	Secret *Secret // created, updated, or deleted secret (nil if failed)
+	Err    error   // error of the batch request which contained this secret (nil if succeeded)
+}
+
+// CreateSecrets creates multiple shared secrets, which can be in different paths.
+//
+// Secrets are grouped by their paths, and sent in chunks of at most `MaxSecretsPerBatch` secrets.
+// Each chunk succeeds or fails as a whole, and its result is reported in the returned results (in the same order as `secrets`).
+//
+// https://infisical.com/docs/api-reference/endpoints/secrets/create-many
+func (c *Client) CreateSecrets(workspaceID, environment string, secrets []BulkSecret) (results []BulkSecretResult, err error) {
+	return c.CreateSecretsWithContext(context.Background(), workspaceID, environment, secrets)
+}
+
+// CreateSecretsWithContext is the same as `CreateSecrets` with given context.
+func (c *Client) CreateSecretsWithContext(ctx context.Context, workspaceID, environment string, secrets []BulkSecret) (results []BulkSecretResult, err error) {
+	if err = validateBulkSecrets(workspaceID, environment, secrets); err != nil {
+		return nil, fmt.Errorf("failed to create secrets: %w", err)
+	}
+
+	if results, err = sendSecretsInBatches(ctx, c, "POST", workspaceID, environment, secrets, func(secret BulkSecret) (string, string) {
+		return secret.SecretPath, secret.SecretKey
+	}); err != nil {
+		return results, fmt.Errorf("failed to create secrets: %w", err)
+	}
+	return results, nil
+}
+
+// UpdateSecrets updates multiple shared secrets, which can be in different paths.
+//
+// Secrets are grouped by their paths, and sent in chunks of at most `MaxSecretsPerBatch` secrets.
+// Each chunk succeeds or fails as a whole, and its result is reported in the returned results (in the same order as `secrets`).
+//
+// https://infisical.com/docs/api-reference/endpoints/secrets/update-many
+func (c *Client) UpdateSecrets(workspaceID, environment string, secrets []BulkSecret) (results []BulkSecretResult, err error) {
+	return c.UpdateSecretsWithContext(context.Background(), workspaceID, environment, secrets)
+}
+
+// UpdateSecretsWithContext is the same as `UpdateSecrets` with given context.
+func (c *Client) UpdateSecretsWithContext(ctx context.Context, workspaceID, environment string, secrets []BulkSecret) (results []BulkSecretResult, err error) {
+	if err = validateBulkSecrets(workspaceID, environment, secrets); err != nil {
+		return nil, fmt.Errorf("failed to update secrets: %w", err)
+	}
+
+	if results, err = sendSecretsInBatches(ctx, c, "PATCH", workspaceID, environment, secrets, func(secret BulkSecret) (string, string) {
+		return secret.SecretPath, secret.SecretKey
+	}); err != nil {
+		return results, fmt.Errorf("failed to update secrets: %w", err)
+	}
+	return results, nil
+}
+
+// DeleteSecrets deletes multiple secrets, which can be in different paths.
+//
+// Secrets are grouped by their paths, and sent in chunks of at most `MaxSecretsPerBatch` secrets.
+// Each chunk succeeds or fails as a whole, and its result is reported in the returned results (in the same order as `secrets`).
+//
+// https://infisical.com/docs/api-reference/endpoints/secrets/delete-many
+func (c *Client) DeleteSecrets(workspaceID, environment string, secrets []BulkSecretKey) (results []BulkSecretResult, err error) {
+	return c.DeleteSecretsWithContext(context.Background(), workspaceID, environment, secrets)
+}
+
+// DeleteSecretsWithContext is the same as `DeleteSecrets` with given context.
+func (c *Client) DeleteSecretsWithContext(ctx context.Context, workspaceID, environment string, secrets []BulkSecretKey) (results []BulkSecretResult, err error) {
+	errs := []error{validateWorkspace(workspaceID, environment)}
+	for _, secret := range secrets {
+		errs = append(errs, validateSecretKey(secret.SecretKey), validatePath(ptr(bulkSecretPath(secret.SecretPath))), validateSecretType(secret.Type))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to delete secrets: %w", err)
+	}
+
+	if results, err = sendSecretsInBatches(ctx, c, "DELETE", workspaceID, environment, secrets, func(secret BulkSecretKey) (string, string) {
+		return secret.SecretPath, secret.SecretKey
+	}); err != nil {
+		return results, fmt.Errorf("failed to delete secrets: %w", err)
+	}
+	return results, nil
+}
+
+// validateBulkSecrets checks secrets for creating or updating.
+func validateBulkSecrets(workspaceID, environment string, secrets []BulkSecret) error {
+	errs := []error{validateWorkspace(workspaceID, environment)}
+	for _, secret := range secrets {
+		errs = append(errs, validateSecretKey(secret.SecretKey), validatePath(ptr(bulkSecretPath(secret.SecretPath))))
+	}
+	return errors.Join(errs...)
+}
+
+// bulkSecretPath returns the path of a secret in bulk operations. ("/" if empty)
+func bulkSecretPath(secretPath string) string {
+	if secretPath == "" {
+		return "/"
+	}
+	if secretPath != "/" {
+		secretPath = strings.TrimSuffix(secretPath, "/")
+	}
+	return secretPath
+}
+
+// sendSecretsInBatches groups secrets by their paths, and sends them in chunks to the batch endpoint with given method.
+func sendSecretsInBatches[T any](ctx context.Context, c *Client, method, workspaceID, environment string, secrets []T, pathAndKey func(T) (string, string)) (results []BulkSecretResult, err error) {
+	results = make([]BulkSecretResult, len(secrets))
+
+	// group indices of secrets by their paths (in order of appearance)
+	paths := []string{}
+	indices := map[string][]int{}
+	for i, secret := range secrets {
+		path, key := pathAndKey(secret)
+		path = bulkSecretPath(path)
+		results[i] = BulkSecretResult{SecretPath: path, SecretKey: key}
+
+		if _, exists := indices[path]; !exists {
+			paths = append(paths, path)
+		}
+		indices[path] = append(indices[path], i)
+	}
+
+	errs := []error{}
+	for _, path := range paths {
+		for start := 0; start < len(indices[path]); start += MaxSecretsPerBatch {
+			chunk := indices[path][start:min(start+MaxSecretsPerBatch, len(indices[path]))]
+
+			items := make([]T, len(chunk))
+			for i, index := range chunk {
+				items[i] = secrets[index]
+			}
+			body := struct {
+				WorkspaceID string `json:"workspaceId"`
+				Environment string `json:"environment"`
+				SecretPath  string `json:"secretPath"`
+				Secrets     []T    `json:"secrets"`
+			}{workspaceID, environment, path, items}
+
+			var result SecretsData
+			var req *http.Request
+			if req, err = c.newRequestWithJSONBody(ctx, method, "/v3/secrets/batch/raw", AuthMethodNormal, body); err == nil {
+				var res *http.Response
+				if res, err = c.do(req); err == nil {
+					err = c.parseResponse(res, &result)
+				}
+			}
+
+			// report results of the chunk
+			if err != nil {
+				errs = append(errs, fmt.Errorf("batch of %d secret(s) at path '%s': %w", len(chunk), path, err))
+			}
+			keys := []string{}
+			for _, index := range chunk {
+				if err != nil {
+					results[index].Err = err
+					continue
+				}
+				for i := range result.Secrets {
+					if result.Secrets[i].SecretKey == results[index].SecretKey {
+						results[index].Secret = &result.Secrets[i]
+						break
+					}
+				}
+				c.secretCache.invalidateSecret(workspaceID, environment, path, results[index].SecretKey)
+				keys = append(keys, results[index].SecretKey)
+			}
+
+			// (the offline cache is invalidated once per chunk, as it rewrites snapshot files)
+			c.invalidateOfflineSecrets(ctx, workspaceID, environment, path, keys...)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,127 @@
+package infisical
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestBulkSecrets(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev")
+	server, workspace := target.fakeServer(t), target.workspace
+	server.AddFolder(workspace.ID, "dev", "/", "folder")
+
+	client := target.newClient(t,
+		WithRetryPolicy(nil),
+		WithSecretCache(DefaultSecretCachePolicy()),
+		WithOfflineCache(&OfflineCachePolicy{Dir: t.TempDir()}),
+	)
+
+	const numSecrets = MaxSecretsPerBatch + 10
+	secrets := []BulkSecret{}
+	for i := 0; i < numSecrets; i++ {
+		secrets = append(secrets, BulkSecret{SecretKey: fmt.Sprintf("KEY%d", i), SecretValue: fmt.Sprintf("value%d", i)})
+	}
+	secrets = append(secrets, BulkSecret{SecretPath: "/folder/", SecretKey: "KEY", SecretValue: "folder-value", SecretComment: ptr("comment")})
+
+	// (create secrets in chunks & multiple paths)
+	if results, err := client.CreateSecrets(workspace.ID, "dev", secrets); err != nil {
+		t.Errorf("failed to create secrets: %s", err)
+	} else if len(results) != len(secrets) {
+		t.Errorf("unexpected number of results: %d", len(results))
+	} else {
+		for i, result := range results {
+			if result.Err != nil || result.Secret == nil || result.SecretKey != secrets[i].SecretKey || result.Secret.SecretValue != secrets[i].SecretValue {
+				t.Errorf("unexpected result at %d: %+v", i, result)
+			}
+		}
+		if results[len(results)-1].SecretPath != "/folder" {
+			t.Errorf("secret path should have been normalized: %s", results[len(results)-1].SecretPath)
+		}
+	}
+	if count := server.CountRequests("POST", "/api/v3/secrets/batch/raw"); count != 3 {
+		t.Errorf("secrets should have been sent in 3 chunks: %d", count)
+	}
+	if value, exists := server.Secret(workspace.ID, "dev", "/folder", "KEY"); !exists || value != "folder-value" {
+		t.Errorf("secret was not created in the folder: %s", value)
+	}
+
+	// (update secrets, invalidating cached ones)
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY0"); err != nil || value != "value0" {
+		t.Errorf("failed to retrieve a secret: %s, %s", value, err)
+	}
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY2"); err != nil || value != "value2" {
+		t.Errorf("failed to retrieve a secret: %s, %s", value, err)
+	}
+	if results, err := client.UpdateSecrets(workspace.ID, "dev", []BulkSecret{
+		{SecretKey: "KEY0", SecretValue: "updated0"},
+		{SecretKey: "KEY2", SecretValue: "updated2"},
+		{SecretPath: "/folder", SecretKey: "KEY", SecretValue: "updated-folder-value"},
+	}); err != nil {
+		t.Errorf("failed to update secrets: %s", err)
+	} else if results[0].Secret == nil || results[0].Secret.Version != 2 {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if value, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY0"); err != nil || value != "updated0" {
+		t.Errorf("updated secret should not have been served from the cache: %s, %s", value, err)
+	}
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	})
+	if _, err := client.RetrieveSecretValue(workspace.ID, "dev", SecretTypeShared, "/KEY2"); err == nil {
+		t.Errorf("updated secret should not have been served from the offline cache")
+	}
+	server.ClearFaults()
+
+	// (failed chunks are reported per secret)
+	results, err := client.UpdateSecrets(workspace.ID, "dev", []BulkSecret{
+		{SecretKey: "KEY1", SecretValue: "updated1"},
+		{SecretPath: "/folder", SecretKey: "NONEXISTENT", SecretValue: "value"},
+	})
+	var apiErr *APIError
+	if err == nil {
+		t.Errorf("updating nonexistent secrets should have failed")
+	} else if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[1].Err == nil {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if value, _ := server.Secret(workspace.ID, "dev", "/", "KEY1"); value != "updated1" {
+		t.Errorf("secret in the succeeded chunk should have been updated: %s", value)
+	}
+
+	// (delete secrets)
+	keys := []BulkSecretKey{{SecretPath: "/folder", SecretKey: "KEY"}}
+	for _, secret := range secrets[:numSecrets] {
+		keys = append(keys, BulkSecretKey{SecretKey: secret.SecretKey, Type: ptr(SecretTypeShared)})
+	}
+	if results, err := client.DeleteSecrets(workspace.ID, "dev", keys); err != nil {
+		t.Errorf("failed to delete secrets: %s", err)
+	} else if len(results) != len(keys) {
+		t.Errorf("unexpected number of results: %d", len(results))
+	}
+	if listed, err := client.ListSecrets(NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("dev")); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	} else if len(listed.Secrets) != 0 {
+		t.Errorf("all secrets should have been deleted: %d", len(listed.Secrets))
+	}
+
+	// (invalid secrets are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"relative secret path": func(client *Client) error {
+			_, err := client.CreateSecrets(workspace.ID, "dev", []BulkSecret{{SecretKey: "KEY"}, {SecretPath: "relative", SecretKey: "KEY"}})
+			return err
+		},
+		"missing secret key": func(client *Client) error {
+			_, err := client.DeleteSecrets(workspace.ID, "dev", []BulkSecretKey{{SecretKey: ""}})
+			return err
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package infisical
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestSnapshots(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t, "dev", "prod")
+	server, workspace := target.fakeServer(t), target.workspace
+
+	client := target.newClient(t)
+
+	// (snapshots are taken after each change of secrets)
+	if _, err := client.CreateSecrets(workspace.ID, "prod", []BulkSecret{
+		{SecretKey: "DB_HOST", SecretValue: "db.internal"},
+		{SecretKey: "DB_PASSWORD", SecretValue: "correct-password"},
+		{SecretKey: "LEGACY_FLAG", SecretValue: "on"},
+	}); err != nil {
+		t.Fatalf("failed to create secrets: %s", err)
+	}
+	if _, err := client.UpdateSecrets(workspace.ID, "prod", []BulkSecret{
+		{SecretKey: "DB_PASSWORD", SecretValue: "wrong-password"},
+	}); err != nil {
+		t.Fatalf("failed to update secrets: %s", err)
+	}
+	if _, err := client.DeleteSecrets(workspace.ID, "prod", []BulkSecretKey{
+		{SecretKey: "LEGACY_FLAG"},
+	}); err != nil {
+		t.Fatalf("failed to delete secrets: %s", err)
+	}
+	if _, err := client.CreateSecrets(workspace.ID, "prod", []BulkSecret{
+		{SecretKey: "FEATURE_X", SecretValue: "enabled"},
+	}); err != nil {
+		t.Fatalf("failed to create secrets: %s", err)
+	}
+
+	// (count snapshots)
+	if count, err := client.CountSnapshots(workspace.ID, "prod", nil); err != nil {
+		t.Errorf("failed to count snapshots: %s", err)
+	} else if count != 4 {
+		t.Errorf("unexpected number of snapshots: %d", count)
+	}
+
+	// (list a page of snapshots)
+	if page, err := client.ListSnapshots(workspace.ID, "prod", NewParamsListSnapshots().SetOffset(1).SetLimit(2)); err != nil {
+		t.Errorf("failed to list snapshots: %s", err)
+	} else if len(page.SecretSnapshots) != 2 {
+		t.Errorf("unexpected number of snapshots in a page: %d", len(page.SecretSnapshots))
+	}
+	if page, err := client.ListSnapshots(workspace.ID, "prod", NewParamsListSnapshots().SetOffset(1_000_000)); err != nil {
+		t.Errorf("failed to list snapshots with a large offset: %s", err)
+	} else if len(page.SecretSnapshots) != 0 {
+		t.Errorf("large offset was not sent properly: %d snapshots", len(page.SecretSnapshots))
+	}
+
+	// (list all snapshots with small pages, from the newest one)
+	snapshots, err := client.ListAllSnapshots(workspace.ID, "prod", NewParamsListSnapshots().SetLimit(3))
+	if err != nil {
+		t.Fatalf("failed to list all snapshots: %s", err)
+	} else if len(snapshots) != 4 {
+		t.Fatalf("unexpected number of snapshots: %d", len(snapshots))
+	}
+	beforeBadEdit := snapshots[3].ID
+
+	// (get a snapshot)
+	if snapshot, err := client.GetSnapshot(beforeBadEdit); err != nil {
+		t.Errorf("failed to get a snapshot: %s", err)
+	} else if snapshot.SecretSnapshot.ProjectID != workspace.ID ||
+		snapshot.SecretSnapshot.Environment == nil || snapshot.SecretSnapshot.Environment.Slug != "prod" ||
+		len(snapshot.SecretSnapshot.SecretVersions) != 3 {
+		t.Errorf("unexpected snapshot: %+v", snapshot.SecretSnapshot)
+	}
+
+	// (diff two snapshots)
+	if diff, err := client.DiffSnapshots(beforeBadEdit, snapshots[2].ID); err != nil {
+		t.Errorf("failed to diff snapshots: %s", err)
+	} else if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Modified) != 1 ||
+		diff.Modified[0].SecretKey != "DB_PASSWORD" ||
+		diff.Modified[0].Before.SecretValue != "correct-password" ||
+		diff.Modified[0].After.SecretValue != "wrong-password" {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	// (diff a snapshot with current secrets)
+	if diff, err := client.DiffSnapshotWithCurrent(workspace.ID, "prod", "/", beforeBadEdit); err != nil {
+		t.Errorf("failed to diff a snapshot with current secrets: %s", err)
+	} else if len(diff.Added) != 1 || diff.Added[0].SecretKey != "FEATURE_X" ||
+		len(diff.Removed) != 1 || diff.Removed[0].SecretKey != "LEGACY_FLAG" ||
+		len(diff.Modified) != 1 || diff.Modified[0].SecretKey != "DB_PASSWORD" {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	// (roll back to a snapshot)
+	if _, err := client.RollbackToSnapshot(beforeBadEdit); err != nil {
+		t.Errorf("failed to roll back to a snapshot: %s", err)
+	}
+	if value, exists := server.Secret(workspace.ID, "prod", "/", "DB_PASSWORD"); !exists || value != "correct-password" {
+		t.Errorf("secret should have been rolled back: %s", value)
+	}
+	if value, exists := server.Secret(workspace.ID, "prod", "/", "LEGACY_FLAG"); !exists || value != "on" {
+		t.Errorf("deleted secret should have been restored: %s", value)
+	}
+	if _, exists := server.Secret(workspace.ID, "prod", "/", "FEATURE_X"); exists {
+		t.Errorf("secret created after the snapshot should have been removed")
+	}
+	if diff, err := client.DiffSnapshotWithCurrent(workspace.ID, "prod", "/", beforeBadEdit); err != nil {
+		t.Errorf("failed to diff a snapshot with current secrets: %s", err)
+	} else if !diff.Empty() {
+		t.Errorf("there should be no difference after rollback: %+v", diff)
+	}
+
+	// (current secrets are not served from caches, and cached ones are dropped after rollback)
+	cached, err := New(
+		WithUniversalAuth(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret),
+		WithBaseURL(server.URL),
+		WithRetryPolicy(nil),
+		WithSecretCache(DefaultSecretCachePolicy()),
+		WithOfflineCache(&OfflineCachePolicy{Dir: t.TempDir()}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	params := NewParamsListSecrets().SetWorkspaceID(workspace.ID).SetEnvironment("prod")
+	if _, err := cached.ListSecrets(params); err != nil {
+		t.Errorf("failed to list secrets: %s", err)
+	}
+	server.SetSecret(workspace.ID, "prod", "/", "DB_PASSWORD", "changed-elsewhere")
+	if diff, err := cached.DiffSnapshotWithCurrent(workspace.ID, "prod", "/", beforeBadEdit); err != nil {
+		t.Errorf("failed to diff a snapshot with current secrets: %s", err)
+	} else if len(diff.Modified) != 1 || diff.Modified[0].After.SecretValue != "changed-elsewhere" {
+		t.Errorf("current secrets should not have been served from caches: %+v", diff)
+	}
+	if _, err := cached.RollbackToSnapshot(beforeBadEdit); err != nil {
+		t.Errorf("failed to roll back to a snapshot: %s", err)
+	}
+	server.InjectFault(infisicaltest.Fault{
+		Path:       "/api/v3/secrets/raw",
+		StatusCode: http.StatusServiceUnavailable,
+	})
+	if _, err := cached.ListSecrets(params); err == nil {
+		t.Errorf("secrets listed before rollback should not be served from the offline cache")
+	}
+	server.ClearFaults()
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"missing environment": func(client *Client) error {
+			_, err := client.ListSnapshots(workspace.ID, "", nil)
+			return err
+		},
+		"negative offset": func(client *Client) error {
+			_, err := client.ListSnapshots(workspace.ID, "prod", NewParamsListSnapshots().SetOffset(-1))
+			return err
+		},
+		"invalid limit": func(client *Client) error {
+			_, err := client.ListAllSnapshots(workspace.ID, "prod", NewParamsListSnapshots().SetLimit(0))
+			return err
+		},
+		"missing snapshot id": func(client *Client) error {
+			_, err := client.RollbackToSnapshot("")
+			return err
+		},
+	})
+}
@@ -0,0 +1,216 @@
+package infisical
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/meinside/infisical-go/infisicaltest"
+)
+
+func TestTokenLifecycle(t *testing.T) {
+	////////////////////////////////
+	// run a stand-in server which counts logins and renewals
+	var logins, renewals atomic.Int32
+
+	issue := func(w http.ResponseWriter, accessToken string) {
+		time.Sleep(10 * time.Millisecond) // make concurrent callers pile up
+		_ = json.NewEncoder(w).Encode(AccessToken{
+			AccessToken:       accessToken,
+			AccessTokenMaxTTL: 3600,
+			ExpiresIn:         600,
+			TokenType:         "Bearer",
+		})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		issue(w, "issued-access-token")
+	})
+	mux.HandleFunc("/api/v1/auth/token/renew", func(w http.ResponseWriter, r *http.Request) {
+		renewals.Add(1)
+		issue(w, "renewed-access-token")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	////////////////////////////////
+	// initialize client
+	client := NewClientWithoutAPIKey("test-client-id", "test-client-secret")
+	client.SetAPIBaseURL(server.URL)
+
+	// get tokens concurrently
+	getTokens := func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				if token, err := client.getToken(context.Background()); err != nil {
+					t.Errorf("failed to get token: %s", err)
+				} else if token == nil {
+					t.Errorf("fetched token is nil")
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	// (first login: shared by all callers)
+	getTokens()
+	if logins.Load() != 1 || renewals.Load() != 0 {
+		t.Errorf("should have logged in once, but logged in %d times and renewed %d times", logins.Load(), renewals.Load())
+	}
+
+	// (within the renewal margin: renewed once)
+	client.tokenLock.Lock()
+	client.tokenExpiresOn = time.Now().Add(DefaultTokenRenewalMargin / 2)
+	client.tokenLock.Unlock()
+
+	getTokens()
+	if logins.Load() != 1 || renewals.Load() != 1 {
+		t.Errorf("should have renewed once, but logged in %d times and renewed %d times", logins.Load(), renewals.Load())
+	}
+
+	// (max TTL reached: logged in again instead of renewal)
+	client.tokenLock.Lock()
+	client.tokenMaxExpiresOn = time.Now().Add(time.Second)
+	client.tokenExpiresOn = client.tokenMaxExpiresOn
+	client.tokenLock.Unlock()
+
+	getTokens()
+	if logins.Load() != 2 || renewals.Load() != 1 {
+		t.Errorf("should have logged in again, but logged in %d times and renewed %d times", logins.Load(), renewals.Load())
+	}
+
+	// (still valid: neither logged in nor renewed)
+	getTokens()
+	if logins.Load() != 2 || renewals.Load() != 1 {
+		t.Errorf("should have reused the token, but logged in %d times and renewed %d times", logins.Load(), renewals.Load())
+	}
+}
+
+func TestTokenWaitCancelled(t *testing.T) {
+	////////////////////////////////
+	// run a stand-in server with a slow login
+	release := make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/auth/universal-auth/login", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_ = json.NewEncoder(w).Encode(AccessToken{
+			AccessToken: "issued-access-token",
+			ExpiresIn:   600,
+			TokenType:   "Bearer",
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	////////////////////////////////
+	// initialize client
+	client := NewClientWithoutAPIKey("test-client-id", "test-client-secret")
+	client.SetAPIBaseURL(server.URL)
+
+	// (a waiter gives up, but the login in flight continues for others)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.getToken(ctx); err == nil {
+		t.Errorf("waiting for the token should have been cancelled")
+	}
+
+	close(release)
+
+	if token, err := client.getToken(context.Background()); err != nil {
+		t.Errorf("failed to get token: %s", err)
+	} else if token.AccessToken != "issued-access-token" {
+		t.Errorf("wrong token was fetched: %s", token.AccessToken)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	server := infisicaltest.NewServer()
+	defer server.Close()
+
+	client := NewClientWithoutAPIKey(infisicaltest.DefaultClientID, infisicaltest.DefaultClientSecret)
+	client.SetAPIBaseURL(server.URL)
+
+	const revokePath = "/api/v1/auth/token/revoke"
+
+	// (revoke the current token)
+	token, err := client.getToken(context.Background())
+	if err != nil || token == nil {
+		t.Fatalf("failed to get token: %v", err)
+	}
+	if err := client.Logout(); err != nil {
+		t.Errorf("failed to logout: %s", err)
+	}
+	client.tokenLock.Lock()
+	if client.token != nil || !client.tokenExpiresOn.IsZero() || !client.tokenMaxExpiresOn.IsZero() {
+		t.Errorf("token state should be wiped")
+	}
+	client.tokenLock.Unlock()
+	if count := server.CountRequests("POST", revokePath); count != 1 {
+		t.Errorf("unexpected number of revocations: %d", count)
+	}
+	revoked := NewClientWithAuthenticator(NewAccessTokenAuth(token.AccessToken))
+	revoked.SetAPIBaseURL(server.URL)
+	if _, err := revoked.ListIdentities(infisicaltest.DefaultOrganizationID); err == nil {
+		t.Errorf("revoked access token should not be used")
+	}
+
+	// (nothing to revoke)
+	if err := client.Logout(); err != nil {
+		t.Errorf("failed to logout without token: %s", err)
+	}
+	if count := server.CountRequests("POST", revokePath); count != 1 {
+		t.Errorf("unexpected number of revocations: %d", count)
+	}
+
+	// (log in again after logout, then close)
+	if _, err := client.ListIdentities(infisicaltest.DefaultOrganizationID); err != nil {
+		t.Errorf("failed to login again: %s", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("failed to close: %s", err)
+	}
+	if count := server.CountRequests("POST", revokePath); count != 2 {
+		t.Errorf("unexpected number of revocations: %d", count)
+	}
+
+	// (static access tokens are not revoked)
+	static := NewClientWithAuthenticator(NewAccessTokenAuth(server.IssueAccessToken(infisicaltest.DefaultIdentityID)))
+	static.SetAPIBaseURL(server.URL)
+	if _, err := static.ListIdentities(infisicaltest.DefaultOrganizationID); err != nil {
+		t.Errorf("failed to use a static access token: %s", err)
+	}
+	if err := static.Close(); err != nil {
+		t.Errorf("failed to close: %s", err)
+	}
+	if count := server.CountRequests("POST", revokePath); count != 2 {
+		t.Errorf("static access tokens should not be revoked: %d", count)
+	}
+
+	// (token state is wiped even when revocation fails)
+	if _, err := client.getToken(context.Background()); err != nil {
+		t.Fatalf("failed to get token: %s", err)
+	}
+	server.InjectFault(infisicaltest.Fault{Path: revokePath, StatusCode: http.StatusInternalServerError, Times: 1})
+	if err := client.Logout(); err == nil {
+		t.Errorf("failed revocation should be reported")
+	}
+	client.tokenLock.Lock()
+	if client.token != nil {
+		t.Errorf("token should be discarded even when revocation fails")
+	}
+	client.tokenLock.Unlock()
+}
@@ -1,102 +1,479 @@
 package infisical
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
-// UniversalAuthToken is a struct of universal-auth token
-type UniversalAuthToken struct {
-	AccessToken       string `json:"accessToken"`
-	AccessTokenMaxTTL int64  `json:"accessTokenMaxTTL"`
-	ExpiresIn         int64  `json:"expiresIn"`
-	TokenType         string `json:"tokenType"`
+// UniversalAuth is an authenticator with universal-auth client id and secret
+type UniversalAuth struct {
+	lock         sync.Mutex
+	clientID     string
+	clientSecret string // can be rotated (see `WithClientSecretRotation`)
 }
 
-// login without token and load the result into the client
+// NewUniversalAuth returns a new universal-auth authenticator with given client id and secret.
+func NewUniversalAuth(clientID, clientSecret string) *UniversalAuth {
+	return &UniversalAuth{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}
+}
+
+// Login logs in with universal-auth client id and secret.
 //
 // https://infisical.com/docs/api-reference/endpoints/universal-auth/login
-func (c *Client) login() (result UniversalAuthToken, err error) {
-	var encoded []byte
-	if encoded, err = json.Marshal(map[string]any{
-		"clientId":     c.clientID,
-		"clientSecret": c.clientSecret,
-	}); err == nil {
-		var req *http.Request
-		if req, err = http.NewRequest("POST", c.requestURL("/v1/auth/universal-auth/login"), bytes.NewReader(encoded)); err == nil {
-			req.Header.Set("Content-Type", "application/json")
-
-			c.dumpRequest(req)
-
-			var res *http.Response
-			if res, err = c.httpClient.Do(req); err == nil {
-				c.dumpResponse(res)
-
-				if err = c.parseResponse(res, &result); err == nil {
-					c.token = &UniversalAuthToken{
-						AccessToken:       result.AccessToken,
-						AccessTokenMaxTTL: result.AccessTokenMaxTTL,
-						ExpiresIn:         result.ExpiresIn,
-						TokenType:         result.TokenType,
-					}
-					c.tokenExpiresOn = time.Now().Add(time.Second * time.Duration(result.ExpiresIn))
-
-					return result, nil
-				}
+func (a *UniversalAuth) Login(ctx context.Context, c *Client) (AccessToken, error) {
+	clientID, clientSecret := a.credential()
+
+	return c.requestToken(ctx, "/v1/auth/universal-auth/login", map[string]any{
+		"clientId":     clientID,
+		"clientSecret": clientSecret,
+	})
+}
+
+// credential returns the current client id and secret.
+func (a *UniversalAuth) credential() (clientID, clientSecret string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.clientID, a.clientSecret
+}
+
+// setClientSecret switches the client secret in place.
+func (a *UniversalAuth) setClientSecret(clientSecret string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.clientSecret = clientSecret
+}
+
+// TrustedIP struct for trusted IP addresses (or ranges in CIDR notation) of universal-auth
+type TrustedIP struct {
+	IPAddress string `json:"ipAddress"`
+	Prefix    *int   `json:"prefix,omitempty"` // only in responses
+	Type      string `json:"type,omitempty"`   // "ipv4" or "ipv6", only in responses
+}
+
+// CIDR returns the trusted IP in CIDR notation (or as it is, if it has no prefix).
+func (t TrustedIP) CIDR() string {
+	if t.Prefix != nil {
+		return fmt.Sprintf("%s/%d", t.IPAddress, *t.Prefix)
+	}
+	return t.IPAddress
+}
+
+// trustedIPs converts given IP addresses or CIDRs into trusted IPs.
+func trustedIPs(cidrs []string) []TrustedIP {
+	ips := []TrustedIP{}
+	for _, cidr := range cidrs {
+		ips = append(ips, TrustedIP{IPAddress: cidr})
+	}
+	return ips
+}
+
+// validateTrustedIPs checks given trusted IPs.
+func validateTrustedIPs(name string, ips []TrustedIP) error {
+	errs := []error{}
+	for _, ip := range ips {
+		if _, _, err := net.ParseCIDR(ip.CIDR()); err != nil && net.ParseIP(ip.CIDR()) == nil {
+			errs = append(errs, fmt.Errorf("%w: %s '%s' is not a valid IP address or CIDR", ErrInvalidParams, name, ip.CIDR()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// seconds converts given duration into seconds for params.
+func seconds(d time.Duration) *int64 {
+	return ptr(int64(d / time.Second))
+}
+
+// UniversalAuthData struct for universal-auth configuration response
+type UniversalAuthData struct {
+	IdentityUniversalAuth UniversalAuthConfig `json:"identityUniversalAuth"`
+}
+
+// UniversalAuthConfig struct for a machine identity's universal-auth configuration
+type UniversalAuthConfig struct {
+	ID                      string      `json:"id"`
+	ClientID                string      `json:"clientId"`
+	IdentityID              string      `json:"identityId"`
+	AccessTokenTTL          int64       `json:"accessTokenTTL"`          // in seconds
+	AccessTokenMaxTTL       int64       `json:"accessTokenMaxTTL"`       // in seconds
+	AccessTokenNumUsesLimit int64       `json:"accessTokenNumUsesLimit"` // 0 for unlimited
+	ClientSecretTrustedIPs  []TrustedIP `json:"clientSecretTrustedIps"`
+	AccessTokenTrustedIPs   []TrustedIP `json:"accessTokenTrustedIps"`
+	CreatedAt               string      `json:"createdAt"`
+	UpdatedAt               string      `json:"updatedAt"`
+}
+
+// ParamsUniversalAuth struct for attaching or updating universal-auth
+type ParamsUniversalAuth struct {
+	ClientSecretTrustedIPs  []TrustedIP `json:"clientSecretTrustedIps,omitempty"`
+	AccessTokenTrustedIPs   []TrustedIP `json:"accessTokenTrustedIps,omitempty"`
+	AccessTokenTTL          *int64      `json:"accessTokenTTL,omitempty"`          // in seconds
+	AccessTokenMaxTTL       *int64      `json:"accessTokenMaxTTL,omitempty"`       // in seconds
+	AccessTokenNumUsesLimit *int64      `json:"accessTokenNumUsesLimit,omitempty"` // 0 for unlimited
+}
+
+// NewParamsUniversalAuth returns a new params for attaching or updating universal-auth.
+//
+// Omitted values will be the server's defaults (when attaching) or left unchanged (when updating).
+func NewParamsUniversalAuth() *ParamsUniversalAuth {
+	return &ParamsUniversalAuth{}
+}
+
+func (p *ParamsUniversalAuth) SetClientSecretTrustedIPs(cidrs ...string) *ParamsUniversalAuth {
+	p.ClientSecretTrustedIPs = trustedIPs(cidrs)
+	return p
+}
+
+func (p *ParamsUniversalAuth) SetAccessTokenTrustedIPs(cidrs ...string) *ParamsUniversalAuth {
+	p.AccessTokenTrustedIPs = trustedIPs(cidrs)
+	return p
+}
+
+func (p *ParamsUniversalAuth) SetAccessTokenTTL(ttl time.Duration) *ParamsUniversalAuth {
+	p.AccessTokenTTL = seconds(ttl)
+	return p
+}
+
+func (p *ParamsUniversalAuth) SetAccessTokenMaxTTL(maxTTL time.Duration) *ParamsUniversalAuth {
+	p.AccessTokenMaxTTL = seconds(maxTTL)
+	return p
+}
+
+func (p *ParamsUniversalAuth) SetAccessTokenNumUsesLimit(limit int64) *ParamsUniversalAuth {
+	p.AccessTokenNumUsesLimit = &limit
+	return p
+}
+
+// empty checks if nothing is set in the params.
+func (p *ParamsUniversalAuth) empty() bool {
+	return p.ClientSecretTrustedIPs == nil && p.AccessTokenTrustedIPs == nil &&
+		p.AccessTokenTTL == nil && p.AccessTokenMaxTTL == nil && p.AccessTokenNumUsesLimit == nil
+}
+
+// validate checks the params.
+func (p *ParamsUniversalAuth) validate() error {
+	errs := []error{
+		validateTrustedIPs("client secret trusted ip", p.ClientSecretTrustedIPs),
+		validateTrustedIPs("access token trusted ip", p.AccessTokenTrustedIPs),
+	}
+	if p.AccessTokenTTL != nil && *p.AccessTokenTTL < 0 {
+		errs = append(errs, fmt.Errorf("%w: access token TTL should not be negative", ErrInvalidParams))
+	}
+	if p.AccessTokenMaxTTL != nil && *p.AccessTokenMaxTTL < 0 {
+		errs = append(errs, fmt.Errorf("%w: access token max TTL should not be negative", ErrInvalidParams))
+	}
+	if p.AccessTokenTTL != nil && p.AccessTokenMaxTTL != nil && *p.AccessTokenMaxTTL > 0 && *p.AccessTokenTTL > *p.AccessTokenMaxTTL {
+		errs = append(errs, fmt.Errorf("%w: access token TTL (%d) is greater than max TTL (%d)", ErrInvalidParams, *p.AccessTokenTTL, *p.AccessTokenMaxTTL))
+	}
+	if p.AccessTokenNumUsesLimit != nil && *p.AccessTokenNumUsesLimit < 0 {
+		errs = append(errs, fmt.Errorf("%w: access token number of uses limit should not be negative", ErrInvalidParams))
+	}
+	return errors.Join(errs...)
+}
+
+// AttachUniversalAuth attaches universal-auth to a machine identity.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/attach
+func (c *Client) AttachUniversalAuth(identityID string, params *ParamsUniversalAuth) (result UniversalAuthData, err error) {
+	return c.AttachUniversalAuthWithContext(context.Background(), identityID, params)
+}
+
+// AttachUniversalAuthWithContext is the same as `AttachUniversalAuth` with given context.
+func (c *Client) AttachUniversalAuthWithContext(ctx context.Context, identityID string, params *ParamsUniversalAuth) (result UniversalAuthData, err error) {
+	if params == nil {
+		params = NewParamsUniversalAuth()
+	}
+	if err = errors.Join(validateID("identity id", identityID), params.validate()); err != nil {
+		return UniversalAuthData{}, fmt.Errorf("failed to attach universal auth: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/auth/universal-auth/identities/%s", identityID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return UniversalAuthData{}, fmt.Errorf("failed to attach universal auth: %w", err)
+}
+
+// GetUniversalAuth retrieves the universal-auth configuration of a machine identity.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/retrieve
+func (c *Client) GetUniversalAuth(identityID string) (result UniversalAuthData, err error) {
+	return c.GetUniversalAuthWithContext(context.Background(), identityID)
+}
+
+// GetUniversalAuthWithContext is the same as `GetUniversalAuth` with given context.
+func (c *Client) GetUniversalAuthWithContext(ctx context.Context, identityID string) (result UniversalAuthData, err error) {
+	if err = validateID("identity id", identityID); err != nil {
+		return UniversalAuthData{}, fmt.Errorf("failed to get universal auth: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/auth/universal-auth/identities/%s", identityID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return UniversalAuthData{}, fmt.Errorf("failed to get universal auth: %w", err)
+}
+
+// UpdateUniversalAuth updates the universal-auth configuration of a machine identity.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/update
+func (c *Client) UpdateUniversalAuth(identityID string, params *ParamsUniversalAuth) (result UniversalAuthData, err error) {
+	return c.UpdateUniversalAuthWithContext(context.Background(), identityID, params)
+}
+
+// UpdateUniversalAuthWithContext is the same as `UpdateUniversalAuth` with given context.
+func (c *Client) UpdateUniversalAuthWithContext(ctx context.Context, identityID string, params *ParamsUniversalAuth) (result UniversalAuthData, err error) {
+	if params == nil {
+		params = NewParamsUniversalAuth()
+	}
+	errs := []error{validateID("identity id", identityID), params.validate()}
+	if params.empty() {
+		errs = append(errs, fmt.Errorf("%w: nothing to update", ErrInvalidParams))
+	}
+	if err = errors.Join(errs...); err != nil {
+		return UniversalAuthData{}, fmt.Errorf("failed to update universal auth: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "PATCH", fmt.Sprintf("/v1/auth/universal-auth/identities/%s", identityID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
 			}
 		}
 	}
 
-	return UniversalAuthToken{}, fmt.Errorf("failed to login: %s", err)
+	return UniversalAuthData{}, fmt.Errorf("failed to update universal auth: %w", err)
+}
+
+// ClientSecret struct for a universal-auth client secret (without its value)
+type ClientSecret struct {
+	ID                      string `json:"id"`
+	Description             string `json:"description"`
+	ClientSecretPrefix      string `json:"clientSecretPrefix"`
+	NumUses                 int64  `json:"clientSecretNumUses"`
+	NumUsesLimit            int64  `json:"clientSecretNumUsesLimit"` // 0 for unlimited
+	TTL                     int64  `json:"clientSecretTTL"`          // in seconds, 0 for no expiry
+	IsRevoked               bool   `json:"isClientSecretRevoked"`
+	IdentityUniversalAuthID string `json:"identityUAId"`
+	CreatedAt               string `json:"createdAt"`
+	UpdatedAt               string `json:"updatedAt"`
+}
+
+// ExpiresAt returns the time when the client secret expires.
+//
+// `ok` is false if it never expires (or its creation time is unknown).
+func (s ClientSecret) ExpiresAt() (expiresAt time.Time, ok bool) {
+	if s.TTL <= 0 {
+		return time.Time{}, false
+	}
+	createdAt, err := time.Parse(time.RFC3339, s.CreatedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return createdAt.Add(time.Duration(s.TTL) * time.Second), true
+}
+
+// RemainingUses returns the number of remaining uses of the client secret.
+//
+// `ok` is false if it can be used without limit.
+func (s ClientSecret) RemainingUses() (remaining int64, ok bool) {
+	if s.NumUsesLimit <= 0 {
+		return 0, false
+	}
+	return max(s.NumUsesLimit-s.NumUses, 0), true
+}
+
+// ClientSecretsData struct for client secrets response
+type ClientSecretsData struct {
+	ClientSecretData []ClientSecret `json:"clientSecretData"`
 }
 
-// refresh access token and load the result into the client
+// ClientSecretData struct for a client secret response
+type ClientSecretData struct {
+	ClientSecretData ClientSecret `json:"clientSecretData"`
+}
+
+// CreatedClientSecretData struct for a newly-created client secret response
+type CreatedClientSecretData struct {
+	ClientSecret     string       `json:"clientSecret"` // only returned once, on creation
+	ClientSecretData ClientSecret `json:"clientSecretData"`
+}
+
+// ParamsCreateClientSecret struct for creating a client secret
+type ParamsCreateClientSecret struct {
+	Description  *string `json:"description,omitempty"`
+	NumUsesLimit *int64  `json:"numUsesLimit,omitempty"` // 0 for unlimited
+	TTL          *int64  `json:"ttl,omitempty"`          // in seconds, 0 for no expiry
+}
+
+// NewParamsCreateClientSecret returns a new params for creating a client secret.
+func NewParamsCreateClientSecret() *ParamsCreateClientSecret {
+	return &ParamsCreateClientSecret{}
+}
+
+func (p *ParamsCreateClientSecret) SetDescription(description string) *ParamsCreateClientSecret {
+	p.Description = &description
+	return p
+}
+
+func (p *ParamsCreateClientSecret) SetNumUsesLimit(limit int64) *ParamsCreateClientSecret {
+	p.NumUsesLimit = &limit
+	return p
+}
+
+func (p *ParamsCreateClientSecret) SetTTL(ttl time.Duration) *ParamsCreateClientSecret {
+	p.TTL = seconds(ttl)
+	return p
+}
+
+// validate checks the params.
+func (p *ParamsCreateClientSecret) validate() error {
+	errs := []error{}
+	if p.NumUsesLimit != nil && *p.NumUsesLimit < 0 {
+		errs = append(errs, fmt.Errorf("%w: client secret number of uses limit should not be negative", ErrInvalidParams))
+	}
+	if p.TTL != nil && *p.TTL < 0 {
+		errs = append(errs, fmt.Errorf("%w: client secret TTL should not be negative", ErrInvalidParams))
+	}
+	return errors.Join(errs...)
+}
+
+// CreateClientSecret creates a new universal-auth client secret of a machine identity.
+//
+// NOTE: the value of the created client secret is returned only once.
 //
-// https://infisical.com/docs/api-reference/endpoints/universal-auth/renew-access-token
-func (c *Client) refresh() (result UniversalAuthToken, err error) {
-	var encoded []byte
-	if encoded, err = json.Marshal(map[string]any{
-		"accessToken": c.token.AccessToken,
-	}); err == nil {
-		var req *http.Request
-		if req, err = http.NewRequest("POST", c.requestURL("/v1/auth/token/renew"), bytes.NewReader(encoded)); err == nil {
-			req.Header.Set("Content-Type", "application/json")
-
-			c.dumpRequest(req)
-
-			var res *http.Response
-			if res, err = c.httpClient.Do(req); err == nil {
-				c.dumpResponse(res)
-
-				if err = c.parseResponse(res, &result); err == nil {
-					c.token = &UniversalAuthToken{
-						AccessToken:       result.AccessToken,
-						AccessTokenMaxTTL: result.AccessTokenMaxTTL,
-						ExpiresIn:         result.ExpiresIn,
-						TokenType:         result.TokenType,
-					}
-					c.tokenExpiresOn = time.Now().Add(time.Second * time.Duration(result.ExpiresIn))
-
-					return result, nil
-				}
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/create-client-secret
+func (c *Client) CreateClientSecret(identityID string, params *ParamsCreateClientSecret) (result CreatedClientSecretData, err error) {
+	return c.CreateClientSecretWithContext(context.Background(), identityID, params)
+}
+
+// CreateClientSecretWithContext is the same as `CreateClientSecret` with given context.
+func (c *Client) CreateClientSecretWithContext(ctx context.Context, identityID string, params *ParamsCreateClientSecret) (result CreatedClientSecretData, err error) {
+	if params == nil {
+		params = NewParamsCreateClientSecret()
+	}
+	if err = errors.Join(validateID("identity id", identityID), params.validate()); err != nil {
+		return CreatedClientSecretData{}, fmt.Errorf("failed to create a client secret: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/auth/universal-auth/identities/%s/client-secrets", identityID), AuthMethodNormal, params)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return CreatedClientSecretData{}, fmt.Errorf("failed to create a client secret: %w", err)
+}
+
+// ListClientSecrets lists universal-auth client secrets (which are not revoked) of a machine identity.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/list-client-secrets
+func (c *Client) ListClientSecrets(identityID string) (result ClientSecretsData, err error) {
+	return c.ListClientSecretsWithContext(context.Background(), identityID)
+}
+
+// ListClientSecretsWithContext is the same as `ListClientSecrets` with given context.
+func (c *Client) ListClientSecretsWithContext(ctx context.Context, identityID string) (result ClientSecretsData, err error) {
+	if err = validateID("identity id", identityID); err != nil {
+		return ClientSecretsData{}, fmt.Errorf("failed to list client secrets: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithQueryParams(ctx, "GET", fmt.Sprintf("/v1/auth/universal-auth/identities/%s/client-secrets", identityID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
+			}
+		}
+	}
+
+	return ClientSecretsData{}, fmt.Errorf("failed to list client secrets: %w", err)
+}
+
+// RevokeClientSecret revokes a universal-auth client secret of a machine identity.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/revoke-client-secret
+func (c *Client) RevokeClientSecret(identityID, clientSecretID string) (result ClientSecretData, err error) {
+	return c.RevokeClientSecretWithContext(context.Background(), identityID, clientSecretID)
+}
+
+// RevokeClientSecretWithContext is the same as `RevokeClientSecret` with given context.
+func (c *Client) RevokeClientSecretWithContext(ctx context.Context, identityID, clientSecretID string) (result ClientSecretData, err error) {
+	if err = errors.Join(validateID("identity id", identityID), validateID("client secret id", clientSecretID)); err != nil {
+		return ClientSecretData{}, fmt.Errorf("failed to revoke a client secret: %w", err)
+	}
+
+	var req *http.Request
+	req, err = c.newRequestWithJSONBody(ctx, "POST", fmt.Sprintf("/v1/auth/universal-auth/identities/%s/client-secrets/%s/revoke", identityID, clientSecretID), AuthMethodNormal, nil)
+	if err == nil {
+		var res *http.Response
+		if res, err = c.do(req); err == nil {
+			if err = c.parseResponse(res, &result); err == nil {
+				return result, nil
 			}
 		}
 	}
 
-	return UniversalAuthToken{}, fmt.Errorf("failed to refresh access token: %s", err)
+	return ClientSecretData{}, fmt.Errorf("failed to revoke a client secret: %w", err)
 }
 
-/*
-TODO:
-* Universal Auth
-- [ ] [Attach](https://infisical.com/docs/api-reference/endpoints/universal-auth/attach)
-- [ ] [Retrieve](https://infisical.com/docs/api-reference/endpoints/universal-auth/retrieve)
-- [ ] [Update](https://infisical.com/docs/api-reference/endpoints/universal-auth/update)
-- [ ] [Create Client Secret](https://infisical.com/docs/api-reference/endpoints/universal-auth/create-client-secret)
-- [ ] [List Client Secrets](https://infisical.com/docs/api-reference/endpoints/universal-auth/list-client-secrets)
-- [ ] [Revoke Client Secret](https://infisical.com/docs/api-reference/endpoints/universal-auth/revoke-client-secret)
-- [ ] [Revoke Access Token](https://infisical.com/docs/api-reference/endpoints/universal-auth/revoke-access-token)
-*/
+// RevokeAccessToken revokes an access token.
+//
+// If it is the client's current access token, it is also discarded from the client.
+//
+// https://infisical.com/docs/api-reference/endpoints/universal-auth/revoke-access-token
+func (c *Client) RevokeAccessToken(accessToken string) (err error) {
+	return c.RevokeAccessTokenWithContext(context.Background(), accessToken)
+}
+
+// RevokeAccessTokenWithContext is the same as `RevokeAccessToken` with given context.
+func (c *Client) RevokeAccessTokenWithContext(ctx context.Context, accessToken string) (err error) {
+	if accessToken == "" {
+		return fmt.Errorf("failed to revoke an access token: %w", fmt.Errorf("%w: access token is missing", ErrInvalidParams))
+	}
+
+	if err = c.revokeToken(ctx, accessToken); err != nil {
+		return fmt.Errorf("failed to revoke an access token: %w", err)
+	}
+
+	c.tokenLock.Lock()
+	if c.token != nil && c.token.AccessToken == accessToken {
+		c.clearToken()
+	}
+	c.tokenLock.Unlock()
+
+	return nil
+}
@@ -1,38 +1,200 @@
 package infisical
 
 import (
-	"os"
+	"context"
 	"testing"
+	"time"
+
+	"github.com/meinside/infisical-go/infisicaltest"
 )
 
 func TestUniversalAuth(t *testing.T) {
-	////////////////////////////////
-	// read values from environment variables
-	apiKey := os.Getenv("INFISICAL_API_KEY")
-	workspaceID := os.Getenv("INFISICAL_WORKSPACE_ID")
-	clientID := os.Getenv("INFISICAL_CLIENT_ID")
-	clientSecret := os.Getenv("INFISICAL_CLIENT_SECRET")
-	environment := os.Getenv("INFISICAL_ENVIRONMENT")
-	verbose := os.Getenv("VERBOSE") // NOTE: "true" or not
-
 	////////////////////////////////
 	// initialize client
-	if apiKey == "" || clientID == "" || clientSecret == "" || workspaceID == "" || environment == "" {
-		t.Fatalf("no environment variables: `INFISICAL_API_KEY`, `INFISICAL_CLIENT_ID`, `INFISICAL_CLIENT_SECRET`, `INFISICAL_WORKSPACE_ID`, or `INFISICAL_ENVIRONMENT` were found.")
-	}
-	client := NewClient(apiKey, clientID, clientSecret)
-	client.Verbose = (verbose == "true")
+	client := newTestTarget(t).newClient(t)
 
 	////////////////////////////////
 	// test api functions
 
 	// login
-	if _, err := client.login(); err != nil {
+	if _, err := client.login(context.Background()); err != nil {
 		t.Errorf("failed to login with universal auth: %s", err)
 	}
 
 	// refresh
-	if _, err := client.refresh(); err != nil {
+	if _, err := client.refresh(context.Background()); err != nil {
 		t.Errorf("failed to refresh token with universal auth: %s", err)
 	}
 }
+
+func TestUniversalAuthConfiguration(t *testing.T) {
+	////////////////////////////////
+	// run a fake server
+	target := newTestTarget(t)
+	server := target.fakeServer(t)
+
+	client := target.newClient(t)
+
+	var identityID string
+	if created, err := client.CreateIdentity(infisicaltest.DefaultOrganizationID, "rotated-service", OrganizationRoleMember); err != nil {
+		t.Fatalf("failed to create an identity: %s", err)
+	} else {
+		identityID = created.Identity.ID
+	}
+
+	// (attach universal auth)
+	var clientID string
+	if attached, err := client.AttachUniversalAuth(identityID, NewParamsUniversalAuth().
+		SetClientSecretTrustedIPs("127.0.0.1/32", "::1").
+		SetAccessTokenTTL(10*time.Minute).
+		SetAccessTokenMaxTTL(time.Hour),
+	); err != nil {
+		t.Fatalf("failed to attach universal auth: %s", err)
+	} else if ua := attached.IdentityUniversalAuth; ua.ClientID == "" ||
+		ua.IdentityID != identityID ||
+		ua.AccessTokenTTL != 600 ||
+		ua.AccessTokenMaxTTL != 3600 ||
+		len(ua.ClientSecretTrustedIPs) != 2 || ua.ClientSecretTrustedIPs[0].CIDR() != "127.0.0.1/32" || ua.ClientSecretTrustedIPs[1].CIDR() != "::1" {
+		t.Errorf("unexpected universal auth: %+v", ua)
+	} else {
+		clientID = ua.ClientID
+	}
+	if _, err := client.AttachUniversalAuth(identityID, nil); err == nil {
+		t.Errorf("universal auth should not be attached twice")
+	}
+	if identity, err := client.GetIdentity(identityID); err != nil {
+		t.Errorf("failed to get an identity: %s", err)
+	} else if len(identity.Identity.Identity.AuthMethods) != 1 || identity.Identity.Identity.AuthMethods[0] != "universal-auth" {
+		t.Errorf("unexpected auth methods: %v", identity.Identity.Identity.AuthMethods)
+	}
+
+	// (update and get universal auth)
+	if _, err := client.UpdateUniversalAuth(identityID, NewParamsUniversalAuth().SetAccessTokenNumUsesLimit(2)); err != nil {
+		t.Errorf("failed to update universal auth: %s", err)
+	}
+	if ua, err := client.GetUniversalAuth(identityID); err != nil {
+		t.Errorf("failed to get universal auth: %s", err)
+	} else if ua.IdentityUniversalAuth.AccessTokenNumUsesLimit != 2 || ua.IdentityUniversalAuth.AccessTokenTTL != 600 {
+		t.Errorf("unexpected universal auth: %+v", ua.IdentityUniversalAuth)
+	}
+
+	// (create a client secret with TTL and usage limit)
+	var clientSecret, clientSecretID string
+	if created, err := client.CreateClientSecret(identityID, NewParamsCreateClientSecret().
+		SetDescription("rotated on 2026-10-17").
+		SetNumUsesLimit(1).
+		SetTTL(24*time.Hour),
+	); err != nil {
+		t.Fatalf("failed to create a client secret: %s", err)
+	} else if created.ClientSecret == "" || created.ClientSecretData.TTL != 86400 || created.ClientSecretData.NumUsesLimit != 1 {
+		t.Errorf("unexpected client secret: %+v", created)
+	} else if expiresAt, ok := created.ClientSecretData.ExpiresAt(); !ok || time.Until(expiresAt) < 23*time.Hour {
+		t.Errorf("unexpected expiry of client secret: %s", expiresAt)
+	} else {
+		clientSecret, clientSecretID = created.ClientSecret, created.ClientSecretData.ID
+	}
+
+	// (log in with the client secret, until its usage limit)
+	serviceClient := NewClientWithoutAPIKey(clientID, clientSecret)
+	serviceClient.SetAPIBaseURL(server.URL)
+	if _, err := serviceClient.login(context.Background()); err != nil {
+		t.Errorf("failed to login with the created client secret: %s", err)
+	}
+	if secrets, err := client.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	} else if len(secrets.ClientSecretData) != 1 || secrets.ClientSecretData[0].NumUses != 1 {
+		t.Errorf("unexpected client secrets: %+v", secrets.ClientSecretData)
+	} else if remaining, ok := secrets.ClientSecretData[0].RemainingUses(); !ok || remaining != 0 {
+		t.Errorf("unexpected remaining uses of client secret: %d", remaining)
+	}
+	if _, err := serviceClient.login(context.Background()); err == nil {
+		t.Errorf("client secret should not be used over its usage limit")
+	}
+
+	// (revoke a client secret)
+	if revoked, err := client.RevokeClientSecret(identityID, clientSecretID); err != nil {
+		t.Errorf("failed to revoke a client secret: %s", err)
+	} else if !revoked.ClientSecretData.IsRevoked {
+		t.Errorf("unexpected client secret: %+v", revoked.ClientSecretData)
+	}
+	if secrets, err := client.ListClientSecrets(identityID); err != nil {
+		t.Errorf("failed to list client secrets: %s", err)
+	} else if len(secrets.ClientSecretData) != 0 {
+		t.Errorf("revoked client secrets should not be listed: %+v", secrets.ClientSecretData)
+	}
+
+	// (untrusted IPs cannot use client secrets)
+	if created, err := client.CreateClientSecret(identityID, nil); err != nil {
+		t.Errorf("failed to create a client secret: %s", err)
+	} else {
+		if _, err := client.UpdateUniversalAuth(identityID, NewParamsUniversalAuth().SetClientSecretTrustedIPs("10.0.0.0/8")); err != nil {
+			t.Errorf("failed to update universal auth: %s", err)
+		}
+		untrusted := NewClientWithoutAPIKey(clientID, created.ClientSecret)
+		untrusted.SetAPIBaseURL(server.URL)
+		if _, err := untrusted.login(context.Background()); err == nil {
+			t.Errorf("client secret should not be used from untrusted IPs")
+		}
+	}
+
+	// (access tokens are limited by their number of uses)
+	limited := NewClientWithAuthenticator(NewAccessTokenAuth(server.IssueAccessToken(identityID)))
+	limited.SetAPIBaseURL(server.URL)
+	for i := 0; i < 2; i++ {
+		if _, err := limited.GetIdentity(identityID); err != nil {
+			t.Errorf("failed to use an access token: %s", err)
+		}
+	}
+	if _, err := limited.GetIdentity(identityID); err == nil {
+		t.Errorf("access token should not be used over its usage limit")
+	}
+
+	// (revoke an access token)
+	token, err := client.login(context.Background())
+	if err != nil {
+		t.Fatalf("failed to login: %s", err)
+	}
+	if err := client.RevokeAccessToken(token.AccessToken); err != nil {
+		t.Errorf("failed to revoke an access token: %s", err)
+	}
+	client.tokenLock.Lock()
+	if client.token != nil {
+		t.Errorf("revoked access token should be discarded from the client")
+	}
+	client.tokenLock.Unlock()
+	revoked := NewClientWithAuthenticator(NewAccessTokenAuth(token.AccessToken))
+	revoked.SetAPIBaseURL(server.URL)
+	if _, err := revoked.GetIdentity(identityID); err == nil {
+		t.Errorf("revoked access token should not be used")
+	}
+	if err := client.RevokeAccessToken(token.AccessToken); err == nil {
+		t.Errorf("revoked access token should not be revoked again")
+	}
+
+	// (invalid params are rejected before sending requests)
+	assertRejectedBeforeRequest(t, map[string]func(*Client) error{
+		"invalid trusted ip": func(client *Client) error {
+			_, err := client.AttachUniversalAuth(identityID, NewParamsUniversalAuth().SetAccessTokenTrustedIPs("10.0.0.0/33"))
+			return err
+		},
+		"ttl over max ttl": func(client *Client) error {
+			_, err := client.UpdateUniversalAuth(identityID, NewParamsUniversalAuth().SetAccessTokenTTL(2*time.Hour).SetAccessTokenMaxTTL(time.Hour))
+			return err
+		},
+		"nothing to update": func(client *Client) error {
+			_, err := client.UpdateUniversalAuth(identityID, nil)
+			return err
+		},
+		"negative number of uses": func(client *Client) error {
+			_, err := client.CreateClientSecret(identityID, NewParamsCreateClientSecret().SetNumUsesLimit(-1))
+			return err
+		},
+		"missing client secret id": func(client *Client) error {
+			_, err := client.RevokeClientSecret(identityID, "")
+			return err
+		},
+		"missing access token": func(client *Client) error {
+			return client.RevokeAccessToken("")
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package infisical
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -11,22 +12,23 @@ import (
 //
 // https://infisical.com/docs/api-reference/endpoints/users/my-organizations
 func (c *Client) RetrieveOrganizations() (result OrganizationsData, err error) {
+	return c.RetrieveOrganizationsWithContext(context.Background())
+}
+
+// RetrieveOrganizationsWithContext is the same as `RetrieveOrganizations` with given context.
+func (c *Client) RetrieveOrganizationsWithContext(ctx context.Context) (result OrganizationsData, err error) {
 	path := "/v2/users/me/organizations"
 
 	var req *http.Request
-	req, err = c.newRequestWithQueryParams("GET", path, AuthMethodAPIKeyOnly, nil)
+	req, err = c.newRequestWithQueryParams(ctx, "GET", path, AuthMethodAPIKeyOnly, nil)
 	if err == nil {
-		c.dumpRequest(req)
-
 		var res *http.Response
-		if res, err = c.httpClient.Do(req); err == nil {
-			c.dumpResponse(res)
-
+		if res, err = c.do(req); err == nil {
 			if err = c.parseResponse(res, &result); err == nil {
 				return result, nil
 			}
 		}
 	}
 
-	return OrganizationsData{}, fmt.Errorf("failed to retrieve organizations: %s", err)
+	return OrganizationsData{}, fmt.Errorf("failed to retrieve organizations: %w", err)
 }
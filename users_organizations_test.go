@@ -1,26 +1,13 @@
 package infisical
 
 import (
-	"os"
 	"testing"
 )
 
 func TestUsersAndOrganizations(t *testing.T) {
-	////////////////////////////////
-	// read values from environment variables
-	var apiKey, clientID, clientSecret, verbose string
-	apiKey = os.Getenv("INFISICAL_API_KEY")
-	clientID = os.Getenv("INFISICAL_CLIENT_ID")
-	clientSecret = os.Getenv("INFISICAL_CLIENT_SECRET")
-	verbose = os.Getenv("VERBOSE") // NOTE: "true" or not
-
 	////////////////////////////////
 	// initialize client
-	if apiKey == "" || clientID == "" || clientSecret == "" {
-		t.Fatalf("no environment variables: `INFISICAL_API_KEY`, `INFISICAL_CLIENT_ID`, or `INFISICAL_CLIENT_SECRET` were found.")
-	}
-	client := NewClient(apiKey, clientID, clientSecret)
-	client.Verbose = (verbose == "true")
+	client := newTestTarget(t).newClient(t)
 
 	////////////////////////////////
 	// test api functions